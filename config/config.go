@@ -6,15 +6,19 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type config struct {
-	Db     *dbConfig
-	Server *serverConfig
-	Auth   *authConfig
-	Header string
+	Db      *dbConfig
+	Server  *serverConfig
+	Auth    *authConfig
+	TestRun *testRunConfig
+	Ingest  *ingestConfig
+	Reports *reportsConfig
+	Header  string
 }
 
 type dbConfig struct {
@@ -34,10 +38,46 @@ type serverConfig struct {
 }
 
 type authConfig struct {
-	JSONWebKeysEndpoint string `mapstructure:"json-web-keys-endpoint"`
-	TokenEndpoint       string `mapstructure:"token-endpoint"`
-	Enabled             bool   `mapstructure:"enabled"`
-	ScopeClaimName      string `mapstructure:"scope-claim-name"`
+	JSONWebKeysEndpoint   string `mapstructure:"json-web-keys-endpoint"`
+	TokenEndpoint         string `mapstructure:"token-endpoint"`
+	Enabled               bool   `mapstructure:"enabled"`
+	ScopeClaimName        string `mapstructure:"scope-claim-name"`
+	GroupsClaimName       string `mapstructure:"groups-claim-name"`
+	OrganizationClaimName string `mapstructure:"organization-claim-name"`
+}
+
+type testRunConfig struct {
+	OpenTimeout    time.Duration `mapstructure:"open-timeout"`
+	ReaperInterval time.Duration `mapstructure:"reaper-interval"`
+}
+
+type ingestConfig struct {
+	Workers      int           `mapstructure:"workers"`
+	PollInterval time.Duration `mapstructure:"poll-interval"`
+	JobTimeout   time.Duration `mapstructure:"job-timeout"`
+}
+
+type reportsConfig struct {
+	DefaultBranch                string        `mapstructure:"default-branch"`
+	SlowSpecFactor               float64       `mapstructure:"slow-spec-factor"`
+	DurationRegressionThreshold  float64       `mapstructure:"duration-regression-threshold"`
+	DurationRegressionMinSamples int           `mapstructure:"duration-regression-min-samples"`
+	DurationRegressionWindow     int           `mapstructure:"duration-regression-window"`
+	Health                       *healthConfig `mapstructure:"health"`
+}
+
+type healthConfig struct {
+	Weights         healthWeights `mapstructure:"weights"`
+	StaleDays       int           `mapstructure:"stale-days"`
+	LongFailingRuns int           `mapstructure:"long-failing-runs"`
+}
+
+type healthWeights struct {
+	PassRate      float64 `mapstructure:"pass-rate"`
+	Flakiness     float64 `mapstructure:"flakiness"`
+	DurationTrend float64 `mapstructure:"duration-trend"`
+	Staleness     float64 `mapstructure:"staleness"`
+	LongFailing   float64 `mapstructure:"long-failing"`
 }
 
 var configuration *config
@@ -88,6 +128,45 @@ func LoadConfig() (*config, error) {
 	if os.Getenv("SCOPE_CLAIM_NAME") != "" {
 		configuration.Auth.ScopeClaimName = os.Getenv("SCOPE_CLAIM_NAME")
 	}
+	if os.Getenv("GROUPS_CLAIM_NAME") != "" {
+		configuration.Auth.GroupsClaimName = os.Getenv("GROUPS_CLAIM_NAME")
+	}
+	if os.Getenv("ORGANIZATION_CLAIM_NAME") != "" {
+		configuration.Auth.OrganizationClaimName = os.Getenv("ORGANIZATION_CLAIM_NAME")
+	}
+	if os.Getenv("TESTRUN_OPEN_TIMEOUT") != "" {
+		if timeout, err := time.ParseDuration(os.Getenv("TESTRUN_OPEN_TIMEOUT")); err == nil {
+			configuration.TestRun.OpenTimeout = timeout
+		}
+	}
+	if os.Getenv("TESTRUN_REAPER_INTERVAL") != "" {
+		if interval, err := time.ParseDuration(os.Getenv("TESTRUN_REAPER_INTERVAL")); err == nil {
+			configuration.TestRun.ReaperInterval = interval
+		}
+	}
+	if os.Getenv("INGEST_WORKERS") != "" {
+		if workers, err := strconv.Atoi(os.Getenv("INGEST_WORKERS")); err == nil {
+			configuration.Ingest.Workers = workers
+		}
+	}
+	if os.Getenv("REPORTS_DEFAULT_BRANCH") != "" {
+		configuration.Reports.DefaultBranch = os.Getenv("REPORTS_DEFAULT_BRANCH")
+	}
+	if os.Getenv("REPORTS_SLOW_SPEC_FACTOR") != "" {
+		if factor, err := strconv.ParseFloat(os.Getenv("REPORTS_SLOW_SPEC_FACTOR"), 64); err == nil {
+			configuration.Reports.SlowSpecFactor = factor
+		}
+	}
+	if os.Getenv("REPORTS_DURATION_REGRESSION_THRESHOLD") != "" {
+		if threshold, err := strconv.ParseFloat(os.Getenv("REPORTS_DURATION_REGRESSION_THRESHOLD"), 64); err == nil {
+			configuration.Reports.DurationRegressionThreshold = threshold
+		}
+	}
+	if os.Getenv("REPORTS_DURATION_REGRESSION_MIN_SAMPLES") != "" {
+		if samples, err := strconv.Atoi(os.Getenv("REPORTS_DURATION_REGRESSION_MIN_SAMPLES")); err == nil {
+			configuration.Reports.DurationRegressionMinSamples = samples
+		}
+	}
 	if os.Getenv("FERN_HEADER_NAME") != "" {
 		configuration.Header = os.Getenv("FERN_HEADER_NAME")
 	}
@@ -107,6 +186,18 @@ func GetAuth() *authConfig {
 	return configuration.Auth
 }
 
+func GetTestRun() *testRunConfig {
+	return configuration.TestRun
+}
+
+func GetIngest() *ingestConfig {
+	return configuration.Ingest
+}
+
+func GetReports() *reportsConfig {
+	return configuration.Reports
+}
+
 func GetHeaderName() string {
 	return configuration.Header
 }
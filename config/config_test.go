@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"os"
+	"time"
 
 	"github.com/guidewire/fern-reporter/config"
 	. "github.com/onsi/ginkgo/v2"
@@ -26,6 +27,8 @@ var _ = Describe("When LoadConfig is invoked", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(appConfig.Auth.JSONWebKeysEndpoint).To(Equal(""))
+			Expect(appConfig.Auth.GroupsClaimName).To(Equal("groups"))
+			Expect(appConfig.Auth.OrganizationClaimName).To(Equal("org"))
 			Expect(appConfig.Server.Port).To(Equal(":8080"))
 			Expect(appConfig.Db.Driver).To(Equal("postgres"))
 			Expect(appConfig.Db.Host).To(Equal("localhost"))
@@ -37,6 +40,23 @@ var _ = Describe("When LoadConfig is invoked", func() {
 			Expect(appConfig.Db.MaxOpenConns).To(Equal(100))
 			Expect(appConfig.Db.MaxIdleConns).To(Equal(10))
 			Expect(appConfig.Header).To(Equal("Fern Acceptance Test Report"))
+			Expect(appConfig.TestRun.OpenTimeout).To(Equal(6 * time.Hour))
+			Expect(appConfig.TestRun.ReaperInterval).To(Equal(5 * time.Minute))
+			Expect(appConfig.Ingest.Workers).To(Equal(4))
+			Expect(appConfig.Ingest.PollInterval).To(Equal(time.Second))
+			Expect(appConfig.Ingest.JobTimeout).To(Equal(30 * time.Minute))
+			Expect(appConfig.Reports.DefaultBranch).To(Equal("main"))
+			Expect(appConfig.Reports.SlowSpecFactor).To(Equal(1.5))
+			Expect(appConfig.Reports.DurationRegressionThreshold).To(Equal(3.0))
+			Expect(appConfig.Reports.DurationRegressionMinSamples).To(Equal(10))
+			Expect(appConfig.Reports.DurationRegressionWindow).To(Equal(30))
+			Expect(appConfig.Reports.Health.Weights.PassRate).To(Equal(0.35))
+			Expect(appConfig.Reports.Health.Weights.Flakiness).To(Equal(0.2))
+			Expect(appConfig.Reports.Health.Weights.DurationTrend).To(Equal(0.1))
+			Expect(appConfig.Reports.Health.Weights.Staleness).To(Equal(0.15))
+			Expect(appConfig.Reports.Health.Weights.LongFailing).To(Equal(0.2))
+			Expect(appConfig.Reports.Health.StaleDays).To(Equal(14))
+			Expect(appConfig.Reports.Health.LongFailingRuns).To(Equal(5))
 		})
 
 		It("should get non-nil DB", func() {
@@ -58,12 +78,30 @@ var _ = Describe("When LoadConfig is invoked", func() {
 		os.Setenv("AUTH_JSON_WEB_KEYS_ENDPOINT", "https://test-idp-base-url.com/oauth2/abc123/v1/keys")
 		os.Setenv("AUTH_ENABLED", "false")
 		os.Setenv("SCOPE_CLAIM_NAME", "fern_scope")
+		os.Setenv("GROUPS_CLAIM_NAME", "fern_groups")
+		DeferCleanup(os.Unsetenv, "GROUPS_CLAIM_NAME")
+		os.Setenv("ORGANIZATION_CLAIM_NAME", "fern_org")
+		DeferCleanup(os.Unsetenv, "ORGANIZATION_CLAIM_NAME")
 		os.Setenv("FERN_USERNAME", "fern")
 		os.Setenv("FERN_PASSWORD", "fern")
 		os.Setenv("FERN_HOST", "localhost")
 		os.Setenv("FERN_PORT", "5432")
 		os.Setenv("FERN_DATABASE", "fern")
 		os.Setenv("FERN_HEADER_NAME", "Custom Fern Report Header")
+		os.Setenv("TESTRUN_OPEN_TIMEOUT", "30m")
+		DeferCleanup(os.Unsetenv, "TESTRUN_OPEN_TIMEOUT")
+		os.Setenv("TESTRUN_REAPER_INTERVAL", "1m")
+		DeferCleanup(os.Unsetenv, "TESTRUN_REAPER_INTERVAL")
+		os.Setenv("INGEST_WORKERS", "8")
+		DeferCleanup(os.Unsetenv, "INGEST_WORKERS")
+		os.Setenv("REPORTS_DEFAULT_BRANCH", "trunk")
+		DeferCleanup(os.Unsetenv, "REPORTS_DEFAULT_BRANCH")
+		os.Setenv("REPORTS_SLOW_SPEC_FACTOR", "3")
+		DeferCleanup(os.Unsetenv, "REPORTS_SLOW_SPEC_FACTOR")
+		os.Setenv("REPORTS_DURATION_REGRESSION_THRESHOLD", "2.5")
+		DeferCleanup(os.Unsetenv, "REPORTS_DURATION_REGRESSION_THRESHOLD")
+		os.Setenv("REPORTS_DURATION_REGRESSION_MIN_SAMPLES", "5")
+		DeferCleanup(os.Unsetenv, "REPORTS_DURATION_REGRESSION_MIN_SAMPLES")
 
 		// v := viper.New()
 		result, err := config.LoadConfig()
@@ -78,7 +116,16 @@ var _ = Describe("When LoadConfig is invoked", func() {
 		Expect(result.Auth.JSONWebKeysEndpoint).To(Equal("https://test-idp-base-url.com/oauth2/abc123/v1/keys"))
 		Expect(result.Auth.Enabled).To(Equal(false))
 		Expect(result.Auth.ScopeClaimName).To(Equal("fern_scope"))
+		Expect(result.Auth.GroupsClaimName).To(Equal("fern_groups"))
+		Expect(result.Auth.OrganizationClaimName).To(Equal("fern_org"))
 		Expect(result.Header).To(Equal("Custom Fern Report Header"))
 		Expect(result.Header).To(Equal("Custom Fern Report Header"))
+		Expect(result.TestRun.OpenTimeout).To(Equal(30 * time.Minute))
+		Expect(result.TestRun.ReaperInterval).To(Equal(time.Minute))
+		Expect(result.Ingest.Workers).To(Equal(8))
+		Expect(result.Reports.DefaultBranch).To(Equal("trunk"))
+		Expect(result.Reports.SlowSpecFactor).To(Equal(3.0))
+		Expect(result.Reports.DurationRegressionThreshold).To(Equal(2.5))
+		Expect(result.Reports.DurationRegressionMinSamples).To(Equal(5))
 	})
 })
@@ -18,6 +18,7 @@ import (
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
 	"github.com/guidewire/fern-reporter/pkg/api/routers"
 	"github.com/guidewire/fern-reporter/pkg/auth"
 	"github.com/guidewire/fern-reporter/pkg/db"
@@ -32,6 +33,8 @@ import (
 
 //go:embed pkg/views/test_runs.html
 //go:embed pkg/views/insights.html
+//go:embed pkg/views/test_run_diff.html
+//go:embed pkg/views/health.html
 var testRunsTemplate embed.FS
 
 func main() {
@@ -48,6 +51,10 @@ func initConfig() {
 
 func initDb() {
 	db.Initialize()
+	testRunConfig := config.GetTestRun()
+	handlers.StartTestRunReaper(context.Background(), db.GetDb(), testRunConfig.OpenTimeout, testRunConfig.ReaperInterval)
+	ingestConfig := config.GetIngest()
+	handlers.StartIngestWorkers(context.Background(), db.GetDb(), ingestConfig.Workers, ingestConfig.PollInterval, ingestConfig.JobTimeout)
 }
 
 func initServer() {
@@ -67,7 +74,7 @@ func initServer() {
 
 	router.Use(cors.New(cors.Config{
 		AllowMethods:     []string{"GET", "POST", "DELETE", "PUT"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "ACCESS_TOKEN", "User-Agent"},
+		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "ACCESS_TOKEN", "User-Agent", "X-Fern-Api-Key", "Idempotency-Key"},
 		AllowCredentials: true,
 		AllowOriginFunc:  isAllowedOrigin,
 		MaxAge:           12 * time.Hour,
@@ -78,7 +85,7 @@ func initServer() {
 		"FormatDate":        utils.FormatDate,
 	}
 
-	templ, err := template.New("").Funcs(funcMap).ParseFS(testRunsTemplate, "pkg/views/test_runs.html", "pkg/views/insights.html")
+	templ, err := template.New("").Funcs(funcMap).ParseFS(testRunsTemplate, "pkg/views/test_runs.html", "pkg/views/insights.html", "pkg/views/test_run_diff.html", "pkg/views/health.html")
 	if err != nil {
 		log.Fatalf("error parsing templates: %v", err)
 	}
@@ -87,7 +94,11 @@ func initServer() {
 	// router.LoadHTMLGlob("pkg/views/*")
 	routers.RegisterRouters(router)
 
-	router.POST("/query", GraphqlHandler(db.GetDb()))
+	if config.GetAuth().Enabled {
+		router.POST("/query", auth.ScopeMiddleware(auth.NewDefaultProjectLookup(db.GetDb())), GraphqlHandler(db.GetDb()))
+	} else {
+		router.POST("/query", GraphqlHandler(db.GetDb()))
+	}
 	router.GET("/", PlaygroundHandler("/query"))
 	err = router.Run(serverConfig.Port)
 	if err != nil {
@@ -132,7 +143,11 @@ func configJWTMiddleware(router *gin.Engine) {
 
 	jwtValidator := &auth.DefaultJWTValidator{}
 
-	router.Use(auth.JWTMiddleware(authConfig.JSONWebKeysEndpoint, keyFetcher, jwtValidator))
+	apiKeyVerifier := auth.NewDefaultAPIKeyVerifier(db.GetDb())
+
+	router.Use(auth.APIKeyMiddleware(apiKeyVerifier, auth.JWTMiddleware(authConfig.JSONWebKeysEndpoint, keyFetcher, jwtValidator)))
+	router.Use(auth.OrganizationMiddleware(auth.NewDefaultOrganizationResolver(db.GetDb())))
+	router.Use(auth.RoleMiddleware(auth.NewDefaultRoleResolver(db.GetDb())))
 	log.Println("JWT Middleware configured successfully.")
 }
 
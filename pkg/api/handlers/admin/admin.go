@@ -0,0 +1,38 @@
+// Package admin holds the handlers of the /api/admin routes, which only Fern
+// administrators may call. Administrators manage the role bindings and read
+// the audit log of their own organization.
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"gorm.io/gorm"
+)
+
+type AdminHandler struct {
+	db *gorm.DB
+}
+
+// NewAdminHandler initializes AdminHandler
+func NewAdminHandler(db *gorm.DB) *AdminHandler {
+	return &AdminHandler{db: db}
+}
+
+// organizationID returns the organization of the caller, or the default
+// organization when the caller is not limited to one.
+func organizationID(c *gin.Context) uint64 {
+	if id, ok := auth.OrganizationFromContext(c); ok {
+		return id
+	}
+	return models.DefaultOrganizationID
+}
+
+// scopeOrganization limits a query to the rows whose column holds the
+// caller's organization, when the caller is limited to one.
+func scopeOrganization(c *gin.Context, query *gorm.DB, column string) *gorm.DB {
+	if id, ok := auth.OrganizationFromContext(c); ok {
+		return query.Where(column+" = ?", id)
+	}
+	return query
+}
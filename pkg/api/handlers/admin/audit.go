@@ -0,0 +1,93 @@
+package admin
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// auditColumnFilters maps the query parameters that filter audit events by
+// equality to their columns.
+var auditColumnFilters = map[string]string{
+	"actor":       "actor",
+	"actor_type":  "actor_type",
+	"method":      "method",
+	"route":       "route",
+	"target_type": "target_type",
+	"target_id":   "target_id",
+	"project_id":  "project_id",
+}
+
+// GetAuditEvents lists the audit events of the caller's organization, newest
+// first. Optional query parameters: actor, actor_type, method, route,
+// target_type, target_id, project_id (a project UUID), start_time and
+// end_time (YYYY-MM-DD, both inclusive), limit (100 by default, at most 1000)
+// and before, an event ID to page from.
+func (h *AdminHandler) GetAuditEvents(c *gin.Context) {
+	query, limit, err := h.auditQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	events := []models.AuditEvent{}
+	if err := query.Order("id DESC").Limit(limit).Find(&events).Error; err != nil {
+		log.Printf("Error fetching audit events: %s", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching audit events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"events": events})
+}
+
+func (h *AdminHandler) auditQuery(c *gin.Context) (*gorm.DB, int, error) {
+	query := scopeOrganization(c, h.db.Model(&models.AuditEvent{}), "organization_id")
+	limit := defaultAuditLimit
+
+	for key, values := range c.Request.URL.Query() {
+		value := values[0]
+		if column, ok := auditColumnFilters[key]; ok {
+			query = query.Where(column+" = ?", value)
+			continue
+		}
+
+		switch key {
+		case "start_time", "end_time":
+			day, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return nil, 0, fmt.Errorf("invalid %s format (expected YYYY-MM-DD): %v", key, err)
+			}
+			if key == "start_time" {
+				query = query.Where("created_at >= ?", day)
+			} else {
+				query = query.Where("created_at < ?", day.AddDate(0, 0, 1))
+			}
+		case "limit":
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed <= 0 || parsed > maxAuditLimit {
+				return nil, 0, fmt.Errorf("limit must be between 1 and %d", maxAuditLimit)
+			}
+			limit = parsed
+		case "before":
+			id, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, 0, fmt.Errorf("invalid before event ID %q", value)
+			}
+			query = query.Where("id < ?", id)
+		default:
+			return nil, 0, fmt.Errorf("invalid query parameter: '%s'", key)
+		}
+	}
+	return query, limit, nil
+}
@@ -0,0 +1,136 @@
+package admin_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers/admin"
+	"github.com/guidewire/fern-reporter/pkg/audit"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Audit events", func() {
+	var (
+		auditDb *gorm.DB
+		router  *gin.Engine
+	)
+
+	request := func(method string, path string, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	listEvents := func(query string) []models.AuditEvent {
+		w := request("GET", "/api/admin/audit"+query, "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var response struct {
+			Events []models.AuditEvent `json:"events"`
+		}
+		Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+		return response.Events
+	}
+
+	targetIDs := func(events []models.AuditEvent) []string {
+		ids := []string{}
+		for _, event := range events {
+			ids = append(ids, event.TargetID)
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		var err error
+		auditDb, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auditDb.AutoMigrate(&models.ProjectDetails{}, &models.RoleBinding{}, &models.AuditEvent{})).To(Succeed())
+
+		cart := models.ProjectDetails{Name: "Cart"}
+		Expect(auditDb.Create(&cart).Error).NotTo(HaveOccurred())
+		Expect(auditDb.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "project-uuid", cart.ID).Error).NotTo(HaveOccurred())
+
+		gin.SetMode(gin.TestMode)
+		router = gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("subject", "alice")
+		})
+		router.Use(audit.Middleware(audit.NewDefaultRecorder(auditDb)))
+		handler := admin.NewAdminHandler(auditDb)
+		router.GET("/api/admin/audit", handler.GetAuditEvents)
+		router.POST("/api/admin/roles", handler.CreateRoleBinding)
+		router.DELETE("/api/admin/roles/:id", handler.DeleteRoleBinding)
+	})
+
+	It("should record role binding changes", func() {
+		w := request("POST", "/api/admin/roles", `{"role": "viewer", "subject_type": "user", "subject": "bob", "project_id": "project-uuid"}`)
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		var created models.RoleBinding
+		Expect(json.Unmarshal(w.Body.Bytes(), &created)).To(Succeed())
+		Expect(request("DELETE", fmt.Sprintf("/api/admin/roles/%d", created.ID), "").Code).To(Equal(http.StatusOK))
+
+		events := listEvents("")
+		Expect(events).To(HaveLen(2))
+
+		deleted, bound := events[0], events[1]
+		Expect(bound.Actor).To(Equal("alice"))
+		Expect(bound.Route).To(Equal("/api/admin/roles"))
+		Expect(bound.TargetType).To(Equal(audit.TargetRoleBinding))
+		Expect(bound.TargetID).To(Equal(fmt.Sprint(created.ID)))
+		Expect(bound.ProjectID).To(Equal("project-uuid"))
+		Expect(bound.Before).To(BeNil())
+		Expect(bound.After).To(HaveKeyWithValue("subject", "bob"))
+		Expect(bound.Changes).To(HaveKeyWithValue("role", models.AuditChange{After: "viewer"}))
+
+		Expect(deleted.Route).To(Equal("/api/admin/roles/:id"))
+		Expect(deleted.ProjectID).To(Equal("project-uuid"))
+		Expect(deleted.Before).To(HaveKeyWithValue("subject", "bob"))
+		Expect(deleted.After).To(BeNil())
+		Expect(deleted.Changes).To(HaveKeyWithValue("role", models.AuditChange{Before: "viewer"}))
+	})
+
+	It("should filter events", func() {
+		now := time.Now()
+		for i, event := range []models.AuditEvent{
+			{ActorType: audit.ActorTypeUser, Actor: "alice", Method: "DELETE", Route: "/api/testrun/:id", TargetType: audit.TargetTestRun, TargetID: "1", ProjectID: "project-uuid", CreatedAt: now.AddDate(0, 0, -10)},
+			{ActorType: audit.ActorTypeAPIKey, Actor: "7", Method: "POST", Route: "/api/testrun/", TargetType: audit.TargetTestRun, TargetID: "2", ProjectID: "project-uuid", CreatedAt: now.AddDate(0, 0, -5)},
+			{ActorType: audit.ActorTypeUser, Actor: "bob", Method: "PUT", Route: "/api/project/:uuid", TargetType: audit.TargetProject, TargetID: "other-uuid", ProjectID: "other-uuid", CreatedAt: now},
+		} {
+			event.Path = fmt.Sprintf("/path/%d", i)
+			Expect(auditDb.Create(&event).Error).NotTo(HaveOccurred())
+		}
+
+		Expect(targetIDs(listEvents(""))).To(Equal([]string{"other-uuid", "2", "1"}))
+		Expect(targetIDs(listEvents("?actor=alice"))).To(Equal([]string{"1"}))
+		Expect(targetIDs(listEvents("?actor_type=api_key"))).To(Equal([]string{"2"}))
+		Expect(targetIDs(listEvents("?method=DELETE&target_type=test_run"))).To(Equal([]string{"1"}))
+		Expect(targetIDs(listEvents("?route=/api/testrun/"))).To(Equal([]string{"2"}))
+		Expect(targetIDs(listEvents("?project_id=project-uuid"))).To(Equal([]string{"2", "1"}))
+		Expect(targetIDs(listEvents("?target_id=other-uuid"))).To(Equal([]string{"other-uuid"}))
+		Expect(targetIDs(listEvents("?start_time=" + now.AddDate(0, 0, -6).Format("2006-01-02")))).To(Equal([]string{"other-uuid", "2"}))
+		Expect(targetIDs(listEvents("?end_time=" + now.AddDate(0, 0, -5).Format("2006-01-02")))).To(Equal([]string{"2", "1"}))
+		Expect(targetIDs(listEvents("?limit=1"))).To(Equal([]string{"other-uuid"}))
+		Expect(targetIDs(listEvents("?limit=1&before=3"))).To(Equal([]string{"2"}))
+	})
+
+	DescribeTable("should reject invalid filters",
+		func(query string) {
+			Expect(request("GET", "/api/admin/audit"+query, "").Code).To(Equal(http.StatusBadRequest))
+		},
+		Entry("unknown parameter", "?user=alice"),
+		Entry("invalid date", "?start_time=yesterday"),
+		Entry("limit too large", "?limit=5000"),
+		Entry("invalid before", "?before=abc"),
+	)
+})
@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/audit"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+// OrganizationRequest creates an organization.
+type OrganizationRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// manageOrganizations reports whether the caller may manage organizations,
+// which only the administrators of the default organization may, writing an
+// error response when it may not.
+func manageOrganizations(c *gin.Context) bool {
+	if organizationID(c) != models.DefaultOrganizationID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "organizations are managed from the default organization"})
+		return false
+	}
+	return true
+}
+
+// CreateOrganization creates an organization. Its projects are created and
+// read by callers whose token names it in the organization claim.
+func (h *AdminHandler) CreateOrganization(c *gin.Context) {
+	if !manageOrganizations(c) {
+		return
+	}
+
+	var request OrganizationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	organization := models.Organization{Name: strings.TrimSpace(request.Name)}
+	if organization.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name must not be empty"})
+		return
+	}
+
+	var count int64
+	if err := h.db.Model(&models.Organization{}).Where("name = ?", organization.Name).Count(&count).Error; err != nil {
+		log.Printf("Failed to check for duplicate organizations: %s", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create organization"})
+		return
+	}
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "organization already exists"})
+		return
+	}
+
+	if err := h.db.Create(&organization).Error; err != nil {
+		log.Printf("Failed to create organization: %s", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create organization"})
+		return
+	}
+
+	log.Printf("Created organization %s", organization.Name)
+	audit.Target(c, audit.TargetOrganization, organization.ID, "")
+	audit.After(c, organization)
+	c.JSON(http.StatusCreated, organization)
+}
+
+// GetOrganizations lists the organizations by name.
+func (h *AdminHandler) GetOrganizations(c *gin.Context) {
+	if !manageOrganizations(c) {
+		return
+	}
+
+	organizations := []models.Organization{}
+	if err := h.db.Order("name").Find(&organizations).Error; err != nil {
+		log.Printf("Error fetching organizations: %s", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching organizations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"organizations": organizations})
+}
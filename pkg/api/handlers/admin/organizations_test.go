@@ -0,0 +1,93 @@
+package admin_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers/admin"
+	"github.com/guidewire/fern-reporter/pkg/audit"
+	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Organizations", func() {
+	var (
+		orgDb  *gorm.DB
+		router *gin.Engine
+	)
+
+	request := func(organizationID uint64, method string, path string, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req = req.WithContext(auth.WithOrganization(req.Context(), organizationID))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	BeforeEach(func() {
+		var err error
+		orgDb, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orgDb.AutoMigrate(&models.Organization{}, &models.ProjectDetails{}, &models.RoleBinding{}, &models.AuditEvent{})).To(Succeed())
+		Expect(orgDb.Create(&models.Organization{ID: models.DefaultOrganizationID, Name: "default"}).Error).NotTo(HaveOccurred())
+
+		gin.SetMode(gin.TestMode)
+		router = gin.New()
+		router.Use(audit.Middleware(audit.NewDefaultRecorder(orgDb)))
+		handler := admin.NewAdminHandler(orgDb)
+		router.GET("/api/admin/organizations", handler.GetOrganizations)
+		router.POST("/api/admin/organizations", handler.CreateOrganization)
+		router.GET("/api/admin/roles", handler.GetRoleBindings)
+		router.POST("/api/admin/roles", handler.CreateRoleBinding)
+		router.GET("/api/admin/audit", handler.GetAuditEvents)
+	})
+
+	It("should create and list organizations from the default organization", func() {
+		w := request(models.DefaultOrganizationID, "POST", "/api/admin/organizations", `{"name": " retail "}`)
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		var created models.Organization
+		Expect(json.Unmarshal(w.Body.Bytes(), &created)).To(Succeed())
+		Expect(created.Name).To(Equal("retail"))
+
+		Expect(request(models.DefaultOrganizationID, "POST", "/api/admin/organizations", `{"name": "retail"}`).Code).To(Equal(http.StatusConflict))
+		Expect(request(models.DefaultOrganizationID, "POST", "/api/admin/organizations", `{"name": " "}`).Code).To(Equal(http.StatusBadRequest))
+
+		w = request(models.DefaultOrganizationID, "GET", "/api/admin/organizations", "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(ContainSubstring(`"name":"default"`))
+		Expect(w.Body.String()).To(ContainSubstring(`"name":"retail"`))
+	})
+
+	It("should not let other organizations manage organizations", func() {
+		Expect(request(2, "GET", "/api/admin/organizations", "").Code).To(Equal(http.StatusForbidden))
+		Expect(request(2, "POST", "/api/admin/organizations", `{"name": "finance"}`).Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should keep the role bindings and audit events of organizations apart", func() {
+		Expect(request(2, "POST", "/api/admin/roles", `{"role": "viewer", "subject_type": "user", "subject": "bob"}`).Code).To(Equal(http.StatusCreated))
+		Expect(request(models.DefaultOrganizationID, "POST", "/api/admin/roles", `{"role": "viewer", "subject_type": "user", "subject": "bob"}`).Code).To(Equal(http.StatusCreated))
+
+		var bindings struct {
+			Bindings []models.RoleBinding `json:"bindings"`
+		}
+		Expect(json.Unmarshal(request(2, "GET", "/api/admin/roles", "").Body.Bytes(), &bindings)).To(Succeed())
+		Expect(bindings.Bindings).To(HaveLen(1))
+
+		var events struct {
+			Events []models.AuditEvent `json:"events"`
+		}
+		Expect(json.Unmarshal(request(2, "GET", "/api/admin/audit", "").Body.Bytes(), &events)).To(Succeed())
+		Expect(events.Events).To(HaveLen(1))
+		Expect(events.Events[0].TargetID).To(Equal(strconv.FormatUint(bindings.Bindings[0].ID, 10)))
+	})
+})
@@ -0,0 +1,164 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/audit"
+	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"gorm.io/gorm"
+)
+
+// RoleBindingRequest binds a role to a user or group on a project, given by
+// its UUID, on the projects of a team, or on every project when neither is set.
+type RoleBindingRequest struct {
+	Role        string `json:"role" binding:"required"`
+	SubjectType string `json:"subject_type" binding:"required"`
+	Subject     string `json:"subject" binding:"required"`
+	ProjectID   string `json:"project_id"`
+	TeamName    string `json:"team_name"`
+}
+
+// CreateRoleBinding binds a role to a user or group in the caller's
+// organization.
+func (h *AdminHandler) CreateRoleBinding(c *gin.Context) {
+	var request RoleBindingRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	binding := models.RoleBinding{
+		Role:           request.Role,
+		SubjectType:    request.SubjectType,
+		Subject:        strings.TrimSpace(request.Subject),
+		TeamName:       strings.TrimSpace(request.TeamName),
+		OrganizationID: organizationID(c),
+	}
+	switch {
+	case !auth.ValidRole(binding.Role):
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid role %q", binding.Role)})
+		return
+	case !auth.ValidSubjectType(binding.SubjectType):
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid subject type %q", binding.SubjectType)})
+		return
+	case binding.Subject == "":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "subject must not be empty"})
+		return
+	case request.ProjectID != "" && binding.TeamName != "":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a role is bound to a project or a team, not both"})
+		return
+	}
+
+	if request.ProjectID != "" {
+		var project models.ProjectDetails
+		if err := auth.ScopeProjects(c, h.db.Where("uuid = ?", request.ProjectID)).First(&project).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Project ID %s not found", request.ProjectID)})
+				return
+			}
+			log.Printf("Error fetching project %s: %s", request.ProjectID, err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching project"})
+			return
+		}
+		binding.ProjectID = &project.ID
+		binding.Project = &project
+	}
+
+	duplicates := h.db.Model(&models.RoleBinding{}).
+		Where("organization_id = ? AND role = ? AND subject_type = ? AND subject = ? AND team_name = ?",
+			binding.OrganizationID, binding.Role, binding.SubjectType, binding.Subject, binding.TeamName)
+	if binding.ProjectID != nil {
+		duplicates = duplicates.Where("project_id = ?", *binding.ProjectID)
+	} else {
+		duplicates = duplicates.Where("project_id IS NULL")
+	}
+	var count int64
+	if err := duplicates.Count(&count).Error; err != nil {
+		log.Printf("Failed to check for duplicate role bindings: %s", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create role binding"})
+		return
+	}
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "role binding already exists"})
+		return
+	}
+
+	if err := h.db.Omit("Project").Create(&binding).Error; err != nil {
+		log.Printf("Failed to create role binding: %s", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create role binding"})
+		return
+	}
+
+	log.Printf("Bound role %s to %s %s", binding.Role, binding.SubjectType, binding.Subject)
+	audit.Target(c, audit.TargetRoleBinding, binding.ID, request.ProjectID)
+	audit.After(c, binding)
+	c.JSON(http.StatusCreated, binding)
+}
+
+// GetRoleBindings lists the role bindings of the caller's organization.
+// Optional query parameters: subject, role, project_id (a project UUID) and
+// team.
+func (h *AdminHandler) GetRoleBindings(c *gin.Context) {
+	query := scopeOrganization(c, h.db.Preload("Project").Order("id"), "role_bindings.organization_id")
+	if subject := c.Query("subject"); subject != "" {
+		query = query.Where("subject = ?", subject)
+	}
+	if role := c.Query("role"); role != "" {
+		query = query.Where("role = ?", role)
+	}
+	if team := c.Query("team"); team != "" {
+		query = query.Where("team_name = ?", team)
+	}
+	if projectUUID := c.Query("project_id"); projectUUID != "" {
+		query = query.Where("project_id IN (?)", h.db.Model(&models.ProjectDetails{}).Select("id").Where("uuid = ?", projectUUID))
+	}
+
+	bindings := []models.RoleBinding{}
+	if err := query.Find(&bindings).Error; err != nil {
+		log.Printf("Error fetching role bindings: %s", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching role bindings"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"bindings": bindings})
+}
+
+// DeleteRoleBinding removes a role binding of the caller's organization.
+func (h *AdminHandler) DeleteRoleBinding(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid role binding ID"})
+		return
+	}
+
+	var existing models.RoleBinding
+	if audit.Recording(c) && scopeOrganization(c, h.db.Preload("Project"), "role_bindings.organization_id").First(&existing, id).Error == nil {
+		projectUUID := ""
+		if existing.Project != nil {
+			projectUUID = existing.Project.UUID
+		}
+		audit.Target(c, audit.TargetRoleBinding, existing.ID, projectUUID)
+		audit.Before(c, existing)
+	}
+
+	result := scopeOrganization(c, h.db, "role_bindings.organization_id").Delete(&models.RoleBinding{}, id)
+	if result.Error != nil {
+		log.Printf("Failed to delete role binding %d: %s", id, result.Error.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete role binding"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("role binding %d not found", id)})
+		return
+	}
+
+	log.Printf("Deleted role binding %d", id)
+	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("role binding %d deleted", id)})
+}
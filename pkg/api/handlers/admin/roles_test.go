@@ -0,0 +1,110 @@
+package admin_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers/admin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Role bindings", func() {
+	var (
+		roleDb *gorm.DB
+		router *gin.Engine
+	)
+
+	request := func(method string, path string, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	listBindings := func(query string) []models.RoleBinding {
+		w := request("GET", "/api/admin/roles"+query, "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var response struct {
+			Bindings []models.RoleBinding `json:"bindings"`
+		}
+		Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+		return response.Bindings
+	}
+
+	BeforeEach(func() {
+		var err error
+		roleDb, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleDb.AutoMigrate(&models.ProjectDetails{}, &models.RoleBinding{})).To(Succeed())
+
+		cart := models.ProjectDetails{Name: "Cart", TeamName: "shop"}
+		Expect(roleDb.Create(&cart).Error).NotTo(HaveOccurred())
+		Expect(roleDb.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "project-uuid", cart.ID).Error).NotTo(HaveOccurred())
+
+		gin.SetMode(gin.TestMode)
+		router = gin.New()
+		handler := admin.NewAdminHandler(roleDb)
+		router.GET("/api/admin/roles", handler.GetRoleBindings)
+		router.POST("/api/admin/roles", handler.CreateRoleBinding)
+		router.DELETE("/api/admin/roles/:id", handler.DeleteRoleBinding)
+	})
+
+	It("should bind roles to users and groups on projects, teams and everything", func() {
+		w := request("POST", "/api/admin/roles", `{"role": "maintainer", "subject_type": "user", "subject": "alice", "project_id": "project-uuid"}`)
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		var created models.RoleBinding
+		Expect(json.Unmarshal(w.Body.Bytes(), &created)).To(Succeed())
+		Expect(created.ID).NotTo(BeZero())
+		Expect(created.Project.Name).To(Equal("Cart"))
+
+		Expect(request("POST", "/api/admin/roles", `{"role": "viewer", "subject_type": "group", "subject": "shoppers", "team_name": "shop"}`).Code).To(Equal(http.StatusCreated))
+		Expect(request("POST", "/api/admin/roles", `{"role": "admin", "subject_type": "group", "subject": "fern-admins"}`).Code).To(Equal(http.StatusCreated))
+
+		Expect(listBindings("")).To(HaveLen(3))
+		Expect(listBindings("?subject=alice")).To(HaveLen(1))
+		Expect(listBindings("?role=viewer")[0].Subject).To(Equal("shoppers"))
+		Expect(listBindings("?team=shop")[0].TeamName).To(Equal("shop"))
+		byProject := listBindings("?project_id=project-uuid")
+		Expect(byProject).To(HaveLen(1))
+		Expect(byProject[0].Project.Name).To(Equal("Cart"))
+	})
+
+	It("should reject duplicate bindings with 409", func() {
+		body := `{"role": "uploader", "subject_type": "user", "subject": "ci", "project_id": "project-uuid"}`
+		Expect(request("POST", "/api/admin/roles", body).Code).To(Equal(http.StatusCreated))
+		Expect(request("POST", "/api/admin/roles", body).Code).To(Equal(http.StatusConflict))
+	})
+
+	DescribeTable("should reject invalid bindings",
+		func(body string, status int) {
+			Expect(request("POST", "/api/admin/roles", body).Code).To(Equal(status))
+			Expect(listBindings("")).To(BeEmpty())
+		},
+		Entry("unknown role", `{"role": "owner", "subject_type": "user", "subject": "alice"}`, http.StatusBadRequest),
+		Entry("unknown subject type", `{"role": "viewer", "subject_type": "robot", "subject": "alice"}`, http.StatusBadRequest),
+		Entry("missing subject", `{"role": "viewer", "subject_type": "user"}`, http.StatusBadRequest),
+		Entry("project and team", `{"role": "viewer", "subject_type": "user", "subject": "alice", "project_id": "project-uuid", "team_name": "shop"}`, http.StatusBadRequest),
+		Entry("unknown project", `{"role": "viewer", "subject_type": "user", "subject": "alice", "project_id": "missing"}`, http.StatusNotFound),
+	)
+
+	It("should delete bindings", func() {
+		w := request("POST", "/api/admin/roles", `{"role": "viewer", "subject_type": "user", "subject": "alice"}`)
+		var created models.RoleBinding
+		Expect(json.Unmarshal(w.Body.Bytes(), &created)).To(Succeed())
+
+		Expect(request("DELETE", "/api/admin/roles/abc", "").Code).To(Equal(http.StatusBadRequest))
+		Expect(request("DELETE", "/api/admin/roles/999", "").Code).To(Equal(http.StatusNotFound))
+		Expect(request("DELETE", fmt.Sprintf("/api/admin/roles/%d", created.ID), "").Code).To(Equal(http.StatusOK))
+		Expect(listBindings("")).To(BeEmpty())
+	})
+})
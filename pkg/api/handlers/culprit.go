@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const defaultCulpritLimit = 500
+
+// CulpritOptions selects the runs searched for the commit that broke a test.
+type CulpritOptions struct {
+	Branch string
+	// Limit caps the number of most recent runs of the test that are searched.
+	Limit int
+}
+
+// FindCulprit looks for the commit that made the test case with the given
+// fingerprint fail on a branch. Walking back from the latest run of the test,
+// it skips over the current streak of failures to the last run in which the
+// test passed. The report lists every run of the branch from that run up to
+// the first failing one, together with the build trigger actors of the runs
+// after the last pass. Runs in which the test was skipped are ignored, and a
+// run counts as passed when any attempt of the test passed. When the latest
+// outcome is a pass the report is not failing and has no runs. It returns
+// gorm.ErrRecordNotFound when no test case has the fingerprint, or when it
+// belongs to a project outside the organization given by the context of db.
+func FindCulprit(db *gorm.DB, fingerprint string, options CulpritOptions) (*models.CulpritReport, error) {
+	report := models.CulpritReport{
+		GitBranch: options.Branch,
+		Runs:      []models.CulpritRun{},
+		Suspects:  []string{},
+	}
+	if err := findTestCase(db, fingerprint, &report.TestCase); err != nil {
+		return nil, err
+	}
+
+	var outcomes []models.CulpritRun
+	if err := db.Table("spec_runs").
+		Select("test_runs.id AS test_run_id, test_runs.git_sha, test_runs.build_trigger_actor, test_runs.build_url, "+
+			"test_runs.start_time, spec_runs.status AS spec_status").
+		Joins("JOIN suite_runs ON suite_runs.id = spec_runs.suite_id").
+		Joins("JOIN test_runs ON test_runs.id = suite_runs.test_run_id").
+		Where("spec_runs.test_case_id = ? AND test_runs.git_branch = ?", report.TestCase.ID, options.Branch).
+		Order("test_runs.start_time DESC, test_runs.id DESC, spec_runs.id DESC").
+		Limit(options.Limit).
+		Scan(&outcomes).Error; err != nil {
+		return nil, err
+	}
+
+	// Collapse retries into one outcome per run, most recent run first
+	var runs []models.CulpritRun
+	index := map[uint64]int{}
+	for _, outcome := range outcomes {
+		status := ""
+		switch {
+		case outcome.SpecStatus == utils.StatusPassed:
+			status = utils.StatusPassed
+		case slices.Contains(utils.FailedStatuses, outcome.SpecStatus):
+			status = utils.StatusFailed
+		}
+		i, seen := index[outcome.TestRunID]
+		if !seen {
+			index[outcome.TestRunID] = len(runs)
+			outcome.SpecStatus = status
+			runs = append(runs, outcome)
+		} else if status == utils.StatusPassed || runs[i].SpecStatus == "" {
+			runs[i].SpecStatus = status
+		}
+	}
+
+	for i := range runs {
+		run := &runs[i]
+		if run.SpecStatus == "" {
+			continue
+		}
+		if run.SpecStatus == utils.StatusPassed {
+			report.LastPassing = run
+			break
+		}
+		report.FirstFailing = run
+	}
+	if report.FirstFailing == nil {
+		report.LastPassing = nil
+		return &report, nil
+	}
+	report.Failing = true
+
+	between := db.Model(&models.TestRun{}).
+		Select("id AS test_run_id, git_sha, build_trigger_actor, build_url, start_time").
+		Where("project_id = ? AND git_branch = ? AND start_time <= ?",
+			report.TestCase.ProjectID, options.Branch, report.FirstFailing.StartTime)
+	if report.LastPassing != nil {
+		between = between.Where("start_time >= ?", report.LastPassing.StartTime)
+	} else {
+		between = between.Where("start_time >= ?", report.FirstFailing.StartTime)
+	}
+	if err := between.Order("start_time, id").Scan(&report.Runs).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range report.Runs {
+		run := &report.Runs[i]
+		if j, ok := index[run.TestRunID]; ok {
+			run.SpecStatus = runs[j].SpecStatus
+		}
+		if report.LastPassing != nil && run.TestRunID == report.LastPassing.TestRunID {
+			continue
+		}
+		if run.BuildTriggerActor != "" && !slices.Contains(report.Suspects, run.BuildTriggerActor) {
+			report.Suspects = append(report.Suspects, run.BuildTriggerActor)
+		}
+	}
+	return &report, nil
+}
+
+// GetCulprit returns the last passing and first failing commit of the test
+// case with the given fingerprint. Optional query parameters: branch (defaults
+// to reports.default-branch in the config) and limit, the number of most
+// recent runs of the test that are searched (default 500).
+func (h *Handler) GetCulprit(c *gin.Context) {
+	fingerprint := c.Param("fingerprint")
+
+	limit, err := QueryPositiveInt(c, "limit", defaultCulpritLimit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	branch := c.DefaultQuery("branch", config.GetReports().DefaultBranch)
+
+	report, err := FindCulprit(h.db.WithContext(c.Request.Context()), fingerprint, CulpritOptions{Branch: branch, Limit: limit})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("test %s not found", fingerprint)})
+		return
+	} else if err != nil {
+		log.Printf("error finding culprit of test %s: %v", fingerprint, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error finding culprit"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
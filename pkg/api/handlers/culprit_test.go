@@ -0,0 +1,152 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+var _ = Describe("Culprit finder", func() {
+	var (
+		db          *gorm.DB
+		projectID   uint64
+		fingerprint string
+		runStart    time.Time
+	)
+
+	// addRun stores a run of the branch triggered by actor. Each status is an
+	// attempt of the checkout spec; without statuses the spec did not run.
+	addRun := func(branch string, sha string, actor string, statuses ...string) {
+		runStart = runStart.Add(time.Hour)
+		suite := models.SuiteRun{SuiteName: "Cart", SpecRuns: []models.SpecRun{{SpecDescription: "adds items", Status: "passed"}}}
+		for _, status := range statuses {
+			suite.SpecRuns = append(suite.SpecRuns, models.SpecRun{SpecDescription: "checks out", Status: status})
+		}
+		testRun := models.TestRun{
+			ProjectID:         projectID,
+			GitBranch:         branch,
+			GitSha:            sha,
+			BuildTriggerActor: actor,
+			StartTime:         runStart,
+			Status:            "passed",
+			SuiteRuns:         []models.SuiteRun{suite},
+		}
+		Expect(handlers.LinkTestCases(db, &testRun)).To(Succeed())
+		Expect(db.Create(&testRun).Error).NotTo(HaveOccurred())
+	}
+
+	shas := func(runs []models.CulpritRun) []string {
+		var result []string
+		for _, run := range runs {
+			result = append(result, run.GitSha)
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		db = setupTestDB()
+		project := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		projectID = project.ID
+		fingerprint = utils.TestCaseFingerprint(projectID, "Cart", "checks out")
+		runStart = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	})
+
+	It("finds the last passing and first failing commits and who triggered the runs between", func() {
+		addRun("main", "sha1", "alice", "passed")
+		addRun("main", "sha2", "bob", "failed", "passed")
+		addRun("feature", "shaF", "erin", "failed")
+		addRun("main", "sha3", "carol")
+		addRun("main", "sha4", "dave", "skipped")
+		addRun("main", "sha5", "dave", "timedout", "failed")
+		addRun("main", "sha6", "alice", "failed")
+
+		report, err := handlers.FindCulprit(db, fingerprint, handlers.CulpritOptions{Branch: "main", Limit: 100})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Failing).To(BeTrue())
+		Expect(report.TestCase.SpecDescription).To(Equal("checks out"))
+		Expect(report.LastPassing.GitSha).To(Equal("sha2"))
+		Expect(report.FirstFailing.GitSha).To(Equal("sha5"))
+		Expect(report.FirstFailing.BuildTriggerActor).To(Equal("dave"))
+		Expect(shas(report.Runs)).To(Equal([]string{"sha2", "sha3", "sha4", "sha5"}))
+		Expect(report.Runs[0].SpecStatus).To(Equal("passed"))
+		Expect(report.Runs[1].SpecStatus).To(BeEmpty())
+		Expect(report.Runs[3].SpecStatus).To(Equal("failed"))
+		Expect(report.Suspects).To(Equal([]string{"carol", "dave"}))
+	})
+
+	It("reports a test that passes on its latest run as not failing", func() {
+		addRun("main", "sha1", "alice", "failed")
+		addRun("main", "sha2", "bob", "passed")
+
+		report, err := handlers.FindCulprit(db, fingerprint, handlers.CulpritOptions{Branch: "main", Limit: 100})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Failing).To(BeFalse())
+		Expect(report.LastPassing).To(BeNil())
+		Expect(report.FirstFailing).To(BeNil())
+		Expect(report.Runs).To(BeEmpty())
+	})
+
+	It("has no last passing commit when the test never passed", func() {
+		addRun("main", "sha1", "alice", "failed")
+		addRun("main", "sha2", "bob", "failed")
+
+		report, err := handlers.FindCulprit(db, fingerprint, handlers.CulpritOptions{Branch: "main", Limit: 100})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Failing).To(BeTrue())
+		Expect(report.LastPassing).To(BeNil())
+		Expect(report.FirstFailing.GitSha).To(Equal("sha1"))
+		Expect(report.Suspects).To(Equal([]string{"alice"}))
+	})
+
+	Describe("GET /api/reports/tests/:fingerprint/culprit", func() {
+		var router *gin.Engine
+
+		get := func(path string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("GET", path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		BeforeEach(func() {
+			gin.SetMode(gin.TestMode)
+			router = gin.New()
+			router.GET("/api/reports/tests/:fingerprint/culprit", handlers.NewHandler(db).GetCulprit)
+		})
+
+		It("searches the default branch unless another is given", func() {
+			addRun("main", "sha1", "alice", "passed")
+			addRun("main", "sha2", "bob", "failed")
+			addRun("feature", "shaF", "erin", "passed")
+
+			w := get("/api/reports/tests/" + fingerprint + "/culprit")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var report models.CulpritReport
+			Expect(json.Unmarshal(w.Body.Bytes(), &report)).To(Succeed())
+			Expect(report.GitBranch).To(Equal("main"))
+			Expect(report.FirstFailing.GitSha).To(Equal("sha2"))
+			Expect(report.Suspects).To(Equal([]string{"bob"}))
+
+			w = get("/api/reports/tests/" + fingerprint + "/culprit?branch=feature")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(json.Unmarshal(w.Body.Bytes(), &report)).To(Succeed())
+			Expect(report.Failing).To(BeFalse())
+		})
+
+		It("rejects invalid limits and unknown tests", func() {
+			Expect(get("/api/reports/tests/" + fingerprint + "/culprit?limit=0").Code).To(Equal(http.StatusBadRequest))
+			Expect(get("/api/reports/tests/unknown/culprit").Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
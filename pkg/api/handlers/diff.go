@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultSlowerFactor = 1.5
+
+	// minSlowdown keeps short specs that jitter by a few milliseconds out of
+	// the slower list.
+	minSlowdown = time.Second
+)
+
+// DiffTestRuns compares a test run with a base run. Specs are matched by suite
+// name and spec description, ignoring whitespace differences. Specs that
+// passed in both runs are reported as slower when they took at least
+// slowerFactor times as long as in the base run.
+func DiffTestRuns(base *models.TestRun, head *models.TestRun, slowerFactor float64) models.TestRunDiff {
+	diff := models.TestRunDiff{
+		TestRunID:     head.ID,
+		BaseTestRunID: base.ID,
+		NewlyFailing:  []models.SpecDiff{},
+		NewlyPassing:  []models.SpecDiff{},
+		StillFailing:  []models.SpecDiff{},
+		Added:         []models.SpecDiff{},
+		Removed:       []models.SpecDiff{},
+		Slower:        []models.SpecDiff{},
+	}
+
+	baseSpecs := specsByIdentity(base)
+	headSpecs := specsByIdentity(head)
+
+	for identity, spec := range headSpecs {
+		entry := models.SpecDiff{
+			SuiteName:       identity.suiteName,
+			SpecDescription: identity.specDescription,
+			Status:          spec.Status,
+			DurationMs:      specDurationMs(spec.StartTime, spec.EndTime),
+			Message:         spec.Message,
+		}
+
+		baseSpec, ok := baseSpecs[identity]
+		if !ok {
+			diff.Added = append(diff.Added, entry)
+			continue
+		}
+		entry.BaseStatus = baseSpec.Status
+		entry.BaseDurationMs = specDurationMs(baseSpec.StartTime, baseSpec.EndTime)
+
+		failed := slices.Contains(utils.FailedStatuses, spec.Status)
+		baseFailed := slices.Contains(utils.FailedStatuses, baseSpec.Status)
+		switch {
+		case failed && baseFailed:
+			diff.StillFailing = append(diff.StillFailing, entry)
+		case failed:
+			diff.NewlyFailing = append(diff.NewlyFailing, entry)
+		case baseFailed && spec.Status == utils.StatusPassed:
+			diff.NewlyPassing = append(diff.NewlyPassing, entry)
+		case spec.Status == utils.StatusPassed && baseSpec.Status == utils.StatusPassed:
+			slowdown := time.Duration(entry.DurationMs-entry.BaseDurationMs) * time.Millisecond
+			if entry.BaseDurationMs > 0 && slowdown >= minSlowdown &&
+				float64(entry.DurationMs) >= float64(entry.BaseDurationMs)*slowerFactor {
+				diff.Slower = append(diff.Slower, entry)
+			}
+		}
+	}
+
+	for identity, spec := range baseSpecs {
+		if _, ok := headSpecs[identity]; !ok {
+			diff.Removed = append(diff.Removed, models.SpecDiff{
+				SuiteName:       identity.suiteName,
+				SpecDescription: identity.specDescription,
+				BaseStatus:      spec.Status,
+				BaseDurationMs:  specDurationMs(spec.StartTime, spec.EndTime),
+				Message:         spec.Message,
+			})
+		}
+	}
+
+	for _, specs := range [][]models.SpecDiff{diff.NewlyFailing, diff.NewlyPassing, diff.StillFailing, diff.Added, diff.Removed} {
+		sortSpecDiffs(specs)
+	}
+	sort.SliceStable(diff.Slower, func(i, j int) bool {
+		return diff.Slower[i].DurationMs-diff.Slower[i].BaseDurationMs > diff.Slower[j].DurationMs-diff.Slower[j].BaseDurationMs
+	})
+	return diff
+}
+
+// specsByIdentity indexes the specs of a run by their normalized names. When
+// a spec appears more than once the last occurrence wins.
+func specsByIdentity(testRun *models.TestRun) map[specIdentity]models.SpecRun {
+	specs := map[specIdentity]models.SpecRun{}
+	for _, suite := range testRun.SuiteRuns {
+		for _, spec := range suite.SpecRuns {
+			specs[specIdentity{utils.NormalizeTestName(suite.SuiteName), utils.NormalizeTestName(spec.SpecDescription)}] = spec
+		}
+	}
+	return specs
+}
+
+func sortSpecDiffs(specs []models.SpecDiff) {
+	sort.Slice(specs, func(i, j int) bool {
+		if specs[i].SuiteName != specs[j].SuiteName {
+			return specs[i].SuiteName < specs[j].SuiteName
+		}
+		return specs[i].SpecDescription < specs[j].SpecDescription
+	})
+}
+
+// loadTestRunDiff compares the runs named by the id and baseId path
+// parameters, writing an error response and returning false when it cannot.
+func (h *Handler) loadTestRunDiff(c *gin.Context) (models.TestRunDiff, bool) {
+	slowerFactor := defaultSlowerFactor
+	if value := c.Query("slower_factor"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid slower_factor parameter: %q", value)})
+			return models.TestRunDiff{}, false
+		}
+		slowerFactor = parsed
+	}
+
+	var runs [2]models.TestRun
+	for i, param := range []string{"baseId", "id"} {
+		id, err := strconv.ParseUint(c.Param(param), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid test run id %q", c.Param(param))})
+			return models.TestRunDiff{}, false
+		}
+		if err := scopeTestRuns(c, h.db.Preload("SuiteRuns.SpecRuns").Where("id = ?", id)).First(&runs[i]).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("test run %d not found", id)})
+				return models.TestRunDiff{}, false
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading test run"})
+			return models.TestRunDiff{}, false
+		}
+	}
+
+	return DiffTestRuns(&runs[0], &runs[1], slowerFactor), true
+}
+
+// GetTestRunDiff reports what changed between a base run and a later run:
+// newly failing, newly passing, still failing, added, removed and slower
+// specs. Optional query parameter: slower_factor (default 1.5).
+func (h *Handler) GetTestRunDiff(c *gin.Context) {
+	diff, ok := h.loadTestRunDiff(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
+
+type specDiffSection struct {
+	Title string
+	Specs []models.SpecDiff
+}
+
+func (h *Handler) ReportTestRunDiffHTML(c *gin.Context) {
+	diff, ok := h.loadTestRunDiff(c)
+	if !ok {
+		return
+	}
+
+	c.HTML(http.StatusOK, "test_run_diff.html", gin.H{
+		"reportHeader": config.GetHeaderName(),
+		"diff":         diff,
+		"sections": []specDiffSection{
+			{"Newly Failing", diff.NewlyFailing},
+			{"Newly Passing", diff.NewlyPassing},
+			{"Still Failing", diff.StillFailing},
+			{"Slower", diff.Slower},
+			{"Added", diff.Added},
+			{"Removed", diff.Removed},
+		},
+	})
+}
@@ -0,0 +1,154 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Test run diff", func() {
+	var start time.Time
+
+	spec := func(description string, status string, duration time.Duration) models.SpecRun {
+		return models.SpecRun{SpecDescription: description, Status: status, StartTime: start, EndTime: start.Add(duration)}
+	}
+
+	BeforeEach(func() {
+		start = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	})
+
+	It("classifies the specs of two runs", func() {
+		base := &models.TestRun{ID: 1, SuiteRuns: []models.SuiteRun{{SuiteName: "Cart", SpecRuns: []models.SpecRun{
+			spec("adds items", "passed", time.Second),
+			spec("removes items", "failed", time.Second),
+			spec("totals", "failed", time.Second),
+			spec("checks out", "passed", 2*time.Second),
+			spec("applies coupons", "passed", time.Second),
+			spec("empties", "passed", 100*time.Millisecond),
+		}}}}
+		head := &models.TestRun{ID: 2, SuiteRuns: []models.SuiteRun{{SuiteName: "Cart ", SpecRuns: []models.SpecRun{
+			spec("adds  items", "failed", time.Second),
+			spec("removes items", "passed", time.Second),
+			spec("totals", "timedout", time.Second),
+			spec("checks out", "passed", 5*time.Second),
+			spec("empties", "passed", 300*time.Millisecond),
+			spec("saves for later", "passed", time.Second),
+		}}}}
+
+		diff := handlers.DiffTestRuns(base, head, 1.5)
+		Expect(diff.TestRunID).To(Equal(uint64(2)))
+		Expect(diff.BaseTestRunID).To(Equal(uint64(1)))
+
+		Expect(diff.NewlyFailing).To(HaveLen(1))
+		Expect(diff.NewlyFailing[0].SpecDescription).To(Equal("adds items"))
+		Expect(diff.NewlyFailing[0].BaseStatus).To(Equal("passed"))
+		Expect(diff.NewlyPassing).To(HaveLen(1))
+		Expect(diff.NewlyPassing[0].SpecDescription).To(Equal("removes items"))
+		Expect(diff.StillFailing).To(HaveLen(1))
+		Expect(diff.StillFailing[0].Status).To(Equal("timedout"))
+
+		Expect(diff.Slower).To(HaveLen(1))
+		Expect(diff.Slower[0].SpecDescription).To(Equal("checks out"))
+		Expect(diff.Slower[0].BaseDurationMs).To(Equal(int64(2000)))
+		Expect(diff.Slower[0].DurationMs).To(Equal(int64(5000)))
+
+		Expect(diff.Added).To(HaveLen(1))
+		Expect(diff.Added[0].SpecDescription).To(Equal("saves for later"))
+		Expect(diff.Removed).To(HaveLen(1))
+		Expect(diff.Removed[0].SpecDescription).To(Equal("applies coupons"))
+	})
+
+	It("returns empty lists for identical runs", func() {
+		run := &models.TestRun{SuiteRuns: []models.SuiteRun{{SuiteName: "Cart", SpecRuns: []models.SpecRun{
+			spec("adds items", "passed", time.Second),
+		}}}}
+
+		diff := handlers.DiffTestRuns(run, run, 1.5)
+		Expect(diff.NewlyFailing).NotTo(BeNil())
+		Expect(diff.NewlyFailing).To(BeEmpty())
+		Expect(diff.Slower).To(BeEmpty())
+		Expect(diff.Added).To(BeEmpty())
+		Expect(diff.Removed).To(BeEmpty())
+	})
+
+	Context("endpoints", func() {
+		var (
+			db     *gorm.DB
+			router *gin.Engine
+			base   models.TestRun
+			head   models.TestRun
+		)
+
+		get := func(path string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("GET", path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		BeforeEach(func() {
+			db = setupTestDB()
+			base = models.TestRun{SuiteRuns: []models.SuiteRun{{SuiteName: "Cart", SpecRuns: []models.SpecRun{
+				spec("adds items", "passed", time.Second),
+				spec("checks out", "passed", time.Second),
+			}}}}
+			head = models.TestRun{SuiteRuns: []models.SuiteRun{{SuiteName: "Cart", SpecRuns: []models.SpecRun{
+				spec("adds items", "failed", time.Second),
+				spec("checks out", "passed", 3*time.Second),
+			}}}}
+			Expect(db.Create(&base).Error).NotTo(HaveOccurred())
+			Expect(db.Create(&head).Error).NotTo(HaveOccurred())
+
+			gin.SetMode(gin.TestMode)
+			router = gin.New()
+			router.LoadHTMLGlob("../../views/test_run_diff.html")
+			handler := handlers.NewHandler(db)
+			router.GET("/api/reports/testruns/:id/diff/:baseId", handler.GetTestRunDiff)
+			router.GET("/reports/testruns/:id/diff/:baseId", handler.ReportTestRunDiffHTML)
+		})
+
+		It("returns the diff as JSON", func() {
+			w := get(fmt.Sprintf("/api/reports/testruns/%d/diff/%d", head.ID, base.ID))
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var diff models.TestRunDiff
+			Expect(json.Unmarshal(w.Body.Bytes(), &diff)).To(Succeed())
+			Expect(diff.NewlyFailing).To(HaveLen(1))
+			Expect(diff.Slower).To(HaveLen(1))
+
+			w = get(fmt.Sprintf("/api/reports/testruns/%d/diff/%d?slower_factor=4", head.ID, base.ID))
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(json.Unmarshal(w.Body.Bytes(), &diff)).To(Succeed())
+			Expect(diff.Slower).To(BeEmpty())
+		})
+
+		It("renders the diff as HTML", func() {
+			w := get(fmt.Sprintf("/reports/testruns/%d/diff/%d", head.ID, base.ID))
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			doc, err := goquery.NewDocumentFromReader(w.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.TrimSpace(doc.Find("table.diff-section caption").First().Text())).To(Equal("Newly Failing (1)"))
+			Expect(strings.TrimSpace(doc.Find("table.diff-section").First().Find("tr.diff-row td").Eq(1).Text())).To(Equal("adds items"))
+		})
+
+		It("rejects invalid parameters and unknown runs", func() {
+			Expect(get(fmt.Sprintf("/api/reports/testruns/abc/diff/%d", base.ID)).Code).To(Equal(http.StatusBadRequest))
+			Expect(get(fmt.Sprintf("/api/reports/testruns/%d/diff/%d?slower_factor=0.5", head.ID, base.ID)).Code).To(Equal(http.StatusBadRequest))
+			Expect(get(fmt.Sprintf("/api/reports/testruns/%d/diff/999", head.ID)).Code).To(Equal(http.StatusNotFound))
+			Expect(get(fmt.Sprintf("/reports/testruns/999/diff/%d", base.ID)).Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	DurationRegressionKindSuite = "suite"
+	DurationRegressionKindSpec  = "spec"
+
+	defaultDurationRegressionDays  = 30
+	defaultDurationRegressionLimit = 100
+)
+
+// DurationRegressionOptions controls when a slowdown counts as a regression.
+type DurationRegressionOptions struct {
+	// Threshold is the number of baseline standard deviations a duration must
+	// exceed the baseline mean by.
+	Threshold float64
+	// MinSamples is the number of baseline durations a suite or spec needs
+	// before it is checked at all.
+	MinSamples int
+	// Window is the number of earlier runs of the branch the baseline is
+	// taken from.
+	Window int
+}
+
+// DurationRegressionOptionsFromConfig reads the detector options from the
+// reports section of the config.
+func DurationRegressionOptionsFromConfig() DurationRegressionOptions {
+	reports := config.GetReports()
+	return DurationRegressionOptions{
+		Threshold:  reports.DurationRegressionThreshold,
+		MinSamples: reports.DurationRegressionMinSamples,
+		Window:     reports.DurationRegressionWindow,
+	}
+}
+
+type durationKey struct {
+	kind string
+	spec specIdentity
+}
+
+// runDurations returns the suite durations and the durations of passed specs
+// of a run in milliseconds. Suites and specs without a recorded duration are
+// left out; a suite or spec that appears more than once contributes each
+// occurrence.
+func runDurations(testRun *models.TestRun) map[durationKey][]int64 {
+	durations := map[durationKey][]int64{}
+	for _, suite := range testRun.SuiteRuns {
+		suiteName := utils.NormalizeTestName(suite.SuiteName)
+		if ms := specDurationMs(suite.StartTime, suite.EndTime); ms > 0 {
+			key := durationKey{DurationRegressionKindSuite, specIdentity{suiteName: suiteName}}
+			durations[key] = append(durations[key], ms)
+		}
+		for _, spec := range suite.SpecRuns {
+			if spec.Status != utils.StatusPassed {
+				continue
+			}
+			if ms := specDurationMs(spec.StartTime, spec.EndTime); ms > 0 {
+				key := durationKey{DurationRegressionKindSpec, specIdentity{suiteName, utils.NormalizeTestName(spec.SpecDescription)}}
+				durations[key] = append(durations[key], ms)
+			}
+		}
+	}
+	return durations
+}
+
+// meanAndStddev returns the mean and sample standard deviation of durations.
+func meanAndStddev(durations []int64) (float64, float64) {
+	var sum float64
+	for _, duration := range durations {
+		sum += float64(duration)
+	}
+	mean := sum / float64(len(durations))
+	if len(durations) < 2 {
+		return mean, 0
+	}
+	var squares float64
+	for _, duration := range durations {
+		squares += (float64(duration) - mean) * (float64(duration) - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(durations)-1))
+}
+
+// loadBaselineRuns returns the last completed runs of the project and branch
+// of a test run that started before it, with the timings of their suites and
+// specs.
+func loadBaselineRuns(db *gorm.DB, testRun *models.TestRun, window int) ([]models.TestRun, error) {
+	var runs []models.TestRun
+	err := db.Select("id").
+		Preload("SuiteRuns", func(tx *gorm.DB) *gorm.DB {
+			return tx.Select("id, test_run_id, suite_name, start_time, end_time")
+		}).
+		Preload("SuiteRuns.SpecRuns", func(tx *gorm.DB) *gorm.DB {
+			return tx.Select("id, suite_id, spec_description, status, start_time, end_time")
+		}).
+		Where("project_id = ? AND git_branch = ? AND id <> ? AND start_time < ?",
+			testRun.ProjectID, testRun.GitBranch, testRun.ID, testRun.StartTime).
+		Where("status NOT IN ?", []string{utils.StatusInProgress, utils.StatusAbandoned}).
+		Order("start_time DESC, id DESC").
+		Limit(window).
+		Find(&runs).Error
+	return runs, err
+}
+
+// DetectDurationRegressions compares the suite and passed spec durations of a
+// completed test run with the same suites and specs in the last
+// options.Window runs of its branch, and replaces the duration regressions
+// recorded for the run with the ones found. A duration regresses when the
+// suite or spec has at least options.MinSamples baseline durations and it
+// exceeds their mean by more than options.Threshold standard deviations and
+// by at least a second. The test run must have its suite and spec runs
+// loaded. Runs that are still in progress or were abandoned are ignored.
+func DetectDurationRegressions(db *gorm.DB, testRun *models.TestRun, options DurationRegressionOptions) ([]models.DurationRegression, error) {
+	if slices.Contains([]string{utils.StatusInProgress, utils.StatusAbandoned}, testRun.Status) {
+		return nil, nil
+	}
+
+	baselineRuns, err := loadBaselineRuns(db, testRun, max(options.Window, 1))
+	if err != nil {
+		return nil, err
+	}
+	baseline := map[durationKey][]int64{}
+	for i := range baselineRuns {
+		for key, durations := range runDurations(&baselineRuns[i]) {
+			baseline[key] = append(baseline[key], durations...)
+		}
+	}
+
+	regressions := []models.DurationRegression{}
+	for key, durations := range runDurations(testRun) {
+		samples := baseline[key]
+		if len(samples) < max(options.MinSamples, 2) {
+			continue
+		}
+		mean, stddev := meanAndStddev(samples)
+		duration := slices.Max(durations)
+		if float64(duration)-mean < float64(minSlowdown.Milliseconds()) {
+			continue
+		}
+		// A baseline without any variance would make every slowdown infinitely
+		// significant; one millisecond is the resolution durations are kept at.
+		zScore := (float64(duration) - mean) / math.Max(stddev, 1)
+		if zScore <= options.Threshold {
+			continue
+		}
+		regressions = append(regressions, models.DurationRegression{
+			ProjectID:        testRun.ProjectID,
+			TestRunID:        testRun.ID,
+			GitBranch:        testRun.GitBranch,
+			GitSha:           testRun.GitSha,
+			Kind:             key.kind,
+			SuiteName:        key.spec.suiteName,
+			SpecDescription:  key.spec.specDescription,
+			DurationMs:       duration,
+			BaselineMeanMs:   mean,
+			BaselineStddevMs: stddev,
+			BaselineSamples:  len(samples),
+			ZScore:           zScore,
+		})
+	}
+	slices.SortFunc(regressions, func(a, b models.DurationRegression) int {
+		return cmp.Or(cmp.Compare(b.ZScore, a.ZScore),
+			cmp.Compare(a.SuiteName, b.SuiteName),
+			cmp.Compare(a.SpecDescription, b.SpecDescription))
+	})
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("test_run_id = ?", testRun.ID).Delete(&models.DurationRegression{}).Error; err != nil {
+			return err
+		}
+		if len(regressions) == 0 {
+			return nil
+		}
+		return tx.Create(&regressions).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return regressions, nil
+}
+
+// recordDurationRegressions runs the duration regression detector with the
+// configured options after a run was stored. Failures are logged rather than
+// returned so that they never fail the request that completed the run.
+func recordDurationRegressions(db *gorm.DB, testRun *models.TestRun) {
+	regressions, err := DetectDurationRegressions(db, testRun, DurationRegressionOptionsFromConfig())
+	if err != nil {
+		log.Printf("error detecting duration regressions of test run %d: %v", testRun.ID, err)
+		return
+	}
+	if len(regressions) > 0 {
+		log.Printf("Recorded %d duration regressions for test run %d", len(regressions), testRun.ID)
+	}
+}
+
+// DurationRegressionFilter selects recorded duration regressions. Zero values
+// leave a field unfiltered.
+type DurationRegressionFilter struct {
+	ProjectID uint64
+	TestRunID uint64
+	Branch    string
+	From      time.Time
+	To        time.Time
+	// Limit caps the number of regressions returned when positive.
+	Limit int
+}
+
+// FindDurationRegressions returns the recorded duration regressions matching
+// the filter, most recent first and most significant first within a run.
+func FindDurationRegressions(db *gorm.DB, filter DurationRegressionFilter) ([]models.DurationRegression, error) {
+	query := db.Model(&models.DurationRegression{})
+	if filter.ProjectID != 0 {
+		query = query.Where("project_id = ?", filter.ProjectID)
+	}
+	if filter.TestRunID != 0 {
+		query = query.Where("test_run_id = ?", filter.TestRunID)
+	}
+	if filter.Branch != "" {
+		query = query.Where("git_branch = ?", filter.Branch)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	regressions := []models.DurationRegression{}
+	err := query.Order("test_run_id DESC, z_score DESC, id").Find(&regressions).Error
+	return regressions, err
+}
+
+// GetDurationRegressions returns the duration regressions recorded for the
+// project with the given UUID. Optional query parameters: branch, from and to
+// (2006-01-02T15:04:05, defaulting to the last 30 days) and limit.
+func (h *Handler) GetDurationRegressions(c *gin.Context) {
+	projectUUID := c.Param("projectUUID")
+
+	now := time.Now()
+	from, err := ParseTimeFromStringWithDefault(c.Query("from"), now.AddDate(0, 0, -defaultDurationRegressionDays))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid from parameter: %q", c.Query("from"))})
+		return
+	}
+	to, err := ParseTimeFromStringWithDefault(c.Query("to"), now)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid to parameter: %q", c.Query("to"))})
+		return
+	}
+	limit, err := QueryPositiveInt(c, "limit", defaultDurationRegressionLimit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	projectID, err := getProjectIDByUUID(c, h.db, projectUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Project ID %s not found", projectUUID)})
+		return
+	}
+
+	regressions, err := FindDurationRegressions(h.db, DurationRegressionFilter{
+		ProjectID: projectID,
+		Branch:    c.Query("branch"),
+		From:      from,
+		To:        to,
+		Limit:     limit,
+	})
+	if err != nil {
+		log.Printf("error fetching duration regressions for project %s: %v", projectUUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error fetching duration regressions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id":  projectUUID,
+		"from":        from,
+		"to":          to,
+		"branch":      c.Query("branch"),
+		"regressions": regressions,
+	})
+}
+
+// GetTestRunDurationRegressions returns the duration regressions recorded for
+// a test run.
+func (h *Handler) GetTestRunDurationRegressions(c *gin.Context) {
+	testRunID, err := parseTestRunID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := scopeTestRuns(c, h.db.Select("id")).First(&models.TestRun{}, testRunID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("test run %d not found", testRunID)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error fetching test run"})
+		return
+	}
+
+	regressions, err := FindDurationRegressions(h.db, DurationRegressionFilter{TestRunID: testRunID})
+	if err != nil {
+		log.Printf("error fetching duration regressions for test run %d: %v", testRunID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error fetching duration regressions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"test_run_id": testRunID,
+		"regressions": regressions,
+	})
+}
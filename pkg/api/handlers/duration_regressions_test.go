@@ -0,0 +1,229 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Duration regressions", func() {
+	var (
+		db        *gorm.DB
+		projectID uint64
+		runStart  time.Time
+		options   handlers.DurationRegressionOptions
+	)
+
+	// newRun builds a run of the Cart suite with one passed spec, "checks out",
+	// that takes specDuration and a suite that takes suiteDuration.
+	newRun := func(branch string, suiteDuration time.Duration, specDuration time.Duration) models.TestRun {
+		runStart = runStart.Add(time.Hour)
+		return models.TestRun{
+			ProjectID: projectID,
+			StartTime: runStart,
+			EndTime:   runStart.Add(suiteDuration),
+			Status:    "passed",
+			GitBranch: branch,
+			GitSha:    fmt.Sprintf("sha-%d", runStart.Unix()),
+			SuiteRuns: []models.SuiteRun{{
+				SuiteName: "Cart",
+				StartTime: runStart,
+				EndTime:   runStart.Add(suiteDuration),
+				SpecRuns: []models.SpecRun{{
+					SpecDescription: "checks out",
+					Status:          "passed",
+					StartTime:       runStart,
+					EndTime:         runStart.Add(specDuration),
+				}},
+			}},
+		}
+	}
+
+	addRun := func(branch string, suiteDuration time.Duration, specDuration time.Duration) models.TestRun {
+		testRun := newRun(branch, suiteDuration, specDuration)
+		Expect(db.Create(&testRun).Error).NotTo(HaveOccurred())
+		return testRun
+	}
+
+	// addBaseline stores count runs on main whose durations vary slightly
+	// around a minute for the suite and two seconds for the spec.
+	addBaseline := func(count int) {
+		for i := 0; i < count; i++ {
+			jitter := time.Duration(i%3) * 50 * time.Millisecond
+			addRun("main", time.Minute+jitter, 2*time.Second+jitter)
+		}
+	}
+
+	BeforeEach(func() {
+		db = setupTestDB()
+		project := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "project-uuid", project.ID).Error).NotTo(HaveOccurred())
+		projectID = project.ID
+		runStart = time.Now().Add(-30 * 24 * time.Hour)
+		options = handlers.DurationRegressionOptions{Threshold: 3, MinSamples: 5, Window: 20}
+	})
+
+	Describe("DetectDurationRegressions", func() {
+		It("records specs and suites that slowed down significantly", func() {
+			addBaseline(6)
+			addRun("feature", time.Minute, 10*time.Second)
+			head := addRun("main", time.Minute+30*time.Second, 8*time.Second)
+
+			regressions, err := handlers.DetectDurationRegressions(db, &head, options)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(regressions).To(HaveLen(2))
+
+			spec := regressions[0]
+			if spec.Kind != handlers.DurationRegressionKindSpec {
+				spec = regressions[1]
+			}
+			Expect(spec.Kind).To(Equal(handlers.DurationRegressionKindSpec))
+			Expect(spec.SuiteName).To(Equal("Cart"))
+			Expect(spec.SpecDescription).To(Equal("checks out"))
+			Expect(spec.DurationMs).To(Equal(int64(8000)))
+			Expect(spec.BaselineSamples).To(Equal(6))
+			Expect(spec.BaselineMeanMs).To(BeNumerically("~", 2050.0))
+			Expect(spec.ZScore).To(BeNumerically(">", 3))
+			Expect(spec.GitBranch).To(Equal("main"))
+			Expect(spec.GitSha).To(Equal(head.GitSha))
+
+			var stored []models.DurationRegression
+			Expect(db.Where("test_run_id = ?", head.ID).Find(&stored).Error).NotTo(HaveOccurred())
+			Expect(stored).To(HaveLen(2))
+			Expect(stored[0].ProjectID).To(Equal(projectID))
+		})
+
+		It("ignores slowdowns within the threshold or below a second", func() {
+			addBaseline(6)
+			head := addRun("main", time.Minute+80*time.Millisecond, 2*time.Second+900*time.Millisecond)
+
+			regressions, err := handlers.DetectDurationRegressions(db, &head, options)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(regressions).To(BeEmpty())
+
+			options.Threshold = 1000
+			head = addRun("main", time.Minute, 6*time.Second)
+			regressions, err = handlers.DetectDurationRegressions(db, &head, options)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(regressions).To(BeEmpty())
+		})
+
+		It("takes the baseline from runs that were uploaded whole rather than opened and closed", func() {
+			addBaseline(6)
+			Expect(db.Exec("UPDATE test_runs SET status = ''").Error).NotTo(HaveOccurred())
+			head := addRun("main", time.Minute, 8*time.Second)
+
+			regressions, err := handlers.DetectDurationRegressions(db, &head, options)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(regressions).To(HaveLen(1))
+			Expect(regressions[0].BaselineSamples).To(Equal(6))
+		})
+
+		It("needs the minimum number of samples on the same branch", func() {
+			addBaseline(4)
+			addRun("feature", time.Minute, 2*time.Second)
+			head := addRun("main", time.Minute, 8*time.Second)
+
+			regressions, err := handlers.DetectDurationRegressions(db, &head, options)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(regressions).To(BeEmpty())
+		})
+
+		It("replaces the regressions recorded for a run and skips open runs", func() {
+			addBaseline(6)
+			head := addRun("main", time.Minute, 8*time.Second)
+
+			_, err := handlers.DetectDurationRegressions(db, &head, options)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = handlers.DetectDurationRegressions(db, &head, options)
+			Expect(err).NotTo(HaveOccurred())
+
+			var count int64
+			Expect(db.Model(&models.DurationRegression{}).Count(&count).Error).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+
+			open := addRun("main", time.Minute, 8*time.Second)
+			open.Status = "in_progress"
+			regressions, err := handlers.DetectDurationRegressions(db, &open, options)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(regressions).To(BeEmpty())
+		})
+	})
+
+	Describe("REST endpoints", func() {
+		var router *gin.Engine
+
+		get := func(path string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("GET", path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		BeforeEach(func() {
+			gin.SetMode(gin.TestMode)
+			router = gin.New()
+			handler := handlers.NewHandler(db)
+			router.POST("/api/testrun", handler.CreateTestRun)
+			router.GET("/api/reports/duration-regressions/:projectUUID", handler.GetDurationRegressions)
+			router.GET("/api/reports/testruns/:id/duration-regressions", handler.GetTestRunDurationRegressions)
+		})
+
+		It("detects regressions of uploaded runs and lists them", func() {
+			addBaseline(12)
+			upload := newRun("main", time.Minute, 9*time.Second)
+			upload.TestProjectID = "project-uuid"
+			body, err := json.Marshal(upload)
+			Expect(err).NotTo(HaveOccurred())
+
+			req, _ := http.NewRequest("POST", "/api/testrun", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			var created models.TestRun
+			Expect(json.Unmarshal(w.Body.Bytes(), &created)).To(Succeed())
+
+			var response struct {
+				Regressions []models.DurationRegression `json:"regressions"`
+			}
+			w = get("/api/reports/duration-regressions/project-uuid?branch=main")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Regressions).To(HaveLen(1))
+			Expect(response.Regressions[0].TestRunID).To(Equal(created.ID))
+			Expect(response.Regressions[0].SpecDescription).To(Equal("checks out"))
+			Expect(response.Regressions[0].BaselineSamples).To(Equal(12))
+
+			w = get("/api/reports/duration-regressions/project-uuid?branch=feature")
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Regressions).NotTo(BeNil())
+			Expect(response.Regressions).To(BeEmpty())
+
+			w = get(fmt.Sprintf("/api/reports/testruns/%d/duration-regressions", created.ID))
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Regressions).To(HaveLen(1))
+		})
+
+		It("rejects invalid parameters, unknown projects and unknown runs", func() {
+			Expect(get("/api/reports/duration-regressions/project-uuid?from=yesterday").Code).To(Equal(http.StatusBadRequest))
+			Expect(get("/api/reports/duration-regressions/project-uuid?limit=0").Code).To(Equal(http.StatusBadRequest))
+			Expect(get("/api/reports/duration-regressions/unknown").Code).To(Equal(http.StatusNotFound))
+			Expect(get("/api/reports/testruns/abc/duration-regressions").Code).To(Equal(http.StatusBadRequest))
+			Expect(get("/api/reports/testruns/999/duration-regressions").Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultDurationDays       = 30
+	defaultLeaderboardLimit   = 50
+	defaultSlowSpecMinRuns    = 5
+	defaultLeaderboardSortKey = "p50"
+)
+
+// leaderboardSortColumns maps the sort parameter of the leaderboard to the
+// statistic it orders by.
+var leaderboardSortColumns = map[string]string{
+	"p50": "p50_ms",
+	"p90": "p90_ms",
+	"p99": "p99_ms",
+	"max": "max_ms",
+}
+
+// SpecDurationOptions selects the spec runs whose durations are aggregated.
+// Skipped specs and specs without a recorded duration are always left out.
+type SpecDurationOptions struct {
+	ProjectID uint64
+	From      time.Time
+	To        time.Time
+	// MinRuns leaves out specs with fewer runs in the window.
+	MinRuns int
+	// Limit caps the number of specs returned when positive.
+	Limit int
+}
+
+const specDurationStatsSelect = "test_cases.fingerprint, test_cases.suite_name, test_cases.spec_description, " +
+	"COUNT(*) AS runs, " +
+	"percentile_cont(0.5) WITHIN GROUP (ORDER BY durations.duration_ms) AS p50_ms, " +
+	"percentile_cont(0.9) WITHIN GROUP (ORDER BY durations.duration_ms) AS p90_ms, " +
+	"percentile_cont(0.95) WITHIN GROUP (ORDER BY durations.duration_ms) AS p95_ms, " +
+	"percentile_cont(0.99) WITHIN GROUP (ORDER BY durations.duration_ms) AS p99_ms, " +
+	"MAX(durations.duration_ms) AS max_ms, " +
+	"stddev_samp(durations.duration_ms) AS stddev_ms"
+
+type specDurationRow struct {
+	Fingerprint      string
+	SuiteName        string
+	SpecDescription  string
+	Runs             int
+	P50Ms            float64
+	P90Ms            float64
+	P95Ms            float64
+	P99Ms            float64
+	MaxMs            float64
+	StddevMs         *float64
+	LatestTestRunID  uint64
+	LatestDurationMs float64
+}
+
+func (row specDurationRow) stats() models.SpecDurationStats {
+	return models.SpecDurationStats{
+		Fingerprint:     row.Fingerprint,
+		SuiteName:       row.SuiteName,
+		SpecDescription: row.SpecDescription,
+		Runs:            row.Runs,
+		P50Ms:           int64(math.Round(row.P50Ms)),
+		P90Ms:           int64(math.Round(row.P90Ms)),
+		P95Ms:           int64(math.Round(row.P95Ms)),
+		P99Ms:           int64(math.Round(row.P99Ms)),
+		MaxMs:           int64(math.Round(row.MaxMs)),
+		StddevMs:        row.StddevMs,
+	}
+}
+
+// specDurations selects the id, test case, run and duration in milliseconds of
+// every spec run the options cover.
+func specDurations(db *gorm.DB, options SpecDurationOptions) *gorm.DB {
+	return db.Table("spec_runs").
+		Select("spec_runs.id, spec_runs.test_case_id, test_runs.id AS test_run_id, test_runs.start_time AS run_start_time, "+
+			"EXTRACT(EPOCH FROM (spec_runs.end_time - spec_runs.start_time)) * 1000 AS duration_ms").
+		Joins("JOIN suite_runs ON suite_runs.id = spec_runs.suite_id").
+		Joins("JOIN test_runs ON test_runs.id = suite_runs.test_run_id").
+		Where("test_runs.project_id = ? AND test_runs.start_time >= ? AND test_runs.start_time < ?",
+			options.ProjectID, options.From, options.To).
+		Where("spec_runs.test_case_id IS NOT NULL AND spec_runs.status <> ? AND spec_runs.end_time > spec_runs.start_time",
+			utils.StatusSkipped)
+}
+
+// FindSpecDurationStats returns duration percentiles, maximum and standard
+// deviation per spec, slowest first by the given column of
+// leaderboardSortColumns.
+func FindSpecDurationStats(db *gorm.DB, options SpecDurationOptions, sortColumn string) ([]models.SpecDurationStats, error) {
+	query := db.Table("(?) AS durations", specDurations(db, options)).
+		Select(specDurationStatsSelect).
+		Joins("JOIN test_cases ON test_cases.id = durations.test_case_id").
+		Group("test_cases.id, test_cases.fingerprint, test_cases.suite_name, test_cases.spec_description").
+		Having("COUNT(*) >= ?", max(options.MinRuns, 1)).
+		Order(sortColumn + " DESC, test_cases.id")
+	if options.Limit > 0 {
+		query = query.Limit(options.Limit)
+	}
+
+	var rows []specDurationRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	stats := make([]models.SpecDurationStats, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, row.stats())
+	}
+	return stats, nil
+}
+
+// FindSlowSpecs returns the specs whose latest run took more than factor
+// times their p95 duration. The statistics describe the runs before the latest
+// one, so a single slow run cannot raise its own threshold. Specs are ordered
+// by how far the latest run exceeds the threshold.
+func FindSlowSpecs(db *gorm.DB, options SpecDurationOptions, factor float64) ([]models.SlowSpec, error) {
+	latest := db.Table("(?) AS durations", specDurations(db, options)).
+		Select("DISTINCT ON (durations.test_case_id) durations.id, durations.test_case_id, durations.test_run_id, durations.duration_ms").
+		Order("durations.test_case_id, durations.run_start_time DESC, durations.id DESC")
+
+	var rows []specDurationRow
+	if err := db.Table("(?) AS durations", specDurations(db, options)).
+		Select(specDurationStatsSelect+", latest.test_run_id AS latest_test_run_id, latest.duration_ms AS latest_duration_ms").
+		Joins("JOIN (?) AS latest ON latest.test_case_id = durations.test_case_id AND latest.id <> durations.id", latest).
+		Joins("JOIN test_cases ON test_cases.id = durations.test_case_id").
+		Group("test_cases.id, test_cases.fingerprint, test_cases.suite_name, test_cases.spec_description, "+
+			"latest.test_run_id, latest.duration_ms").
+		Having("COUNT(*) >= ? AND latest.duration_ms > ? * percentile_cont(0.95) WITHIN GROUP (ORDER BY durations.duration_ms)",
+			max(options.MinRuns, 1), factor).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	slow := make([]models.SlowSpec, 0, len(rows))
+	for _, row := range rows {
+		spec := models.SlowSpec{
+			SpecDurationStats: row.stats(),
+			LatestTestRunID:   row.LatestTestRunID,
+			LatestDurationMs:  int64(math.Round(row.LatestDurationMs)),
+		}
+		if row.P95Ms > 0 {
+			spec.Ratio = row.LatestDurationMs / row.P95Ms
+		}
+		slow = append(slow, spec)
+	}
+	sort.SliceStable(slow, func(i, j int) bool {
+		return slow[i].Ratio > slow[j].Ratio
+	})
+	if options.Limit > 0 && len(slow) > options.Limit {
+		slow = slow[:options.Limit]
+	}
+	return slow, nil
+}
+
+// specDurationOptionsFromQuery reads the project, from, to, limit and min_runs
+// parameters shared by the duration endpoints, writing an error response and
+// returning false when they are invalid.
+func (h *Handler) specDurationOptionsFromQuery(c *gin.Context, defaultMinRuns int) (SpecDurationOptions, bool) {
+	projectUUID := c.Param("projectUUID")
+
+	now := time.Now()
+	from, err := ParseTimeFromStringWithDefault(c.Query("from"), now.AddDate(0, 0, -defaultDurationDays))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid from parameter: %q", c.Query("from"))})
+		return SpecDurationOptions{}, false
+	}
+	to, err := ParseTimeFromStringWithDefault(c.Query("to"), now)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid to parameter: %q", c.Query("to"))})
+		return SpecDurationOptions{}, false
+	}
+	limit, err := QueryPositiveInt(c, "limit", defaultLeaderboardLimit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return SpecDurationOptions{}, false
+	}
+	minRuns, err := QueryPositiveInt(c, "min_runs", defaultMinRuns)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return SpecDurationOptions{}, false
+	}
+
+	projectID, err := getProjectIDByUUID(c, h.db, projectUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Project ID %s not found", projectUUID)})
+		return SpecDurationOptions{}, false
+	}
+
+	return SpecDurationOptions{ProjectID: projectID, From: from, To: to, MinRuns: minRuns, Limit: limit}, true
+}
+
+// GetSpecDurationLeaderboard returns per-spec duration statistics of a project,
+// slowest first. Optional query parameters: sort (p50, p90, p99 or max,
+// default p50), from and to (2006-01-02T15:04:05, defaulting to the last 30
+// days), limit and min_runs.
+func (h *Handler) GetSpecDurationLeaderboard(c *gin.Context) {
+	sortKey := c.DefaultQuery("sort", defaultLeaderboardSortKey)
+	sortColumn, ok := leaderboardSortColumns[sortKey]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid sort parameter: %q, expected p50, p90, p99 or max", sortKey)})
+		return
+	}
+	options, ok := h.specDurationOptionsFromQuery(c, 1)
+	if !ok {
+		return
+	}
+
+	stats, err := FindSpecDurationStats(h.db, options, sortColumn)
+	if err != nil {
+		log.Printf("error computing spec durations for project %s: %v", c.Param("projectUUID"), err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error computing spec durations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id": c.Param("projectUUID"),
+		"from":       options.From,
+		"to":         options.To,
+		"sort":       sortKey,
+		"specs":      stats,
+	})
+}
+
+// GetSlowSpecs returns the specs of a project whose latest run exceeded their
+// p95 duration by a factor. Optional query parameters: factor (defaults to
+// reports.slow-spec-factor in the config), from and to, limit and min_runs
+// (default 5).
+func (h *Handler) GetSlowSpecs(c *gin.Context) {
+	factor := config.GetReports().SlowSpecFactor
+	if value := c.Query("factor"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid factor parameter: %q", value)})
+			return
+		}
+		factor = parsed
+	}
+	options, ok := h.specDurationOptionsFromQuery(c, defaultSlowSpecMinRuns)
+	if !ok {
+		return
+	}
+
+	slow, err := FindSlowSpecs(h.db, options, factor)
+	if err != nil {
+		log.Printf("error finding slow specs for project %s: %v", c.Param("projectUUID"), err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error finding slow specs"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id": c.Param("projectUUID"),
+		"from":       options.From,
+		"to":         options.To,
+		"factor":     factor,
+		"specs":      slow,
+	})
+}
@@ -0,0 +1,152 @@
+package handlers_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Spec durations", func() {
+	var (
+		db     *sql.DB
+		mock   sqlmock.Sqlmock
+		router *gin.Engine
+	)
+
+	projectQuery := regexp.QuoteMeta(`SELECT * FROM "project_details" WHERE uuid = $1 ORDER BY "project_details"."id" LIMIT $2`)
+	statsColumns := []string{"fingerprint", "suite_name", "spec_description", "runs", "p50_ms", "p90_ms", "p95_ms", "p99_ms", "max_ms", "stddev_ms"}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	expectProject := func() {
+		mock.ExpectQuery(projectQuery).
+			WithArgs("project-uuid", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "uuid"}).AddRow(7, "project-uuid"))
+	}
+
+	BeforeEach(func() {
+		_, err := config.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+
+		db, mock, _ = sqlmock.New()
+		gormDb, _ := gorm.Open(postgres.New(postgres.Config{
+			DSN:                  "sqlmock_db_0",
+			DriverName:           "postgres",
+			Conn:                 db,
+			PreferSimpleProtocol: true,
+		}), &gorm.Config{})
+
+		gin.SetMode(gin.TestMode)
+		router = gin.New()
+		handler := handlers.NewHandler(gormDb)
+		router.GET("/api/reports/durations/:projectUUID", handler.GetSpecDurationLeaderboard)
+		router.GET("/api/reports/durations/:projectUUID/slow", handler.GetSlowSpecs)
+	})
+
+	AfterEach(func() {
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		_ = db.Close()
+	})
+
+	It("ranks specs by the requested percentile", func() {
+		expectProject()
+		mock.ExpectQuery(`SELECT test_cases.fingerprint, .*percentile_cont\(0.99\) WITHIN GROUP \(ORDER BY durations.duration_ms\) AS p99_ms, ` +
+			`MAX\(durations.duration_ms\) AS max_ms, stddev_samp\(durations.duration_ms\) AS stddev_ms ` +
+			`FROM \(SELECT spec_runs.id, .* AS duration_ms FROM "spec_runs" .*\) AS durations ` +
+			`JOIN test_cases ON test_cases.id = durations.test_case_id GROUP BY .* HAVING COUNT\(\*\) >= \$\d+ ` +
+			`ORDER BY p99_ms DESC, test_cases.id LIMIT \$\d+`).
+			WillReturnRows(sqlmock.NewRows(statsColumns).
+				AddRow("abc", "Cart", "checks out", 12, 1500.4, 2100.0, 2500.0, 4000.6, 4200.0, 310.5).
+				AddRow("def", "Cart", "adds items", 1, 900.0, 900.0, 900.0, 900.0, 900.0, nil))
+
+		w := get("/api/reports/durations/project-uuid?sort=p99&limit=2")
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		var response struct {
+			Sort  string                     `json:"sort"`
+			Specs []models.SpecDurationStats `json:"specs"`
+		}
+		Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+		Expect(response.Sort).To(Equal("p99"))
+		Expect(response.Specs).To(HaveLen(2))
+		Expect(response.Specs[0].Fingerprint).To(Equal("abc"))
+		Expect(response.Specs[0].P50Ms).To(Equal(int64(1500)))
+		Expect(response.Specs[0].P99Ms).To(Equal(int64(4001)))
+		Expect(*response.Specs[0].StddevMs).To(Equal(310.5))
+		Expect(response.Specs[1].StddevMs).To(BeNil())
+	})
+
+	It("finds specs whose latest run exceeds their p95 by the factor", func() {
+		expectProject()
+		mock.ExpectQuery(`SELECT test_cases.fingerprint, .* latest.test_run_id AS latest_test_run_id, latest.duration_ms AS latest_duration_ms ` +
+			`FROM \(SELECT spec_runs.id, .*\) AS durations ` +
+			`JOIN \(SELECT DISTINCT ON \(durations.test_case_id\) .* ORDER BY durations.test_case_id, durations.run_start_time DESC, durations.id DESC\) AS latest ` +
+			`ON latest.test_case_id = durations.test_case_id AND latest.id <> durations.id .*` +
+			`HAVING COUNT\(\*\) >= \$\d+ AND latest.duration_ms > \$\d+ \* percentile_cont\(0.95\) WITHIN GROUP \(ORDER BY durations.duration_ms\)`).
+			WillReturnRows(sqlmock.NewRows(append(statsColumns, "latest_test_run_id", "latest_duration_ms")).
+				AddRow("abc", "Cart", "checks out", 10, 1000.0, 1100.0, 1200.0, 1300.0, 1300.0, 50.0, 42, 3600.0).
+				AddRow("def", "Cart", "adds items", 8, 100.0, 110.0, 120.0, 130.0, 130.0, 5.0, 42, 480.0))
+
+		w := get("/api/reports/durations/project-uuid/slow?factor=2")
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		var response struct {
+			Factor float64           `json:"factor"`
+			Specs  []models.SlowSpec `json:"specs"`
+		}
+		Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+		Expect(response.Factor).To(Equal(2.0))
+		Expect(response.Specs).To(HaveLen(2))
+		Expect(response.Specs[0].Fingerprint).To(Equal("def"))
+		Expect(response.Specs[0].Ratio).To(Equal(4.0))
+		Expect(response.Specs[1].LatestTestRunID).To(Equal(uint64(42)))
+		Expect(response.Specs[1].LatestDurationMs).To(Equal(int64(3600)))
+		Expect(response.Specs[1].Ratio).To(Equal(3.0))
+	})
+
+	It("defaults the factor to the configured value", func() {
+		expectProject()
+		mock.ExpectQuery(`SELECT test_cases.fingerprint, .* latest.duration_ms AS latest_duration_ms .*`).
+			WillReturnRows(sqlmock.NewRows(append(statsColumns, "latest_test_run_id", "latest_duration_ms")))
+
+		w := get("/api/reports/durations/project-uuid/slow")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var response struct {
+			Factor float64           `json:"factor"`
+			Specs  []models.SlowSpec `json:"specs"`
+		}
+		Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+		Expect(response.Factor).To(Equal(config.GetReports().SlowSpecFactor))
+		Expect(response.Specs).NotTo(BeNil())
+		Expect(response.Specs).To(BeEmpty())
+	})
+
+	It("rejects invalid parameters and unknown projects", func() {
+		Expect(get("/api/reports/durations/project-uuid?sort=mean").Code).To(Equal(http.StatusBadRequest))
+		Expect(get("/api/reports/durations/project-uuid/slow?factor=0").Code).To(Equal(http.StatusBadRequest))
+		Expect(get("/api/reports/durations/project-uuid?min_runs=-1").Code).To(Equal(http.StatusBadRequest))
+
+		mock.ExpectQuery(projectQuery).
+			WithArgs("unknown", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "uuid"}))
+		Expect(get("/api/reports/durations/unknown").Code).To(Equal(http.StatusNotFound))
+	})
+})
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultFailureDays  = 7
+	defaultClusterLimit = 50
+)
+
+// FailureClusterFilter selects the failures that are clustered, either those of
+// a single test run or those of a project's runs in a time range.
+type FailureClusterFilter struct {
+	// TestRunID limits the clusters to one run when set.
+	TestRunID uint64
+	// ProjectID, From and To select runs by project and start time when
+	// TestRunID is not set.
+	ProjectID uint64
+	From      time.Time
+	To        time.Time
+	// Limit caps the number of clusters returned.
+	Limit int
+}
+
+// SignFailures stores the failure signature of every failed spec of the run so
+// that failures with the same cause can be grouped without reading messages.
+func SignFailures(testRun *models.TestRun) {
+	for i, suite := range testRun.SuiteRuns {
+		for j, spec := range suite.SpecRuns {
+			signature := ""
+			if slices.Contains(utils.FailedStatuses, spec.Status) {
+				signature = utils.FailureSignature(spec.Message)
+			}
+			testRun.SuiteRuns[i].SpecRuns[j].FailureSignature = signature
+		}
+	}
+}
+
+type specFailure struct {
+	TestRunID        uint64
+	RunStartTime     time.Time
+	SuiteName        string
+	SpecDescription  string
+	Message          string
+	FailureSignature string
+}
+
+// FindFailureClusters groups failed specs by failure signature, largest
+// clusters first. Failures stored before signatures were recorded are signed
+// on the fly.
+func FindFailureClusters(db *gorm.DB, filter FailureClusterFilter) ([]models.FailureCluster, error) {
+	query := db.Table("spec_runs").
+		Select("test_runs.id AS test_run_id, test_runs.start_time AS run_start_time, suite_runs.suite_name, "+
+			"spec_runs.spec_description, spec_runs.message, spec_runs.failure_signature").
+		Joins("JOIN suite_runs ON suite_runs.id = spec_runs.suite_id").
+		Joins("JOIN test_runs ON test_runs.id = suite_runs.test_run_id").
+		Where("spec_runs.status IN ?", utils.FailedStatuses)
+	if filter.TestRunID != 0 {
+		query = query.Where("test_runs.id = ?", filter.TestRunID)
+	} else {
+		query = query.Where("test_runs.project_id = ? AND test_runs.start_time >= ? AND test_runs.start_time <= ?",
+			filter.ProjectID, filter.From, filter.To)
+	}
+
+	var failures []specFailure
+	if err := query.Order("test_runs.start_time, test_runs.id, spec_runs.id").Scan(&failures).Error; err != nil {
+		return nil, err
+	}
+
+	clusters := map[string]*models.FailureCluster{}
+	specs := map[string]map[specIdentity]bool{}
+	runs := map[string]map[uint64]bool{}
+	var order []string
+	for _, failure := range failures {
+		signature := failure.FailureSignature
+		if signature == "" {
+			signature = utils.FailureSignature(failure.Message)
+		}
+
+		cluster, ok := clusters[signature]
+		if !ok {
+			cluster = &models.FailureCluster{
+				Signature:         signature,
+				NormalizedMessage: utils.NormalizeFailureMessage(failure.Message),
+				FirstSeen:         failure.RunStartTime,
+				Suites:            []string{},
+			}
+			clusters[signature] = cluster
+			specs[signature] = map[specIdentity]bool{}
+			runs[signature] = map[uint64]bool{}
+			order = append(order, signature)
+		}
+
+		// Failures are in run order, so the last one seen is the most recent
+		cluster.Count++
+		cluster.Message = failure.Message
+		cluster.LastSeen = failure.RunStartTime
+		if !slices.Contains(cluster.Suites, failure.SuiteName) {
+			cluster.Suites = append(cluster.Suites, failure.SuiteName)
+		}
+		specs[signature][specIdentity{failure.SuiteName, failure.SpecDescription}] = true
+		runs[signature][failure.TestRunID] = true
+	}
+
+	result := make([]models.FailureCluster, 0, len(order))
+	for _, signature := range order {
+		cluster := clusters[signature]
+		cluster.Specs = len(specs[signature])
+		cluster.TestRuns = len(runs[signature])
+		sort.Strings(cluster.Suites)
+		result = append(result, *cluster)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Specs > result[j].Specs
+	})
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+	return result, nil
+}
+
+// GetTestRunFailureClusters returns the failure clusters of a test run.
+// Optional query parameter: limit.
+func (h *Handler) GetTestRunFailureClusters(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid test run id %q", c.Param("id"))})
+		return
+	}
+	limit, err := QueryPositiveInt(c, "limit", defaultClusterLimit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := scopeTestRuns(c, h.db.Where("id = ?", id)).First(&models.TestRun{}).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "test run not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading test run"})
+		return
+	}
+
+	clusters, err := FindFailureClusters(h.db, FailureClusterFilter{TestRunID: id, Limit: limit})
+	if err != nil {
+		log.Printf("error clustering failures of test run %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error clustering failures"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"test_run_id": id,
+		"clusters":    clusters,
+	})
+}
+
+// GetProjectFailureClusters returns the failure clusters of the runs of the
+// project with the given UUID. Optional query parameters: from and to
+// (2006-01-02T15:04:05, defaulting to the last 7 days) and limit.
+func (h *Handler) GetProjectFailureClusters(c *gin.Context) {
+	projectUUID := c.Param("projectUUID")
+
+	now := time.Now()
+	from, err := ParseTimeFromStringWithDefault(c.Query("from"), now.AddDate(0, 0, -defaultFailureDays))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid from parameter: %q", c.Query("from"))})
+		return
+	}
+	to, err := ParseTimeFromStringWithDefault(c.Query("to"), now)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid to parameter: %q", c.Query("to"))})
+		return
+	}
+	limit, err := QueryPositiveInt(c, "limit", defaultClusterLimit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	projectID, err := getProjectIDByUUID(c, h.db, projectUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Project ID %s not found", projectUUID)})
+		return
+	}
+
+	clusters, err := FindFailureClusters(h.db, FailureClusterFilter{ProjectID: projectID, From: from, To: to, Limit: limit})
+	if err != nil {
+		log.Printf("error clustering failures for project %s: %v", projectUUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error clustering failures"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id": projectUUID,
+		"from":       from,
+		"to":         to,
+		"clusters":   clusters,
+	})
+}
@@ -0,0 +1,163 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+var _ = Describe("Failure clustering", func() {
+	var (
+		db        *gorm.DB
+		projectID uint64
+		runStart  time.Time
+	)
+
+	// addRun stores a signed run with the given specs in the Cart and Orders suites.
+	addRun := func(cart []models.SpecRun, orders []models.SpecRun) models.TestRun {
+		runStart = runStart.Add(time.Hour)
+		testRun := models.TestRun{
+			ProjectID: projectID,
+			StartTime: runStart,
+			SuiteRuns: []models.SuiteRun{
+				{SuiteName: "Cart", SpecRuns: cart},
+				{SuiteName: "Orders", SpecRuns: orders},
+			},
+		}
+		handlers.SignFailures(&testRun)
+		Expect(db.Create(&testRun).Error).NotTo(HaveOccurred())
+		return testRun
+	}
+
+	refused := func(description string, port int) models.SpecRun {
+		return models.SpecRun{SpecDescription: description, Status: "failed", Message: fmt.Sprintf("dial tcp 10.0.0.1:%d: connection refused", port)}
+	}
+
+	BeforeEach(func() {
+		db = setupTestDB()
+		project := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "project-uuid", project.ID).Error).NotTo(HaveOccurred())
+		projectID = project.ID
+		runStart = time.Now().Add(-24 * time.Hour)
+	})
+
+	It("signs failed specs only", func() {
+		testRun := models.TestRun{SuiteRuns: []models.SuiteRun{{SpecRuns: []models.SpecRun{
+			{Status: "passed", Message: "ok"},
+			{Status: "panicked", Message: "nil pointer at 0xc000010000"},
+		}}}}
+		handlers.SignFailures(&testRun)
+		Expect(testRun.SuiteRuns[0].SpecRuns[0].FailureSignature).To(BeEmpty())
+		Expect(testRun.SuiteRuns[0].SpecRuns[1].FailureSignature).To(Equal(utils.FailureSignature("nil pointer at 0xc000010000")))
+	})
+
+	It("groups the failures of a run by normalized message", func() {
+		testRun := addRun(
+			[]models.SpecRun{refused("adds items", 5432), refused("removes items", 5433), {SpecDescription: "totals", Status: "passed"}},
+			[]models.SpecRun{refused("places order", 5434), {SpecDescription: "cancels", Status: "failed", Message: "expected 2 orders, got 3"}},
+		)
+
+		clusters, err := handlers.FindFailureClusters(db, handlers.FailureClusterFilter{TestRunID: testRun.ID})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters).To(HaveLen(2))
+
+		Expect(clusters[0].Count).To(Equal(3))
+		Expect(clusters[0].Specs).To(Equal(3))
+		Expect(clusters[0].TestRuns).To(Equal(1))
+		Expect(clusters[0].Suites).To(Equal([]string{"Cart", "Orders"}))
+		Expect(clusters[0].NormalizedMessage).To(Equal("dial tcp <n>.<n>.<n>.<n>:<n>: connection refused"))
+		Expect(clusters[0].Message).To(Equal("dial tcp 10.0.0.1:5434: connection refused"))
+
+		Expect(clusters[1].Count).To(Equal(1))
+		Expect(clusters[1].Suites).To(Equal([]string{"Orders"}))
+	})
+
+	It("clusters across the runs of a project and signs older failures on the fly", func() {
+		addRun([]models.SpecRun{refused("adds items", 5432)}, nil)
+		unsigned := addRun([]models.SpecRun{refused("adds items", 5433)}, nil)
+		Expect(db.Model(&models.SpecRun{}).Where("suite_id = ?", unsigned.SuiteRuns[0].ID).
+			Update("failure_signature", "").Error).NotTo(HaveOccurred())
+
+		clusters, err := handlers.FindFailureClusters(db, handlers.FailureClusterFilter{
+			ProjectID: projectID,
+			From:      time.Now().Add(-48 * time.Hour),
+			To:        time.Now(),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters).To(HaveLen(1))
+		Expect(clusters[0].Count).To(Equal(2))
+		Expect(clusters[0].Specs).To(Equal(1))
+		Expect(clusters[0].TestRuns).To(Equal(2))
+		Expect(clusters[0].LastSeen.After(clusters[0].FirstSeen)).To(BeTrue())
+	})
+
+	Context("REST endpoints", func() {
+		var router *gin.Engine
+
+		get := func(path string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("GET", path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		BeforeEach(func() {
+			gin.SetMode(gin.TestMode)
+			router = gin.New()
+			handler := handlers.NewHandler(db)
+			router.GET("/api/reports/testruns/:id/failures", handler.GetTestRunFailureClusters)
+			router.GET("/api/reports/failures/:projectUUID", handler.GetProjectFailureClusters)
+		})
+
+		It("returns the clusters of a run", func() {
+			testRun := addRun([]models.SpecRun{refused("adds items", 5432), refused("removes items", 5433)}, nil)
+
+			w := get(fmt.Sprintf("/api/reports/testruns/%d/failures", testRun.ID))
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var response struct {
+				Clusters []models.FailureCluster `json:"clusters"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Clusters).To(HaveLen(1))
+			Expect(response.Clusters[0].Count).To(Equal(2))
+		})
+
+		It("returns the clusters of a project in a time range", func() {
+			addRun([]models.SpecRun{refused("adds items", 5432)}, nil)
+
+			w := get("/api/reports/failures/project-uuid")
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var response struct {
+				Clusters []models.FailureCluster `json:"clusters"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Clusters).To(HaveLen(1))
+
+			w = get("/api/reports/failures/project-uuid?to=" + time.Now().Add(-48*time.Hour).Format("2006-01-02T15:04:05"))
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Clusters).To(BeEmpty())
+		})
+
+		It("rejects invalid parameters and unknown runs or projects", func() {
+			Expect(get("/api/reports/testruns/abc/failures").Code).To(Equal(http.StatusBadRequest))
+			Expect(get("/api/reports/testruns/999/failures").Code).To(Equal(http.StatusNotFound))
+			Expect(get("/api/reports/failures/project-uuid?from=yesterday").Code).To(Equal(http.StatusBadRequest))
+			Expect(get("/api/reports/failures/unknown").Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
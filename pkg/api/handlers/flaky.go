@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	FlakyScopeGitSha = "git_sha"
+	FlakyScopeBranch = "branch"
+
+	defaultFlakyWindow = 20
+	defaultFlakyDays   = 30
+	defaultFlakyLimit  = 50
+)
+
+// FlakyOptions controls how spec outcomes are compared when looking for
+// flaky specs.
+type FlakyOptions struct {
+	// Scope is the column runs are grouped on before outcomes are compared,
+	// either FlakyScopeGitSha or FlakyScopeBranch.
+	Scope string
+	// Window is the number of most recent runs per group that are compared.
+	Window int
+	// Since limits the analysis to runs started after it.
+	Since time.Time
+	// MinFlips is the number of pass/fail flips for a spec to be reported.
+	MinFlips int
+	// Limit caps the number of specs returned.
+	Limit int
+}
+
+type specOutcome struct {
+	// ProjectID is only loaded by reports that span projects.
+	ProjectID       uint64
+	TestRunID       uint64
+	RunStartTime    time.Time
+	GitSha          string
+	GitBranch       string
+	SuiteName       string
+	SpecDescription string
+	Status          string
+	Message         string
+}
+
+type specIdentity struct {
+	suiteName       string
+	specDescription string
+}
+
+// FindFlakySpecs ranks the specs of a project by how often their outcome flips
+// between passed and failed across runs of the same git sha or branch. Runs of
+// the same code that disagree point to flakiness rather than a regression.
+func FindFlakySpecs(db *gorm.DB, projectID uint64, options FlakyOptions) ([]models.FlakySpec, error) {
+	var outcomes []specOutcome
+	if err := db.Table("spec_runs").
+		Select("test_runs.id AS test_run_id, test_runs.start_time AS run_start_time, test_runs.git_sha, test_runs.git_branch, "+
+			"suite_runs.suite_name, spec_runs.spec_description, spec_runs.status, spec_runs.message").
+		Joins("JOIN suite_runs ON suite_runs.id = spec_runs.suite_id").
+		Joins("JOIN test_runs ON test_runs.id = suite_runs.test_run_id").
+		Where("test_runs.project_id = ? AND test_runs.start_time >= ?", projectID, options.Since).
+		Where("spec_runs.status = ? OR spec_runs.status IN ?", utils.StatusPassed, utils.FailedStatuses).
+		Order("test_runs.start_time, test_runs.id").
+		Scan(&outcomes).Error; err != nil {
+		return nil, err
+	}
+	return rankFlakySpecs(outcomes, options), nil
+}
+
+// rankFlakySpecs finds the flaky specs among the outcomes of a project's
+// specs, oldest run first.
+func rankFlakySpecs(outcomes []specOutcome, options FlakyOptions) []models.FlakySpec {
+	// Group the history of each spec by the scope value. Runs without one
+	// may be of unrelated code, so comparing them would report regressions
+	// as flakiness.
+	type groupKey struct {
+		spec  specIdentity
+		scope string
+	}
+	groups := map[groupKey][]specOutcome{}
+	var order []groupKey
+	for _, outcome := range outcomes {
+		key := groupKey{
+			spec:  specIdentity{outcome.SuiteName, outcome.SpecDescription},
+			scope: outcome.GitSha,
+		}
+		if options.Scope == FlakyScopeBranch {
+			key.scope = outcome.GitBranch
+		}
+		if key.scope == "" {
+			continue
+		}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], outcome)
+	}
+
+	specs := map[specIdentity]*models.FlakySpec{}
+	transitions := map[specIdentity]int{}
+	var specOrder []specIdentity
+	for _, key := range order {
+		history := groups[key]
+		if len(history) > options.Window {
+			history = history[len(history)-options.Window:]
+		}
+
+		spec, ok := specs[key.spec]
+		if !ok {
+			spec = &models.FlakySpec{
+				SuiteName:       key.spec.suiteName,
+				SpecDescription: key.spec.specDescription,
+				FirstSeen:       history[0].RunStartTime,
+			}
+			specs[key.spec] = spec
+			specOrder = append(specOrder, key.spec)
+		}
+
+		for i, outcome := range history {
+			failed := outcome.Status != utils.StatusPassed
+			spec.Runs++
+			if failed {
+				spec.Failures++
+				if spec.LastFailureTime == nil || !outcome.RunStartTime.Before(*spec.LastFailureTime) {
+					failedAt := outcome.RunStartTime
+					spec.LastFailureTime = &failedAt
+					spec.LastFailureMessage = outcome.Message
+				}
+			}
+			if i > 0 && failed != (history[i-1].Status != utils.StatusPassed) {
+				spec.Flips++
+			}
+			if outcome.RunStartTime.Before(spec.FirstSeen) {
+				spec.FirstSeen = outcome.RunStartTime
+			}
+			if outcome.RunStartTime.After(spec.LastSeen) {
+				spec.LastSeen = outcome.RunStartTime
+			}
+		}
+		transitions[key.spec] += len(history) - 1
+	}
+
+	var flaky []models.FlakySpec
+	for _, identity := range specOrder {
+		spec := specs[identity]
+		if spec.Flips == 0 || spec.Flips < options.MinFlips {
+			continue
+		}
+		spec.FlipRate = float64(spec.Flips) / float64(transitions[identity])
+		flaky = append(flaky, *spec)
+	}
+
+	sort.SliceStable(flaky, func(i, j int) bool {
+		if flaky[i].FlipRate != flaky[j].FlipRate {
+			return flaky[i].FlipRate > flaky[j].FlipRate
+		}
+		return flaky[i].Flips > flaky[j].Flips
+	})
+	if options.Limit > 0 && len(flaky) > options.Limit {
+		flaky = flaky[:options.Limit]
+	}
+	return flaky
+}
+
+// GetFlakySpecs returns the flaky specs of the project with the given UUID.
+// Optional query parameters: scope (git_sha or branch), window (runs compared
+// per sha or branch), days (how far back to look), min_flips and limit.
+func (h *Handler) GetFlakySpecs(c *gin.Context) {
+	projectUUID := c.Param("projectUUID")
+
+	options := FlakyOptions{Scope: c.DefaultQuery("scope", FlakyScopeGitSha)}
+	if !slices.Contains([]string{FlakyScopeGitSha, FlakyScopeBranch}, options.Scope) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid scope parameter: %q", options.Scope)})
+		return
+	}
+
+	window, err := QueryPositiveInt(c, "window", defaultFlakyWindow)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	days, err := QueryPositiveInt(c, "days", defaultFlakyDays)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	minFlips, err := QueryPositiveInt(c, "min_flips", 1)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	limit, err := QueryPositiveInt(c, "limit", defaultFlakyLimit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	options.Window = window
+	options.MinFlips = minFlips
+	options.Limit = limit
+	options.Since = time.Now().AddDate(0, 0, -days)
+
+	projectID, err := getProjectIDByUUID(c, h.db, projectUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Project ID %s not found", projectUUID)})
+		return
+	}
+
+	flaky, err := FindFlakySpecs(h.db, projectID, options)
+	if err != nil {
+		log.Printf("error finding flaky specs for project %s: %v", projectUUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error finding flaky specs"})
+		return
+	}
+	if flaky == nil {
+		flaky = []models.FlakySpec{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id":  projectUUID,
+		"scope":       options.Scope,
+		"window":      options.Window,
+		"since":       options.Since,
+		"flaky_specs": flaky,
+	})
+}
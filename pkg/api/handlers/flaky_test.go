@@ -0,0 +1,151 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Flaky spec detection", func() {
+	var (
+		db        *gorm.DB
+		projectID uint64
+		runStart  time.Time
+	)
+
+	// addRun stores a run whose specs have the given statuses keyed by description.
+	addRun := func(sha string, branch string, statuses map[string]string) {
+		runStart = runStart.Add(time.Minute)
+		var specs []models.SpecRun
+		for description, status := range statuses {
+			specs = append(specs, models.SpecRun{SpecDescription: description, Status: status, Message: description + " " + status + " at " + runStart.Format(time.Kitchen)})
+		}
+		testRun := models.TestRun{
+			ProjectID: projectID,
+			GitSha:    sha,
+			GitBranch: branch,
+			StartTime: runStart,
+			SuiteRuns: []models.SuiteRun{{SuiteName: "Cart", SpecRuns: specs}},
+		}
+		Expect(db.Create(&testRun).Error).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		db = setupTestDB()
+		project := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "project-uuid", project.ID).Error).NotTo(HaveOccurred())
+		projectID = project.ID
+		runStart = time.Now().Add(-time.Hour)
+	})
+
+	It("ranks specs that flip on the same sha", func() {
+		addRun("sha1", "main", map[string]string{"stable": "passed", "flaky": "passed", "sometimes": "passed"})
+		addRun("sha1", "main", map[string]string{"stable": "passed", "flaky": "failed", "sometimes": "passed"})
+		addRun("sha1", "main", map[string]string{"stable": "passed", "flaky": "passed", "sometimes": "passed"})
+		addRun("sha1", "main", map[string]string{"stable": "passed", "flaky": "failed", "sometimes": "failed"})
+
+		flaky, err := handlers.FindFlakySpecs(db, projectID, handlers.FlakyOptions{Scope: handlers.FlakyScopeGitSha, Window: 20, MinFlips: 1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(flaky).To(HaveLen(2))
+
+		Expect(flaky[0].SpecDescription).To(Equal("flaky"))
+		Expect(flaky[0].SuiteName).To(Equal("Cart"))
+		Expect(flaky[0].Runs).To(Equal(4))
+		Expect(flaky[0].Failures).To(Equal(2))
+		Expect(flaky[0].Flips).To(Equal(3))
+		Expect(flaky[0].FlipRate).To(Equal(1.0))
+		Expect(flaky[0].LastFailureMessage).To(ContainSubstring(runStart.Format(time.Kitchen)))
+		Expect(flaky[0].LastFailureTime).NotTo(BeNil())
+		Expect(flaky[0].LastSeen.After(flaky[0].FirstSeen)).To(BeTrue())
+
+		Expect(flaky[1].SpecDescription).To(Equal("sometimes"))
+		Expect(flaky[1].Flips).To(Equal(1))
+	})
+
+	It("does not count a fix on a new sha as a flip unless grouped by branch", func() {
+		addRun("sha1", "main", map[string]string{"fixed": "failed"})
+		addRun("sha2", "main", map[string]string{"fixed": "passed"})
+
+		flaky, err := handlers.FindFlakySpecs(db, projectID, handlers.FlakyOptions{Scope: handlers.FlakyScopeGitSha, Window: 20, MinFlips: 1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(flaky).To(BeEmpty())
+
+		flaky, err = handlers.FindFlakySpecs(db, projectID, handlers.FlakyOptions{Scope: handlers.FlakyScopeBranch, Window: 20, MinFlips: 1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(flaky).To(HaveLen(1))
+	})
+
+	It("does not compare runs without a sha or branch", func() {
+		addRun("", "", map[string]string{"regressed": "passed"})
+		addRun("", "", map[string]string{"regressed": "failed"})
+
+		for _, scope := range []string{handlers.FlakyScopeGitSha, handlers.FlakyScopeBranch} {
+			flaky, err := handlers.FindFlakySpecs(db, projectID, handlers.FlakyOptions{Scope: scope, Window: 20, MinFlips: 1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(flaky).To(BeEmpty())
+		}
+	})
+
+	It("only compares the most recent runs in the window", func() {
+		addRun("sha1", "main", map[string]string{"recovered": "failed"})
+		addRun("sha1", "main", map[string]string{"recovered": "passed"})
+		addRun("sha1", "main", map[string]string{"recovered": "passed"})
+		addRun("sha1", "main", map[string]string{"recovered": "passed"})
+
+		flaky, err := handlers.FindFlakySpecs(db, projectID, handlers.FlakyOptions{Scope: handlers.FlakyScopeGitSha, Window: 3, MinFlips: 1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(flaky).To(BeEmpty())
+	})
+
+	Context("GET /api/reports/flaky/:projectUUID", func() {
+		var router *gin.Engine
+
+		get := func(path string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("GET", path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		BeforeEach(func() {
+			gin.SetMode(gin.TestMode)
+			router = gin.New()
+			router.GET("/api/reports/flaky/:projectUUID", handlers.NewHandler(db).GetFlakySpecs)
+		})
+
+		It("returns the ranked flaky specs", func() {
+			addRun("sha1", "main", map[string]string{"flaky": "passed"})
+			addRun("sha1", "main", map[string]string{"flaky": "failed"})
+
+			w := get("/api/reports/flaky/project-uuid?window=10")
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var response struct {
+				Scope      string             `json:"scope"`
+				Window     int                `json:"window"`
+				FlakySpecs []models.FlakySpec `json:"flaky_specs"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Scope).To(Equal("git_sha"))
+			Expect(response.Window).To(Equal(10))
+			Expect(response.FlakySpecs).To(HaveLen(1))
+			Expect(response.FlakySpecs[0].Flips).To(Equal(1))
+		})
+
+		It("rejects invalid parameters and unknown projects", func() {
+			Expect(get("/api/reports/flaky/project-uuid?scope=tag").Code).To(Equal(http.StatusBadRequest))
+			Expect(get("/api/reports/flaky/project-uuid?window=0").Code).To(Equal(http.StatusBadRequest))
+			Expect(get("/api/reports/flaky/unknown").Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
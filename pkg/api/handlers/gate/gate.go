@@ -0,0 +1,375 @@
+package gate
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/audit"
+	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CriticalTag marks specs whose failure fails the no_critical_failures rule.
+const CriticalTag = "priority:critical"
+
+const (
+	RuleMinPassRate         = "min_pass_rate"
+	RuleMaxNewFailures      = "max_new_failures"
+	RuleMaxDurationIncrease = "max_duration_increase"
+	RuleRequiredTags        = "required_tags"
+	RuleNoCriticalFailures  = "no_critical_failures"
+)
+
+type GateHandler struct {
+	db *gorm.DB
+}
+
+func NewGateHandler(db *gorm.DB) *GateHandler {
+	return &GateHandler{db: db}
+}
+
+// Evaluate checks a test run against the rules of a quality gate. Only the
+// rules the gate sets are evaluated. The test run must have its suite runs,
+// spec runs and their tags loaded. Rules that compare with a baseline pass
+// when no baseline run exists.
+func Evaluate(db *gorm.DB, gate *models.QualityGate, testRun *models.TestRun, options handlers.BaselineOptions) (models.GateEvaluation, error) {
+	evaluation := models.GateEvaluation{
+		TestRunID: testRun.ID,
+		Rules:     []models.GateRuleResult{},
+	}
+
+	if gate.MinPassRate != nil {
+		evaluation.Rules = append(evaluation.Rules, evaluateMinPassRate(*gate.MinPassRate, testRun))
+	}
+
+	if gate.MaxNewFailures != nil || gate.MaxDurationIncrease != nil {
+		baseline, _, err := handlers.FindBaselineTestRun(db, testRun, options)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return evaluation, err
+		}
+		if baseline != nil {
+			evaluation.Baseline = handlers.NewBaselineRun(baseline)
+		}
+		if gate.MaxNewFailures != nil {
+			evaluation.Rules = append(evaluation.Rules, evaluateMaxNewFailures(*gate.MaxNewFailures, baseline, testRun))
+		}
+		if gate.MaxDurationIncrease != nil {
+			evaluation.Rules = append(evaluation.Rules, evaluateMaxDurationIncrease(*gate.MaxDurationIncrease, baseline, testRun))
+		}
+	}
+
+	if len(gate.RequiredTags) > 0 {
+		evaluation.Rules = append(evaluation.Rules, evaluateRequiredTags(gate.RequiredTags, testRun))
+	}
+
+	if gate.NoCriticalFailures {
+		evaluation.Rules = append(evaluation.Rules, evaluateNoCriticalFailures(testRun))
+	}
+
+	evaluation.Passed = true
+	for _, rule := range evaluation.Rules {
+		evaluation.Passed = evaluation.Passed && rule.Passed
+	}
+	return evaluation, nil
+}
+
+func evaluateMinPassRate(minPassRate float64, testRun *models.TestRun) models.GateRuleResult {
+	var passed, failed int
+	for _, suite := range testRun.SuiteRuns {
+		for _, spec := range suite.SpecRuns {
+			switch {
+			case spec.Status == utils.StatusPassed:
+				passed++
+			case slices.Contains(utils.FailedStatuses, spec.Status):
+				failed++
+			}
+		}
+	}
+
+	if passed+failed == 0 {
+		return models.GateRuleResult{Rule: RuleMinPassRate, Reason: "no specs passed or failed"}
+	}
+	passRate := float64(passed) / float64(passed+failed)
+	return models.GateRuleResult{
+		Rule:   RuleMinPassRate,
+		Passed: passRate >= minPassRate,
+		Reason: fmt.Sprintf("pass rate %.4g (%d of %d specs), minimum %.4g", passRate, passed, passed+failed, minPassRate),
+	}
+}
+
+func evaluateMaxNewFailures(maxNewFailures int, baseline *models.TestRun, testRun *models.TestRun) models.GateRuleResult {
+	if baseline == nil {
+		return models.GateRuleResult{Rule: RuleMaxNewFailures, Passed: true, Reason: "no baseline run found"}
+	}
+
+	regressions := handlers.RegressedSpecs(baseline, testRun)
+	reason := fmt.Sprintf("%d new failures since test run %d, maximum %d", len(regressions), baseline.ID, maxNewFailures)
+	if len(regressions) > 0 {
+		names := make([]string, 0, len(regressions))
+		for _, spec := range regressions {
+			names = append(names, spec.SuiteName+" "+spec.SpecDescription)
+		}
+		reason += ": " + strings.Join(names, "; ")
+	}
+	return models.GateRuleResult{
+		Rule:   RuleMaxNewFailures,
+		Passed: len(regressions) <= maxNewFailures,
+		Reason: reason,
+	}
+}
+
+func evaluateMaxDurationIncrease(maxIncrease float64, baseline *models.TestRun, testRun *models.TestRun) models.GateRuleResult {
+	if baseline == nil {
+		return models.GateRuleResult{Rule: RuleMaxDurationIncrease, Passed: true, Reason: "no baseline run found"}
+	}
+
+	baseDuration := baseline.EndTime.Sub(baseline.StartTime)
+	duration := testRun.EndTime.Sub(testRun.StartTime)
+	if baseDuration <= 0 || duration <= 0 {
+		return models.GateRuleResult{Rule: RuleMaxDurationIncrease, Passed: true, Reason: "run durations are not known"}
+	}
+
+	increase := duration.Seconds()/baseDuration.Seconds() - 1
+	return models.GateRuleResult{
+		Rule:   RuleMaxDurationIncrease,
+		Passed: increase <= maxIncrease,
+		Reason: fmt.Sprintf("took %s against %s for test run %d, an increase of %.4g, maximum %.4g",
+			duration, baseDuration, baseline.ID, increase, maxIncrease),
+	}
+}
+
+func evaluateRequiredTags(requiredTags []string, testRun *models.TestRun) models.GateRuleResult {
+	present := map[string]bool{}
+	for _, suite := range testRun.SuiteRuns {
+		for _, tag := range suite.Tags {
+			present[tag.Name] = true
+		}
+		for _, spec := range suite.SpecRuns {
+			for _, tag := range spec.Tags {
+				present[tag.Name] = true
+			}
+		}
+	}
+
+	var missing []string
+	for _, tag := range requiredTags {
+		if !present[tag] {
+			missing = append(missing, tag)
+		}
+	}
+	if len(missing) > 0 {
+		return models.GateRuleResult{Rule: RuleRequiredTags, Reason: "missing tags: " + strings.Join(missing, ", ")}
+	}
+	return models.GateRuleResult{Rule: RuleRequiredTags, Passed: true, Reason: "all required tags are present"}
+}
+
+// evaluateNoCriticalFailures fails when a spec tagged with CriticalTag, either
+// directly or through its suite, failed.
+func evaluateNoCriticalFailures(testRun *models.TestRun) models.GateRuleResult {
+	hasCriticalTag := func(tags []models.Tag) bool {
+		return slices.ContainsFunc(tags, func(tag models.Tag) bool { return tag.Name == CriticalTag })
+	}
+
+	var failures []string
+	for _, suite := range testRun.SuiteRuns {
+		suiteCritical := hasCriticalTag(suite.Tags)
+		for _, spec := range suite.SpecRuns {
+			if slices.Contains(utils.FailedStatuses, spec.Status) && (suiteCritical || hasCriticalTag(spec.Tags)) {
+				failures = append(failures, suite.SuiteName+" "+spec.SpecDescription)
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return models.GateRuleResult{
+			Rule:   RuleNoCriticalFailures,
+			Reason: fmt.Sprintf("%d critical specs failed: %s", len(failures), strings.Join(failures, "; ")),
+		}
+	}
+	return models.GateRuleResult{Rule: RuleNoCriticalFailures, Passed: true, Reason: "no critical specs failed"}
+}
+
+func (h *GateHandler) findProject(c *gin.Context) (*models.ProjectDetails, bool) {
+	projectUUID := c.Param("projectUUID")
+	var project models.ProjectDetails
+	if err := auth.ScopeProjects(c, h.db.Where("uuid = ?", projectUUID)).First(&project).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Project ID %s not found", projectUUID)})
+			return nil, false
+		}
+		log.Printf("Failed to query project %s: %v", projectUUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query project"})
+		return nil, false
+	}
+	return &project, true
+}
+
+func (h *GateHandler) findGate(c *gin.Context, project *models.ProjectDetails) (*models.QualityGate, bool) {
+	var gate models.QualityGate
+	if err := h.db.Where("project_id = ?", project.ID).First(&gate).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("no quality gate defined for project %s", project.UUID)})
+			return nil, false
+		}
+		log.Printf("Failed to query quality gate of project %s: %v", project.UUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query quality gate"})
+		return nil, false
+	}
+	if gate.RequiredTags == nil {
+		gate.RequiredTags = []string{}
+	}
+	return &gate, true
+}
+
+// GetGate returns the quality gate of a project.
+func (h *GateHandler) GetGate(c *gin.Context) {
+	project, ok := h.findProject(c)
+	if !ok {
+		return
+	}
+	gate, ok := h.findGate(c, project)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, gate)
+}
+
+// SaveGate creates or replaces the quality gate of a project. Rules left out
+// of the request body are not evaluated.
+func (h *GateHandler) SaveGate(c *gin.Context) {
+	project, ok := h.findProject(c)
+	if !ok {
+		return
+	}
+
+	var gate models.QualityGate
+	if err := c.ShouldBindJSON(&gate); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if gate.MinPassRate != nil && (*gate.MinPassRate < 0 || *gate.MinPassRate > 1) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "min_pass_rate must be between 0 and 1"})
+		return
+	}
+	if gate.MaxNewFailures != nil && *gate.MaxNewFailures < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "max_new_failures must not be negative"})
+		return
+	}
+	if gate.MaxDurationIncrease != nil && *gate.MaxDurationIncrease < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "max_duration_increase must not be negative"})
+		return
+	}
+
+	requiredTags := []string{}
+	for _, tag := range gate.RequiredTags {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(requiredTags, tag) {
+			requiredTags = append(requiredTags, tag)
+		}
+	}
+	gate.RequiredTags = requiredTags
+	gate.ProjectID = project.ID
+
+	var existing models.QualityGate
+	if audit.Recording(c) && h.db.Where("project_id = ?", project.ID).First(&existing).Error == nil {
+		audit.Before(c, existing)
+	}
+
+	if err := h.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "project_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"min_pass_rate", "max_new_failures", "max_duration_increase",
+			"required_tags", "no_critical_failures", "updated_at",
+		}),
+	}).Create(&gate).Error; err != nil {
+		log.Printf("Failed to save quality gate of project %s: %v", project.UUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save quality gate"})
+		return
+	}
+	audit.Target(c, audit.TargetQualityGate, project.UUID, project.UUID)
+	audit.After(c, gate)
+	c.JSON(http.StatusOK, gate)
+}
+
+// DeleteGate removes the quality gate of a project.
+func (h *GateHandler) DeleteGate(c *gin.Context) {
+	project, ok := h.findProject(c)
+	if !ok {
+		return
+	}
+	var existing models.QualityGate
+	if audit.Recording(c) && h.db.Where("project_id = ?", project.ID).First(&existing).Error == nil {
+		audit.Target(c, audit.TargetQualityGate, project.UUID, project.UUID)
+		audit.Before(c, existing)
+	}
+	result := h.db.Where("project_id = ?", project.ID).Delete(&models.QualityGate{})
+	if result.Error != nil {
+		log.Printf("Failed to delete quality gate of project %s: %v", project.UUID, result.Error)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete quality gate"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("no quality gate defined for project %s", project.UUID)})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Quality gate deleted"})
+}
+
+// EvaluateGate checks a test run of the project against its quality gate. It
+// responds 200 when every rule passes and 422 when any rule fails, so CI
+// scripts can rely on the status code alone. Optional query parameters, used to
+// pick the baseline: merge_base and branch (defaults to the configured default
+// branch).
+func (h *GateHandler) EvaluateGate(c *gin.Context) {
+	testRunID, err := strconv.ParseUint(c.Param("testRunId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid test run id %q", c.Param("testRunId"))})
+		return
+	}
+
+	project, ok := h.findProject(c)
+	if !ok {
+		return
+	}
+	gate, ok := h.findGate(c, project)
+	if !ok {
+		return
+	}
+
+	var testRun models.TestRun
+	if err := h.db.Preload("SuiteRuns.Tags").Preload("SuiteRuns.SpecRuns.Tags").
+		Where("id = ? AND project_id = ?", testRunID, project.ID).First(&testRun).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("test run %d not found in project %s", testRunID, project.UUID)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading test run"})
+		return
+	}
+
+	evaluation, err := Evaluate(h.db, gate, &testRun, handlers.BaselineOptions{
+		MergeBaseSha: c.Query("merge_base"),
+		Branch:       c.DefaultQuery("branch", config.GetReports().DefaultBranch),
+	})
+	if err != nil {
+		log.Printf("error evaluating quality gate of project %s for test run %d: %v", project.UUID, testRunID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error evaluating quality gate"})
+		return
+	}
+	evaluation.ProjectID = project.UUID
+
+	status := http.StatusOK
+	if !evaluation.Passed {
+		status = http.StatusUnprocessableEntity
+	}
+	c.JSON(status, evaluation)
+}
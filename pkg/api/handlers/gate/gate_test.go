@@ -0,0 +1,228 @@
+package gate_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/api/handlers/gate"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Quality gates", func() {
+	var (
+		db          *gorm.DB
+		project     models.ProjectDetails
+		teamTag     models.Tag
+		criticalTag models.Tag
+		runStart    time.Time
+	)
+
+	// addRun stores a run of the Cart suite. Spec names map to their status;
+	// specs whose name starts with "critical" are tagged priority:critical.
+	addRun := func(branch string, duration time.Duration, specs map[string]string) models.TestRun {
+		runStart = runStart.Add(time.Hour)
+		suite := models.SuiteRun{SuiteName: "Cart", Tags: []models.Tag{teamTag}}
+		for description, status := range specs {
+			spec := models.SpecRun{SpecDescription: description, Status: status}
+			if len(description) >= 8 && description[:8] == "critical" {
+				spec.Tags = []models.Tag{criticalTag}
+			}
+			suite.SpecRuns = append(suite.SpecRuns, spec)
+		}
+		testRun := models.TestRun{
+			ProjectID: project.ID,
+			StartTime: runStart,
+			EndTime:   runStart.Add(duration),
+			Status:    "passed",
+			GitBranch: branch,
+			SuiteRuns: []models.SuiteRun{suite},
+		}
+		Expect(db.Create(&testRun).Error).NotTo(HaveOccurred())
+		Expect(db.Preload("SuiteRuns.Tags").Preload("SuiteRuns.SpecRuns.Tags").First(&testRun, testRun.ID).Error).NotTo(HaveOccurred())
+		return testRun
+	}
+
+	ruleResult := func(evaluation models.GateEvaluation, rule string) models.GateRuleResult {
+		for _, result := range evaluation.Rules {
+			if result.Rule == rule {
+				return result
+			}
+		}
+		Fail("rule " + rule + " was not evaluated")
+		return models.GateRuleResult{}
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&models.ProjectDetails{}, &models.TestRun{}, &models.SuiteRun{}, &models.SpecRun{},
+			&models.Tag{}, &models.QualityGate{})).To(Succeed())
+
+		project = models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "project-uuid", project.ID).Error).NotTo(HaveOccurred())
+		project.UUID = "project-uuid"
+
+		teamTag = models.Tag{Name: "team:cart", Category: "team", Value: "cart"}
+		criticalTag = models.Tag{Name: gate.CriticalTag, Category: "priority", Value: "critical"}
+		Expect(db.Create(&teamTag).Error).NotTo(HaveOccurred())
+		Expect(db.Create(&criticalTag).Error).NotTo(HaveOccurred())
+		runStart = time.Now().Add(-24 * time.Hour)
+	})
+
+	Describe("Evaluate", func() {
+		It("passes a run that meets every rule", func() {
+			minPassRate := 0.5
+			maxNewFailures := 0
+			maxIncrease := 0.2
+			addRun("main", 10*time.Minute, map[string]string{"adds items": "passed", "totals": "failed"})
+			head := addRun("feature", 11*time.Minute, map[string]string{"adds items": "passed", "totals": "failed", "critical checkout": "passed"})
+
+			evaluation, err := gate.Evaluate(db, &models.QualityGate{
+				MinPassRate:         &minPassRate,
+				MaxNewFailures:      &maxNewFailures,
+				MaxDurationIncrease: &maxIncrease,
+				RequiredTags:        []string{"team:cart"},
+				NoCriticalFailures:  true,
+			}, &head, handlers.BaselineOptions{Branch: "main"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(evaluation.Rules).To(HaveLen(5))
+			Expect(evaluation.Passed).To(BeTrue(), fmt.Sprint(evaluation.Rules))
+			Expect(evaluation.Baseline).NotTo(BeNil())
+		})
+
+		It("reports the reason of every failed rule", func() {
+			minPassRate := 0.9
+			maxNewFailures := 0
+			maxIncrease := 0.2
+			addRun("main", 10*time.Minute, map[string]string{"adds items": "passed", "critical checkout": "passed"})
+			head := addRun("feature", 15*time.Minute, map[string]string{"adds items": "failed", "critical checkout": "timedout"})
+
+			evaluation, err := gate.Evaluate(db, &models.QualityGate{
+				MinPassRate:         &minPassRate,
+				MaxNewFailures:      &maxNewFailures,
+				MaxDurationIncrease: &maxIncrease,
+				RequiredTags:        []string{"team:cart", "suite:smoke"},
+				NoCriticalFailures:  true,
+			}, &head, handlers.BaselineOptions{Branch: "main"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(evaluation.Passed).To(BeFalse())
+
+			Expect(ruleResult(evaluation, gate.RuleMinPassRate).Passed).To(BeFalse())
+			Expect(ruleResult(evaluation, gate.RuleMaxNewFailures).Reason).To(HavePrefix("2 new failures"))
+			Expect(ruleResult(evaluation, gate.RuleMaxDurationIncrease).Passed).To(BeFalse())
+			Expect(ruleResult(evaluation, gate.RuleRequiredTags).Reason).To(Equal("missing tags: suite:smoke"))
+			Expect(ruleResult(evaluation, gate.RuleNoCriticalFailures).Reason).To(ContainSubstring("Cart critical checkout"))
+		})
+
+		It("passes baseline rules when there is no baseline", func() {
+			maxNewFailures := 0
+			head := addRun("feature", time.Minute, map[string]string{"adds items": "failed"})
+
+			evaluation, err := gate.Evaluate(db, &models.QualityGate{MaxNewFailures: &maxNewFailures}, &head, handlers.BaselineOptions{Branch: "main"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(evaluation.Passed).To(BeTrue())
+			Expect(evaluation.Baseline).To(BeNil())
+			Expect(ruleResult(evaluation, gate.RuleMaxNewFailures).Reason).To(Equal("no baseline run found"))
+		})
+	})
+
+	Describe("REST endpoints", func() {
+		var router *gin.Engine
+
+		request := func(method string, path string, body string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		BeforeEach(func() {
+			_, err := config.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+
+			gin.SetMode(gin.TestMode)
+			router = gin.New()
+			handler := gate.NewGateHandler(db)
+			router.GET("/api/gates/:projectUUID", handler.GetGate)
+			router.PUT("/api/gates/:projectUUID", handler.SaveGate)
+			router.DELETE("/api/gates/:projectUUID", handler.DeleteGate)
+			router.POST("/api/gates/:projectUUID/evaluate/:testRunId", handler.EvaluateGate)
+		})
+
+		It("saves, replaces and deletes the gate of a project", func() {
+			w := request("PUT", "/api/gates/project-uuid", `{"min_pass_rate": 0.95, "required_tags": [" team:cart ", ""]}`)
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			w = request("GET", "/api/gates/project-uuid", "")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var saved models.QualityGate
+			Expect(json.Unmarshal(w.Body.Bytes(), &saved)).To(Succeed())
+			Expect(*saved.MinPassRate).To(Equal(0.95))
+			Expect(saved.RequiredTags).To(Equal([]string{"team:cart"}))
+
+			Expect(request("PUT", "/api/gates/project-uuid", `{"no_critical_failures": true}`).Code).To(Equal(http.StatusOK))
+			w = request("GET", "/api/gates/project-uuid", "")
+			Expect(json.Unmarshal(w.Body.Bytes(), &saved)).To(Succeed())
+			Expect(saved.MinPassRate).To(BeNil())
+			Expect(saved.NoCriticalFailures).To(BeTrue())
+
+			var count int64
+			Expect(db.Model(&models.QualityGate{}).Count(&count).Error).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+
+			Expect(request("DELETE", "/api/gates/project-uuid", "").Code).To(Equal(http.StatusOK))
+			Expect(request("GET", "/api/gates/project-uuid", "").Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("rejects invalid rules and unknown projects", func() {
+			Expect(request("PUT", "/api/gates/project-uuid", `{"min_pass_rate": 1.5}`).Code).To(Equal(http.StatusBadRequest))
+			Expect(request("PUT", "/api/gates/project-uuid", `{"max_new_failures": -1}`).Code).To(Equal(http.StatusBadRequest))
+			Expect(request("PUT", "/api/gates/unknown", `{}`).Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("answers 200 when the gate passes and 422 when it fails", func() {
+			Expect(request("PUT", "/api/gates/project-uuid", `{"no_critical_failures": true}`).Code).To(Equal(http.StatusOK))
+			green := addRun("main", time.Minute, map[string]string{"critical checkout": "passed"})
+			red := addRun("main", time.Minute, map[string]string{"critical checkout": "failed"})
+
+			w := request("POST", fmt.Sprintf("/api/gates/project-uuid/evaluate/%d", green.ID), "")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var evaluation models.GateEvaluation
+			Expect(json.Unmarshal(w.Body.Bytes(), &evaluation)).To(Succeed())
+			Expect(evaluation.Passed).To(BeTrue())
+			Expect(evaluation.ProjectID).To(Equal("project-uuid"))
+
+			w = request("POST", fmt.Sprintf("/api/gates/project-uuid/evaluate/%d", red.ID), "")
+			Expect(w.Code).To(Equal(http.StatusUnprocessableEntity))
+			Expect(json.Unmarshal(w.Body.Bytes(), &evaluation)).To(Succeed())
+			Expect(evaluation.Passed).To(BeFalse())
+			Expect(evaluation.Rules[0].Rule).To(Equal(gate.RuleNoCriticalFailures))
+		})
+
+		It("rejects runs of other projects and projects without a gate", func() {
+			testRun := addRun("main", time.Minute, map[string]string{"adds items": "passed"})
+			path := fmt.Sprintf("/api/gates/project-uuid/evaluate/%d", testRun.ID)
+			Expect(request("POST", path, "").Code).To(Equal(http.StatusNotFound))
+
+			Expect(request("PUT", "/api/gates/project-uuid", `{"no_critical_failures": true}`).Code).To(Equal(http.StatusOK))
+			Expect(request("POST", "/api/gates/project-uuid/evaluate/999", "").Code).To(Equal(http.StatusNotFound))
+			Expect(request("POST", "/api/gates/project-uuid/evaluate/abc", "").Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// ParseGinkgoReport converts the output of `ginkgo --json-report` into a
+// TestRun. Each suite report becomes a suite run; It nodes become spec runs,
+// as do failed suite level nodes such as BeforeSuite so that their failures
+// are not lost.
+func ParseGinkgoReport(r io.Reader) (*models.TestRun, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []types.Report
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var report types.Report
+		if err := json.Unmarshal(trimmed, &report); err != nil {
+			return nil, fmt.Errorf("invalid ginkgo report: %v", err)
+		}
+		reports = append(reports, report)
+	} else if err := json.Unmarshal(trimmed, &reports); err != nil {
+		return nil, fmt.Errorf("invalid ginkgo report: %v", err)
+	}
+
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("invalid ginkgo report: no suites found")
+	}
+
+	testRun := &models.TestRun{TestSeed: uint64(reports[0].SuiteConfig.RandomSeed)}
+	for _, report := range reports {
+		suiteRun := models.SuiteRun{
+			SuiteName: report.SuiteDescription,
+			StartTime: report.StartTime,
+			EndTime:   report.EndTime,
+			Tags:      labelsToTags(report.SuiteLabels),
+		}
+
+		for _, spec := range report.SpecReports {
+			if !spec.LeafNodeType.Is(types.NodeTypeIt) && !spec.Failed() {
+				continue
+			}
+			suiteRun.SpecRuns = append(suiteRun.SpecRuns, models.SpecRun{
+				SpecDescription: ginkgoSpecDescription(spec),
+				Status:          spec.State.String(),
+				Message:         ginkgoSpecMessage(spec),
+				Tags:            labelsToTags(spec.LeafNodeLabels),
+				StartTime:       spec.StartTime,
+				EndTime:         spec.EndTime,
+			})
+		}
+
+		if testRun.StartTime.IsZero() || suiteRun.StartTime.Before(testRun.StartTime) {
+			testRun.StartTime = suiteRun.StartTime
+		}
+		if suiteRun.EndTime.After(testRun.EndTime) {
+			testRun.EndTime = suiteRun.EndTime
+		}
+		testRun.SuiteRuns = append(testRun.SuiteRuns, suiteRun)
+	}
+	return testRun, nil
+}
+
+func ginkgoSpecDescription(spec types.SpecReport) string {
+	if spec.LeafNodeType.Is(types.NodeTypeIt) {
+		return spec.FullText()
+	}
+	// Suite level nodes have no text of their own.
+	return strings.TrimSpace(spec.LeafNodeType.String() + " " + spec.FullText())
+}
+
+func ginkgoSpecMessage(spec types.SpecReport) string {
+	if spec.State.Is(types.SpecStatePassed) || spec.Failure.Message == "" {
+		return ""
+	}
+	if spec.Failure.Location.FileName == "" {
+		return spec.Failure.Message
+	}
+	return fmt.Sprintf("%s\n%s", spec.Failure.Message, spec.Failure.Location.String())
+}
+
+func labelsToTags(labels []string) []models.Tag {
+	tags := make([]models.Tag, 0, len(labels))
+	for _, label := range labels {
+		tags = append(tags, ParseTagName(label))
+	}
+	return tags
+}
+
+// CreateTestRunFromGinkgo ingests a Ginkgo JSON report for the project given by
+// the project_id query parameter. It accepts the same optional CI metadata query
+// parameters as CreateTestRunFromJUnit.
+func (h *Handler) CreateTestRunFromGinkgo(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	testRun, err := ParseGinkgoReport(bytes.NewReader(body))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	applyRunMetadataFromQuery(c, testRun)
+	h.saveTestRun(c, testRun, fingerprintReport(c, body))
+}
@@ -0,0 +1,159 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+func ginkgoReportJSON() []byte {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	reports := []types.Report{
+		{
+			SuiteDescription: "Cart Suite",
+			SuiteLabels:      []string{"team:payments"},
+			SuiteConfig:      types.SuiteConfig{RandomSeed: 1717243200},
+			StartTime:        start,
+			EndTime:          start.Add(10 * time.Second),
+			SpecReports: types.SpecReports{
+				{
+					LeafNodeType: types.NodeTypeBeforeSuite,
+					State:        types.SpecStatePassed,
+					StartTime:    start,
+					EndTime:      start.Add(time.Second),
+				},
+				{
+					LeafNodeType:            types.NodeTypeIt,
+					ContainerHierarchyTexts: []string{"Cart", "when empty"},
+					LeafNodeText:            "has no items",
+					LeafNodeLabels:          []string{"priority:high", "smoke"},
+					State:                   types.SpecStatePassed,
+					StartTime:               start.Add(time.Second),
+					EndTime:                 start.Add(2 * time.Second),
+				},
+				{
+					LeafNodeType:            types.NodeTypeIt,
+					ContainerHierarchyTexts: []string{"Cart"},
+					LeafNodeText:            "checks out",
+					State:                   types.SpecStateFailed,
+					StartTime:               start.Add(2 * time.Second),
+					EndTime:                 start.Add(5 * time.Second),
+					Failure: types.Failure{
+						Message:  "Expected <int>: 1 to equal <int>: 2",
+						Location: types.CodeLocation{FileName: "/src/cart_test.go", LineNumber: 42},
+					},
+				},
+				{
+					LeafNodeType:            types.NodeTypeIt,
+					ContainerHierarchyTexts: []string{"Cart"},
+					LeafNodeText:            "applies coupons",
+					State:                   types.SpecStatePending,
+				},
+			},
+		},
+		{
+			SuiteDescription: "Checkout Suite",
+			SuiteConfig:      types.SuiteConfig{RandomSeed: 99},
+			StartTime:        start.Add(time.Second),
+			EndTime:          start.Add(20 * time.Second),
+			SpecReports: types.SpecReports{
+				{
+					LeafNodeType: types.NodeTypeBeforeSuite,
+					State:        types.SpecStatePanicked,
+					Failure:      types.Failure{Message: "database unavailable"},
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(reports)
+	Expect(err).NotTo(HaveOccurred())
+	return data
+}
+
+var _ = Describe("ParseGinkgoReport", func() {
+	It("maps suite and spec reports", func() {
+		testRun, err := handlers.ParseGinkgoReport(bytes.NewReader(ginkgoReportJSON()))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(testRun.TestSeed).To(Equal(uint64(1717243200)))
+		Expect(testRun.StartTime).To(Equal(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)))
+		Expect(testRun.EndTime).To(Equal(time.Date(2025, 6, 1, 12, 0, 20, 0, time.UTC)))
+		Expect(testRun.SuiteRuns).To(HaveLen(2))
+
+		cart := testRun.SuiteRuns[0]
+		Expect(cart.SuiteName).To(Equal("Cart Suite"))
+		Expect(cart.Tags).To(ConsistOf(models.Tag{Name: "team:payments", Category: "team", Value: "payments"}))
+		Expect(cart.SpecRuns).To(HaveLen(3))
+
+		Expect(cart.SpecRuns[0].SpecDescription).To(Equal("Cart when empty has no items"))
+		Expect(cart.SpecRuns[0].Status).To(Equal("passed"))
+		Expect(cart.SpecRuns[0].Message).To(BeEmpty())
+		Expect(cart.SpecRuns[0].Tags).To(HaveLen(2))
+		Expect(cart.SpecRuns[0].Tags[0].Category).To(Equal("priority"))
+
+		Expect(cart.SpecRuns[1].Status).To(Equal("failed"))
+		Expect(cart.SpecRuns[1].Message).To(Equal("Expected <int>: 1 to equal <int>: 2\n/src/cart_test.go:42"))
+
+		Expect(cart.SpecRuns[2].Status).To(Equal("pending"))
+
+		checkout := testRun.SuiteRuns[1]
+		Expect(checkout.SpecRuns).To(HaveLen(1))
+		Expect(checkout.SpecRuns[0].SpecDescription).To(Equal("BeforeSuite"))
+		Expect(checkout.SpecRuns[0].Status).To(Equal("panicked"))
+		Expect(checkout.SpecRuns[0].Message).To(Equal("database unavailable"))
+	})
+
+	It("accepts a single suite report object", func() {
+		testRun, err := handlers.ParseGinkgoReport(strings.NewReader(`{"SuiteDescription":"Solo","SuiteConfig":{"RandomSeed":7},"SpecReports":[{"LeafNodeType":"It","LeafNodeText":"works","State":"passed"}]}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testRun.TestSeed).To(Equal(uint64(7)))
+		Expect(testRun.SuiteRuns[0].SpecRuns[0].SpecDescription).To(Equal("works"))
+	})
+
+	It("returns an error for an empty report list", func() {
+		_, err := handlers.ParseGinkgoReport(strings.NewReader(`[]`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("returns an error for invalid JSON", func() {
+		_, err := handlers.ParseGinkgoReport(strings.NewReader(`[{"SpecReports": 1}]`))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("CreateTestRunFromGinkgo", func() {
+	It("stores the converted test run", func() {
+		gin.SetMode(gin.TestMode)
+		db := setupTestDB()
+
+		project := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "project-uuid", project.ID).Error).NotTo(HaveOccurred())
+
+		router := gin.New()
+		router.POST("/api/testrun/ginkgo", handlers.NewHandler(db).CreateTestRunFromGinkgo)
+
+		req, _ := http.NewRequest("POST", "/api/testrun/ginkgo?project_id=project-uuid&git_sha=abc", bytes.NewReader(ginkgoReportJSON()))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		Expect(w.Code).To(Equal(http.StatusCreated))
+
+		var stored models.TestRun
+		Expect(db.Preload("SuiteRuns.SpecRuns").First(&stored).Error).NotTo(HaveOccurred())
+		Expect(stored.TestSeed).To(Equal(uint64(1717243200)))
+		Expect(stored.GitSha).To(Equal("abc"))
+		Expect(stored.SuiteRuns).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+// goTestEvent is a single line of the `go test -json` (test2json) stream.
+type goTestEvent struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package"`
+	Test    string    `json:"Test"`
+	Elapsed float64   `json:"Elapsed"`
+	Output  string    `json:"Output"`
+}
+
+type goTestPackage struct {
+	suiteRun models.SuiteRun
+	specs    map[string]int // test name -> index into suiteRun.SpecRuns
+	output   map[string]*strings.Builder
+}
+
+// ParseGoTestReport reconstructs a TestRun from the NDJSON event stream written
+// by `go test -json`. Each package becomes a suite run and each test or subtest
+// becomes a spec run whose message is the output it printed.
+func ParseGoTestReport(r io.Reader) (*models.TestRun, error) {
+	packages := map[string]*goTestPackage{}
+	var order []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var event goTestEvent
+		if err := json.Unmarshal([]byte(text), &event); err != nil {
+			return nil, fmt.Errorf("invalid go test event on line %d: %v", line, err)
+		}
+		if event.Package == "" {
+			continue
+		}
+
+		pkg, ok := packages[event.Package]
+		if !ok {
+			pkg = &goTestPackage{
+				suiteRun: models.SuiteRun{SuiteName: event.Package, StartTime: event.Time},
+				specs:    map[string]int{},
+				output:   map[string]*strings.Builder{},
+			}
+			packages[event.Package] = pkg
+			order = append(order, event.Package)
+		}
+		pkg.apply(event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	testRun := &models.TestRun{}
+	for _, name := range order {
+		suiteRun := packages[name].finish()
+		if len(suiteRun.SpecRuns) == 0 {
+			continue
+		}
+		if testRun.StartTime.IsZero() || suiteRun.StartTime.Before(testRun.StartTime) {
+			testRun.StartTime = suiteRun.StartTime
+		}
+		if suiteRun.EndTime.After(testRun.EndTime) {
+			testRun.EndTime = suiteRun.EndTime
+		}
+		testRun.SuiteRuns = append(testRun.SuiteRuns, suiteRun)
+	}
+
+	if len(testRun.SuiteRuns) == 0 {
+		return nil, fmt.Errorf("invalid go test report: no tests found")
+	}
+	return testRun, nil
+}
+
+func (p *goTestPackage) apply(event goTestEvent) {
+	if event.Test == "" {
+		switch event.Action {
+		case "pass", "fail", "skip":
+			p.suiteRun.EndTime = event.Time
+		}
+		return
+	}
+
+	spec := p.spec(event)
+	switch event.Action {
+	case "run":
+		spec.StartTime = event.Time
+	case "output":
+		if !isGoTestFraming(event.Output) {
+			p.output[event.Test].WriteString(event.Output)
+		}
+	case "pass", "fail", "skip":
+		spec.Status = goTestStatus(event.Action)
+		spec.EndTime = event.Time
+		if elapsed := time.Duration(event.Elapsed * float64(time.Second)); elapsed > 0 {
+			spec.StartTime = event.Time.Add(-elapsed)
+		}
+	}
+}
+
+func (p *goTestPackage) spec(event goTestEvent) *models.SpecRun {
+	if idx, ok := p.specs[event.Test]; ok {
+		return &p.suiteRun.SpecRuns[idx]
+	}
+	p.specs[event.Test] = len(p.suiteRun.SpecRuns)
+	p.output[event.Test] = &strings.Builder{}
+	p.suiteRun.SpecRuns = append(p.suiteRun.SpecRuns, models.SpecRun{
+		SpecDescription: event.Test,
+		StartTime:       event.Time,
+		EndTime:         event.Time,
+	})
+	return &p.suiteRun.SpecRuns[len(p.suiteRun.SpecRuns)-1]
+}
+
+func (p *goTestPackage) finish() models.SuiteRun {
+	for i := range p.suiteRun.SpecRuns {
+		spec := &p.suiteRun.SpecRuns[i]
+		// A test without a terminal event was cut short by a panic or timeout
+		// of the package binary.
+		if spec.Status == "" {
+			spec.Status = utils.StatusFailed
+		}
+		spec.Message = strings.TrimRight(p.output[spec.SpecDescription].String(), "\n")
+		if spec.EndTime.After(p.suiteRun.EndTime) {
+			p.suiteRun.EndTime = spec.EndTime
+		}
+	}
+	return p.suiteRun
+}
+
+// isGoTestFraming reports whether an output line is one of the "=== RUN" or
+// "--- PASS" markers the test runner prints around each test.
+func isGoTestFraming(output string) bool {
+	trimmed := strings.TrimSpace(output)
+	for _, prefix := range []string{"=== ", "--- PASS:", "--- FAIL:", "--- SKIP:"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func goTestStatus(action string) string {
+	switch action {
+	case "pass":
+		return utils.StatusPassed
+	case "skip":
+		return utils.StatusSkipped
+	default:
+		return utils.StatusFailed
+	}
+}
+
+// CreateTestRunFromGoTest ingests the output of `go test -json` for the project
+// given by the project_id query parameter. It accepts the same optional CI
+// metadata query parameters as CreateTestRunFromJUnit.
+func (h *Handler) CreateTestRunFromGoTest(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	testRun, err := ParseGoTestReport(bytes.NewReader(body))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	applyRunMetadataFromQuery(c, testRun)
+	h.saveTestRun(c, testRun, fingerprintReport(c, body))
+}
@@ -0,0 +1,108 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+const goTestReport = `{"Time":"2025-06-01T12:00:00Z","Action":"start","Package":"example.com/cart"}
+{"Time":"2025-06-01T12:00:00.1Z","Action":"run","Package":"example.com/cart","Test":"TestAdd"}
+{"Time":"2025-06-01T12:00:00.1Z","Action":"output","Package":"example.com/cart","Test":"TestAdd","Output":"=== RUN   TestAdd\n"}
+{"Time":"2025-06-01T12:00:00.3Z","Action":"output","Package":"example.com/cart","Test":"TestAdd","Output":"--- PASS: TestAdd (0.20s)\n"}
+{"Time":"2025-06-01T12:00:00.3Z","Action":"pass","Package":"example.com/cart","Test":"TestAdd","Elapsed":0.2}
+{"Time":"2025-06-01T12:00:00.3Z","Action":"run","Package":"example.com/cart","Test":"TestRemove"}
+{"Time":"2025-06-01T12:00:00.3Z","Action":"run","Package":"example.com/cart","Test":"TestRemove/empty_cart"}
+{"Time":"2025-06-01T12:00:00.4Z","Action":"output","Package":"example.com/cart","Test":"TestRemove/empty_cart","Output":"    cart_test.go:42: expected error\n"}
+{"Time":"2025-06-01T12:00:00.4Z","Action":"output","Package":"example.com/cart","Test":"TestRemove/empty_cart","Output":"    cart_test.go:43: got nil\n"}
+{"Time":"2025-06-01T12:00:00.4Z","Action":"fail","Package":"example.com/cart","Test":"TestRemove/empty_cart","Elapsed":0.1}
+{"Time":"2025-06-01T12:00:00.4Z","Action":"fail","Package":"example.com/cart","Test":"TestRemove","Elapsed":0.1}
+{"Time":"2025-06-01T12:00:00.5Z","Action":"output","Package":"example.com/cart","Output":"FAIL\n"}
+{"Time":"2025-06-01T12:00:00.5Z","Action":"fail","Package":"example.com/cart","Elapsed":0.5}
+{"Time":"2025-06-01T12:00:01Z","Action":"start","Package":"example.com/checkout"}
+{"Time":"2025-06-01T12:00:01Z","Action":"run","Package":"example.com/checkout","Test":"TestPay"}
+{"Time":"2025-06-01T12:00:01Z","Action":"skip","Package":"example.com/checkout","Test":"TestPay","Elapsed":0}
+{"Time":"2025-06-01T12:00:01Z","Action":"run","Package":"example.com/checkout","Test":"TestRefund"}
+{"Time":"2025-06-01T12:00:02Z","Action":"output","Package":"example.com/checkout","Test":"TestRefund","Output":"panic: boom\n"}
+{"Time":"2025-06-01T12:00:02Z","Action":"fail","Package":"example.com/checkout","Elapsed":1}
+{"Time":"2025-06-01T12:00:02Z","Action":"start","Package":"example.com/notests"}
+{"Time":"2025-06-01T12:00:02Z","Action":"output","Package":"example.com/notests","Output":"?   \texample.com/notests\t[no test files]\n"}
+{"Time":"2025-06-01T12:00:02Z","Action":"skip","Package":"example.com/notests","Elapsed":0}
+`
+
+var _ = Describe("ParseGoTestReport", func() {
+	It("maps packages to suites and tests to specs", func() {
+		testRun, err := handlers.ParseGoTestReport(strings.NewReader(goTestReport))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testRun.SuiteRuns).To(HaveLen(2))
+		Expect(testRun.StartTime).To(Equal(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)))
+		Expect(testRun.EndTime).To(Equal(time.Date(2025, 6, 1, 12, 0, 2, 0, time.UTC)))
+
+		cart := testRun.SuiteRuns[0]
+		Expect(cart.SuiteName).To(Equal("example.com/cart"))
+		Expect(cart.EndTime).To(Equal(time.Date(2025, 6, 1, 12, 0, 0, 500000000, time.UTC)))
+		Expect(cart.SpecRuns).To(HaveLen(3))
+
+		Expect(cart.SpecRuns[0].SpecDescription).To(Equal("TestAdd"))
+		Expect(cart.SpecRuns[0].Status).To(Equal("passed"))
+		Expect(cart.SpecRuns[0].Message).To(BeEmpty())
+		Expect(cart.SpecRuns[0].EndTime.Sub(cart.SpecRuns[0].StartTime)).To(Equal(200 * time.Millisecond))
+
+		Expect(cart.SpecRuns[1].SpecDescription).To(Equal("TestRemove"))
+		Expect(cart.SpecRuns[1].Status).To(Equal("failed"))
+
+		Expect(cart.SpecRuns[2].SpecDescription).To(Equal("TestRemove/empty_cart"))
+		Expect(cart.SpecRuns[2].Status).To(Equal("failed"))
+		Expect(cart.SpecRuns[2].Message).To(Equal("    cart_test.go:42: expected error\n    cart_test.go:43: got nil"))
+
+		checkout := testRun.SuiteRuns[1]
+		Expect(checkout.SpecRuns[0].Status).To(Equal("skipped"))
+		Expect(checkout.SpecRuns[1].Status).To(Equal("failed"))
+		Expect(checkout.SpecRuns[1].Message).To(Equal("panic: boom"))
+	})
+
+	It("returns an error for a malformed event", func() {
+		_, err := handlers.ParseGoTestReport(strings.NewReader("{\"Action\":\"run\"}\nnot json\n"))
+		Expect(err).To(MatchError(ContainSubstring("line 2")))
+	})
+
+	It("returns an error when the stream has no tests", func() {
+		_, err := handlers.ParseGoTestReport(strings.NewReader(""))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("CreateTestRunFromGoTest", func() {
+	It("stores the converted test run", func() {
+		gin.SetMode(gin.TestMode)
+		db := setupTestDB()
+
+		project := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "project-uuid", project.ID).Error).NotTo(HaveOccurred())
+
+		router := gin.New()
+		router.POST("/api/testrun/gotest", handlers.NewHandler(db).CreateTestRunFromGoTest)
+
+		req, _ := http.NewRequest("POST", "/api/testrun/gotest?project_id=project-uuid", bytes.NewBufferString(goTestReport))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		Expect(w.Code).To(Equal(http.StatusCreated))
+
+		var suiteCount, specCount int64
+		db.Model(&models.SuiteRun{}).Count(&suiteCount)
+		db.Model(&models.SpecRun{}).Count(&specCount)
+		Expect(suiteCount).To(Equal(int64(2)))
+		Expect(specCount).To(Equal(int64(5)))
+	})
+})
@@ -1,16 +1,23 @@
 package handlers
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/auth"
 	"github.com/guidewire/fern-reporter/pkg/models"
+	"gorm.io/gorm"
 	"time"
 )
 
 const timeQueryLayout = "2006-01-02T15:04:05"
 
-func GetLongestTestRuns(h *Handler, projectName string, startTimeRange time.Time, endTimeRange time.Time) []models.TestRunInsight {
+func GetLongestTestRuns(ctx context.Context, h *Handler, projectName string, startTimeRange time.Time, endTimeRange time.Time) []models.TestRunInsight {
 	var testRuns []models.TestRunInsight
 
-	h.db.Table("test_runs").
+	scopeTestRuns(ctx, h.db.Table("test_runs")).
 		Joins("INNER JOIN suite_runs ON test_runs.id = suite_runs.test_run_id").
 		Joins("INNER JOIN spec_runs ON suite_runs.id = spec_runs.suite_id").
 		Select("suite_runs.id, test_runs.test_project_name, test_runs.start_time, test_runs.end_time,"+
@@ -26,9 +33,9 @@ func GetLongestTestRuns(h *Handler, projectName string, startTimeRange time.Time
 	return testRuns
 }
 
-func GetAverageDuration(h *Handler, projectName string, startTimeRange time.Time, endTimeRange time.Time) float64 {
+func GetAverageDuration(ctx context.Context, h *Handler, projectName string, startTimeRange time.Time, endTimeRange time.Time) float64 {
 	var averageDuration float64
-	h.db.Table("test_runs").
+	scopeTestRuns(ctx, h.db.Table("test_runs")).
 		Select("AVG(EXTRACT(EPOCH FROM (end_time - start_time)))").
 		Where("test_project_name = ?", projectName).
 		Where("start_time >= ?", startTimeRange).
@@ -50,9 +57,9 @@ func ParseTimeFromStringWithDefault(timeString string, defaultTime time.Time) (t
 	return parsedTime, nil
 }
 
-func GetProjectSpecStatistics(h *Handler, projectId string) []models.TestSummary {
+func GetProjectSpecStatistics(ctx context.Context, h *Handler, projectId string) []models.TestSummary {
 	var testSummaries []models.TestSummary
-	h.db.Table("test_runs").
+	scopeTestRuns(ctx, h.db.Table("test_runs")).
 		Joins("INNER JOIN suite_runs ON test_runs.id = suite_runs.test_run_id").
 		Joins("INNER JOIN spec_runs ON suite_runs.id = spec_runs.suite_id").
 		Select(`suite_runs.id AS suite_run_id, 
@@ -67,3 +74,39 @@ func GetProjectSpecStatistics(h *Handler, projectId string) []models.TestSummary
 		Scan(&testSummaries)
 	return testSummaries
 }
+
+// QueryPositiveInt reads an optional positive integer query parameter,
+// returning defaultValue when it is absent.
+func QueryPositiveInt(c *gin.Context, name string, defaultValue int) (int, error) {
+	value := c.Query(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		return 0, fmt.Errorf("invalid %s parameter: %q", name, value)
+	}
+	return parsed, nil
+}
+
+// scopeTestRuns limits a query on test_runs to the runs of the projects of the
+// caller's organization.
+func scopeTestRuns(ctx context.Context, query *gorm.DB) *gorm.DB {
+	return auth.ScopeProjectRows(ctx, query, "test_runs.project_id")
+}
+
+// filterAllowedTestRuns limits a query on test_runs to the runs of the projects
+// of the caller's organization that the caller's scopes allow.
+func (h *Handler) filterAllowedTestRuns(c *gin.Context, query *gorm.DB) *gorm.DB {
+	return auth.FilterAllowedProjectRows(c, scopeTestRuns(c, query), "test_runs.project_id")
+}
+
+// projectUUID returns the UUID of the project with the given ID, or an empty
+// string when it cannot be found. It attributes audit events to projects.
+func projectUUID(db *gorm.DB, projectID uint64) string {
+	var project models.ProjectDetails
+	if err := db.Select("uuid").Where("id = ?", projectID).First(&project).Error; err != nil {
+		return ""
+	}
+	return project.UUID
+}
@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/audit"
+	"github.com/guidewire/fern-reporter/pkg/auth"
 	"github.com/guidewire/fern-reporter/pkg/models"
 	"github.com/guidewire/fern-reporter/pkg/utils"
 	"strings"
@@ -15,6 +18,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Handler struct {
@@ -34,6 +38,14 @@ func (h *Handler) CreateTestRun(c *gin.Context) {
 		return // Stop further processing if there is a binding error
 	}
 
+	h.saveTestRun(c, &testRun, fingerprintTestRun(&testRun))
+}
+
+// saveTestRun validates the project UUID of a decoded test run, resolves its
+// tags and persists it, writing the response to the context. It is shared by
+// every ingestion format so that they all go through the same pipeline.
+// payloadHash identifies the request payload for idempotent retries.
+func (h *Handler) saveTestRun(c *gin.Context, testRun *models.TestRun, payloadHash string) {
 	// Validate that UUID is provided
 	if testRun.TestProjectID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Project UUID is required"})
@@ -44,7 +56,7 @@ func (h *Handler) CreateTestRun(c *gin.Context) {
 	isNewRecord := testRun.ID == 0
 
 	//Check if UUID is already exists and get the Project Name
-	projectID, err := getProjectIDByUUID(h.db, testRun.TestProjectID)
+	projectID, err := getProjectIDByUUID(c, h.db, testRun.TestProjectID)
 
 	if err != nil || projectID == 0 {
 		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Project ID %s not found", testRun.TestProjectID)})
@@ -54,30 +66,84 @@ func (h *Handler) CreateTestRun(c *gin.Context) {
 
 	// If it's not a new record, try to find it first
 	if !isNewRecord {
-		if err := gdb.Where("id = ?", testRun.ID).First(&models.TestRun{}).Error; err != nil {
+		var existing models.TestRun
+		err := scopeTestRuns(c, gdb.Where("id = ?", testRun.ID)).First(&existing).Error
+		// The caller's scopes were checked against the project of the body, so
+		// the run must belong to it
+		if err != nil || existing.ProjectID != projectID {
 			c.JSON(http.StatusNotFound, gin.H{"error": "record not found"})
 			return // Stop further processing if record not found
 		}
+		audit.Before(c, &existing)
+	}
+
+	// Answer retries of an upload with the run that was originally created
+	var key string
+	if isNewRecord {
+		key = idempotencyKey(c)
+		if key != "" && h.replayIdempotentTestRun(c, projectID, key, payloadHash) {
+			audit.Skip(c)
+			return
+		}
+	}
+
+	// Large uploads can be handed to the ingest workers instead
+	if isNewRecord && isAsyncIngest(c) {
+		h.enqueueIngestJob(c, testRun, key, payloadHash)
+		return
 	}
 
 	// Process tags
-	err = ProcessTags(gdb, &testRun)
+	err = ProcessTags(gdb, testRun)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "error processing tags"})
 		return // Stop further processing if tag processing fails
 	}
 
+	SignFailures(testRun)
+
 	// Save or update the testRun record in the database
-	if err := gdb.Save(&testRun).Error; err != nil {
+	err = saveTestRunRecord(gdb, testRun, key, payloadHash)
+	if errors.Is(err, errIdempotencyKeyTaken) && h.replayIdempotentTestRun(c, projectID, key, payloadHash) {
+		audit.Skip(c)
+		return // A concurrent retry created the run first
+	}
+	if err != nil {
+		log.Printf("error saving test run: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "error saving record"})
 		return // Stop further processing if save fails
 	}
+	recordDurationRegressions(gdb, testRun)
+
+	audit.Target(c, audit.TargetTestRun, testRun.ID, testRun.TestProjectID)
+	audit.After(c, testRun)
+	c.JSON(http.StatusCreated, testRun)
+}
 
-	c.JSON(http.StatusCreated, &testRun)
+// saveTestRunRecord links the specs of a test run whose tags are already
+// resolved to the test case catalog and saves it. When key is set it claims the
+// idempotency key in the same transaction, so that a failed save or a claimed
+// key leaves no catalog entries behind.
+func saveTestRunRecord(db *gorm.DB, testRun *models.TestRun, key string, payloadHash string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := LinkTestCases(tx, testRun); err != nil {
+			return fmt.Errorf("error linking test cases: %w", err)
+		}
+		if err := tx.Save(testRun).Error; err != nil {
+			return err
+		}
+		if key == "" {
+			return nil
+		}
+		return claimIdempotencyKey(tx, testRun, key, payloadHash)
+	})
 }
-func getProjectIDByUUID(db *gorm.DB, uuid string) (uint64, error) {
+
+// getProjectIDByUUID returns the ID of the project with the given UUID in the
+// caller's organization.
+func getProjectIDByUUID(ctx context.Context, db *gorm.DB, uuid string) (uint64, error) {
 	var project models.ProjectDetails
-	if err := db.Where("uuid = ?", uuid).First(&project).Error; err != nil {
+	if err := auth.ScopeProjects(ctx, db.Where("uuid = ?", uuid)).First(&project).Error; err != nil {
 		return 0, err
 	}
 	return project.ID, nil
@@ -85,20 +151,81 @@ func getProjectIDByUUID(db *gorm.DB, uuid string) (uint64, error) {
 
 // GetOrCreateTag checks if a tag exists by name, creates it if not, and returns the tag.
 func GetOrCreateTag(db *gorm.DB, tagName string) (models.Tag, error) {
-	var existingTag models.Tag
-
-	result := db.Where("name = ?", tagName).First(&existingTag)
-	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-		newTag := ParseTagName(tagName)
-		if err := db.Create(&newTag).Error; err != nil {
-			log.Printf("failed to create tag %s: %v", tagName, err)
-			return models.Tag{}, err
+	tags, err := ResolveTags(db, []string{tagName})
+	if err != nil {
+		log.Printf("failed to resolve tag %s: %v", tagName, err)
+		return models.Tag{}, err
+	}
+	return tags[tagName], nil
+}
+
+// lookupBatchSize bounds the number of values per IN list or bulk insert so
+// that large uploads stay well below the Postgres bind parameter limit.
+const lookupBatchSize = 1000
+
+// ResolveTags returns the tags for the given names keyed by name, creating the
+// ones that do not exist yet. Existing tags are read with one query per batch
+// and missing ones are inserted in bulk; the unique index on tags.name lets
+// concurrent uploads insert the same name without creating duplicates.
+func ResolveTags(db *gorm.DB, names []string) (map[string]models.Tag, error) {
+	tags := make(map[string]models.Tag, len(names))
+	distinct := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			distinct = append(distinct, name)
 		}
-		return newTag, nil
-	} else if result.Error != nil {
-		return models.Tag{}, result.Error
 	}
-	return existingTag, nil
+
+	for start := 0; start < len(distinct); start += lookupBatchSize {
+		batch := distinct[start:min(start+lookupBatchSize, len(distinct))]
+		if err := findTags(db, batch, tags); err != nil {
+			return nil, err
+		}
+
+		missing := make([]models.Tag, 0, len(batch))
+		for _, name := range batch {
+			if _, ok := tags[name]; !ok {
+				missing = append(missing, ParseTagName(name))
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		if err := db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+			Create(&missing).Error; err != nil {
+			return nil, err
+		}
+
+		// Read back rather than trust the insert, which skips names that a
+		// concurrent upload created first.
+		names := make([]string, 0, len(missing))
+		for _, tag := range missing {
+			names = append(names, tag.Name)
+		}
+		if err := findTags(db, names, tags); err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if _, ok := tags[name]; !ok {
+				return nil, fmt.Errorf("tag %q could not be created", name)
+			}
+		}
+	}
+	return tags, nil
+}
+
+func findTags(db *gorm.DB, names []string, tags map[string]models.Tag) error {
+	var found []models.Tag
+	if err := db.Where("name IN ?", names).Find(&found).Error; err != nil {
+		return err
+	}
+	for _, tag := range found {
+		tags[tag.Name] = tag
+	}
+	return nil
 }
 
 // ParseTagName splits a tag string into Category, Value, and Name.
@@ -116,34 +243,42 @@ func ParseTagName(tagName string) models.Tag {
 	return tag
 }
 
+// ProcessTags replaces the tags of every suite and spec in the run with stored
+// tags of the same name. All names in the run are resolved together so that
+// large uploads need a handful of queries rather than one per tag.
 func ProcessTags(db *gorm.DB, testRun *models.TestRun) error {
-	processTagList := func(tags []models.Tag) ([]models.Tag, error) {
-		result := make([]models.Tag, 0, len(tags))
-		for _, t := range tags {
-			tag, err := GetOrCreateTag(db, t.Name)
-			if err != nil {
-				return nil, err
+	var names []string
+	for _, suite := range testRun.SuiteRuns {
+		for _, tag := range suite.Tags {
+			names = append(names, tag.Name)
+		}
+		for _, spec := range suite.SpecRuns {
+			for _, tag := range spec.Tags {
+				names = append(names, tag.Name)
 			}
-			result = append(result, tag)
 		}
-		return result, nil
+	}
+	if len(names) == 0 {
+		return nil
 	}
 
-	for i, suite := range testRun.SuiteRuns {
-		// Process suite-level tags
-		suiteTags, err := processTagList(suite.Tags)
-		if err != nil {
-			return err
+	resolved, err := ResolveTags(db, names)
+	if err != nil {
+		return err
+	}
+
+	resolveList := func(tags []models.Tag) []models.Tag {
+		result := make([]models.Tag, 0, len(tags))
+		for _, t := range tags {
+			result = append(result, resolved[t.Name])
 		}
-		testRun.SuiteRuns[i].Tags = suiteTags
+		return result
+	}
 
-		// Process spec-level tags
+	for i, suite := range testRun.SuiteRuns {
+		testRun.SuiteRuns[i].Tags = resolveList(suite.Tags)
 		for j, spec := range suite.SpecRuns {
-			specTags, err := processTagList(spec.Tags)
-			if err != nil {
-				return err
-			}
-			testRun.SuiteRuns[i].SpecRuns[j].Tags = specTags
+			testRun.SuiteRuns[i].SpecRuns[j].Tags = resolveList(spec.Tags)
 		}
 	}
 	return nil
@@ -151,14 +286,14 @@ func ProcessTags(db *gorm.DB, testRun *models.TestRun) error {
 
 func (h *Handler) GetTestRunAll(c *gin.Context) {
 	var testRuns []models.TestRun
-	h.db.Preload("Project").Find(&testRuns)
+	h.filterAllowedTestRuns(c, h.db.Preload("Project")).Find(&testRuns)
 	c.JSON(http.StatusOK, testRuns)
 }
 
 func (h *Handler) GetTestRunByID(c *gin.Context) {
 	var testRun models.TestRun
 	id := c.Param("id")
-	h.db.Preload("Project").Where("id = ?", id).First(&testRun)
+	scopeTestRuns(c, h.db.Preload("Project").Where("id = ?", id)).First(&testRun)
 	c.JSON(http.StatusOK, testRun)
 
 }
@@ -168,16 +303,32 @@ func (h *Handler) UpdateTestRun(c *gin.Context) {
 	id := c.Param("id")
 
 	db := h.db
-	if err := db.Where("id = ?", id).First(&testRun).Error; err != nil {
+	if err := scopeTestRuns(c, db.Where("id = ?", id)).First(&testRun).Error; err != nil {
 		c.AbortWithStatus(http.StatusNotFound)
 		return
 	}
-	err := c.BindJSON(&testRun)
-	if err != nil {
-		log.Printf("error binding json: %v", err)
+	audit.Before(c, &testRun)
+	testRunID, projectID := testRun.ID, testRun.ProjectID
+	if err := c.ShouldBindJSON(&testRun); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
+	// The body may not point the update at another run or move the run to a
+	// project the caller was not checked against
+	testRun.ID, testRun.ProjectID = testRunID, projectID
+	SignFailures(&testRun)
 
-	db.Save(&testRun)
+	// Only update the run itself, so that suite runs in the body cannot be
+	// moved over from other runs
+	if err := db.Omit(clause.Associations).Save(&testRun).Error; err != nil {
+		log.Printf("error updating test run %d: %v", testRunID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error updating test run"})
+		return
+	}
+	if audit.Recording(c) {
+		audit.Target(c, audit.TargetTestRun, testRun.ID, projectUUID(db, testRun.ProjectID))
+		audit.After(c, &testRun)
+	}
 	c.JSON(http.StatusOK, &testRun)
 }
 
@@ -191,7 +342,13 @@ func (h *Handler) DeleteTestRun(c *gin.Context) {
 		testRun.ID = uint64(testRunID)
 	}
 
-	result := h.db.Delete(&testRun)
+	var existing models.TestRun
+	if audit.Recording(c) && scopeTestRuns(c, h.db.Where("id = ?", testRun.ID)).First(&existing).Error == nil {
+		audit.Target(c, audit.TargetTestRun, existing.ID, projectUUID(h.db, existing.ProjectID))
+		audit.Before(c, &existing)
+	}
+
+	result := scopeTestRuns(c, h.db).Delete(&testRun)
 	if result.Error != nil {
 		// If there was an error during the delete operation
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "error deleting test run"})
@@ -214,7 +371,7 @@ func (h *Handler) ReportTestRunAll(c *gin.Context) {
 		return
 	}
 
-	query := h.db.Preload("SuiteRuns.SpecRuns.Tags").Preload("Project")
+	query := h.filterAllowedTestRuns(c, h.db.Preload("SuiteRuns.SpecRuns.Tags").Preload("Project"))
 
 	// Optional Filters
 	if filter.ProjectID != "" {
@@ -266,7 +423,7 @@ func (h *Handler) ReportTestRunAll(c *gin.Context) {
 func (h *Handler) ReportTestRunById(c *gin.Context) {
 	var testRun models.TestRun
 	id := c.Param("id")
-	h.db.Preload("SuiteRuns.SpecRuns.Tags").Preload("Project").Where("id = ?", id).First(&testRun)
+	scopeTestRuns(c, h.db.Preload("SuiteRuns.SpecRuns.Tags").Preload("Project").Where("id = ?", id)).First(&testRun)
 
 	c.JSON(http.StatusOK, gin.H{
 		"reportHeader": config.GetHeaderName(),
@@ -276,7 +433,7 @@ func (h *Handler) ReportTestRunById(c *gin.Context) {
 
 func (h *Handler) ReportTestRunAllHTML(c *gin.Context) {
 	var testRuns []models.TestRun
-	h.db.Preload("SuiteRuns.SpecRuns.Tags").Find(&testRuns)
+	h.filterAllowedTestRuns(c, h.db.Preload("SuiteRuns.SpecRuns.Tags")).Find(&testRuns)
 	totalTests, executedTests, passedTests, failedTests := utils.CalculateTestMetrics(testRuns)
 
 	c.HTML(http.StatusOK, "test_runs.html", gin.H{
@@ -292,7 +449,7 @@ func (h *Handler) ReportTestRunAllHTML(c *gin.Context) {
 func (h *Handler) ReportTestRunByIdHTML(c *gin.Context) {
 	var testRun models.TestRun
 	id := c.Param("id")
-	h.db.Preload("SuiteRuns.SpecRuns").Where("id = ?", id).First(&testRun)
+	scopeTestRuns(c, h.db.Preload("SuiteRuns.SpecRuns").Where("id = ?", id)).First(&testRun)
 	testRuns := []models.TestRun{testRun}
 	totalTests, executedTests, passedTests, failedTests := utils.CalculateTestMetrics(testRuns)
 
@@ -320,13 +477,13 @@ func (h *Handler) ReportTestInsights(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid endTimeInput parameter: %v", err)})
 	}
 
-	longestTestRuns := GetLongestTestRuns(h, projectName, startTime, endTime)
+	longestTestRuns := GetLongestTestRuns(c, h, projectName, startTime, endTime)
 	numTests := len(longestTestRuns)
 	if len(longestTestRuns) > 10 {
 		longestTestRuns = longestTestRuns[:10] //only send top 10 longest runs to display
 	}
 
-	averageDuration := GetAverageDuration(h, projectName, startTime, endTime)
+	averageDuration := GetAverageDuration(c, h, projectName, startTime, endTime)
 	fmt.Printf("longestTestRuns: %v\n", longestTestRuns)
 	fmt.Printf("averageDuration: %v\n", averageDuration)
 
@@ -343,7 +500,7 @@ func (h *Handler) ReportTestInsights(c *gin.Context) {
 
 func (h *Handler) GetTestSummary(c *gin.Context) {
 	projectId := c.Param("projectId")
-	testSummaries := GetProjectSpecStatistics(h, projectId)
+	testSummaries := GetProjectSpecStatistics(c, h, projectId)
 
 	c.JSON(http.StatusOK, testSummaries)
 }
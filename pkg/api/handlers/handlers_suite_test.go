@@ -3,6 +3,8 @@ package handlers_test
 import (
 	"testing"
 
+	"github.com/guidewire/fern-reporter/config"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -11,3 +13,8 @@ func TestHandlers(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Handlers Suite")
 }
+
+var _ = BeforeSuite(func() {
+	_, err := config.LoadConfig()
+	Expect(err).NotTo(HaveOccurred())
+})
@@ -22,6 +22,7 @@ import (
 
 	"github.com/guidewire/fern-reporter/pkg/api/handlers"
 	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
 )
 
 var _ = Describe("ParseTagName", func() {
@@ -196,8 +197,8 @@ var _ = Describe("Handlers", func() {
 				WillReturnRows(rows)
 
 			mock.ExpectBegin()
-			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_runs" ("test_project_name","project_id","test_seed","start_time","end_time","git_branch","git_sha","build_trigger_actor","build_url") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "id"`)).
-				WithArgs(expectedTestRun.TestProjectName, expectedProject.ID, expectedTestRun.TestSeed, expectedTestRun.StartTime, expectedTestRun.EndTime, expectedTestRun.GitBranch, expectedTestRun.GitSha, expectedTestRun.BuildTriggerActor, expectedTestRun.BuildUrl).
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_runs" ("test_project_name","project_id","test_seed","start_time","end_time","status","git_branch","git_sha","build_trigger_actor","build_url") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "id"`)).
+				WithArgs(expectedTestRun.TestProjectName, expectedProject.ID, expectedTestRun.TestSeed, expectedTestRun.StartTime, expectedTestRun.EndTime, expectedTestRun.Status, expectedTestRun.GitBranch, expectedTestRun.GitSha, expectedTestRun.BuildTriggerActor, expectedTestRun.BuildUrl).
 				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 			mock.ExpectCommit()
 
@@ -369,8 +370,8 @@ var _ = Describe("Handlers", func() {
 			}
 
 			// mock.ExpectBegin()
-			testRuns := sqlmock.NewRows([]string{"id", "TestProjectName"}).
-				AddRow(1, "project 1")
+			testRuns := sqlmock.NewRows([]string{"id", "TestProjectName", "project_id"}).
+				AddRow(1, "project 1", expectedProject.ID)
 
 			rows := sqlmock.NewRows([]string{"ID", "UUID"}).
 				AddRow(expectedProject.ID, expectedProject.UUID)
@@ -452,8 +453,8 @@ var _ = Describe("Handlers", func() {
 				},
 			}
 
-			testRuns := sqlmock.NewRows([]string{"id", "TestProjectName"}).
-				AddRow(1, "project 1")
+			testRuns := sqlmock.NewRows([]string{"id", "TestProjectName", "project_id"}).
+				AddRow(1, "project 1", expectedProject.ID)
 
 			projectRows := sqlmock.NewRows([]string{"ID", "UUID"}).
 				AddRow(expectedProject.ID, expectedProject.UUID)
@@ -467,18 +468,31 @@ var _ = Describe("Handlers", func() {
 				WithArgs(testRun.ID, 1).
 				WillReturnRows(testRuns)
 
-			rows := sqlmock.NewRows([]string{"id"})
+			rows := sqlmock.NewRows([]string{"id", "name"})
 
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name = $1 ORDER BY "tags"."id" LIMIT $2`)).WithArgs("TagName", 1).WillReturnRows(rows)
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name IN ($1)`)).WithArgs("TagName").WillReturnRows(rows)
 
 			mock.ExpectBegin()
-			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tags" ("name","category","value") VALUES ($1,$2,$3) RETURNING "id"`)).
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tags" ("name","category","value") VALUES ($1,$2,$3) ON CONFLICT ("name") DO NOTHING RETURNING "id"`)).
 				WithArgs("TagName", "", "TagName").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 			mock.ExpectCommit()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name IN ($1)`)).WithArgs("TagName").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "TagName"))
 
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_cases" WHERE project_id = $1 AND fingerprint IN ($2)`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "fingerprint"}).AddRow(1, utils.TestCaseFingerprint(expectedProject.ID, "TestSuite", "TestSpec")))
 			mock.ExpectBegin()
-			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "test_project_name"=$1,"project_id"=$2,"test_seed"=$3,"start_time"=$4,"end_time"=$5,"git_branch"=$6,"git_sha"=$7,"build_trigger_actor"=$8,"build_url"=$9 WHERE "id" = $10`)).
-				WithArgs(testRun.TestProjectName, expectedProject.ID, testRun.TestSeed, testRun.StartTime, testRun.EndTime, testRun.GitBranch, testRun.GitSha, testRun.BuildTriggerActor, testRun.BuildUrl, testRun.ID).
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_cases" SET "first_seen"=$1 WHERE id IN ($2) AND first_seen > $3`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectCommit()
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_cases" SET "last_seen"=$1,"last_status"=$2 WHERE id IN ($3) AND last_seen <= $4`)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "test_project_name"=$1,"project_id"=$2,"test_seed"=$3,"start_time"=$4,"end_time"=$5,"status"=$6,"git_branch"=$7,"git_sha"=$8,"build_trigger_actor"=$9,"build_url"=$10 WHERE "id" = $11`)).
+				WithArgs(testRun.TestProjectName, expectedProject.ID, testRun.TestSeed, testRun.StartTime, testRun.EndTime, testRun.Status, testRun.GitBranch, testRun.GitSha, testRun.BuildTriggerActor, testRun.BuildUrl, testRun.ID).
 				WillReturnError(errors.New("unable to save record"))
 			mock.ExpectRollback()
 
@@ -657,10 +671,8 @@ var _ = Describe("Handlers", func() {
 			for _, suite := range testRun.SuiteRuns {
 				for _, spec := range suite.SpecRuns {
 					for _, tag := range spec.Tags {
-						rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
-						mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name = $1 ORDER BY "tags"."id" LIMIT $2`)).WithArgs(tag.Name, 1).WillReturnRows(rows)
-						mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name = $1 ORDER BY "tags"."id" LIMIT $2`)).WithArgs(tag.Name, 1).WillReturnRows(rows)
-						mock.ExpectCommit()
+						rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(1, tag.Name)
+						mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name IN ($1)`)).WithArgs(tag.Name).WillReturnRows(rows)
 					}
 				}
 			}
@@ -687,14 +699,16 @@ var _ = Describe("Handlers", func() {
 			suiteRun := models.SuiteRun{SpecRuns: []models.SpecRun{specRun}}
 			testRun := &models.TestRun{SuiteRuns: []models.SuiteRun{suiteRun}}
 
-			rows := sqlmock.NewRows([]string{"id"})
+			rows := sqlmock.NewRows([]string{"id", "name"})
 
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name = $1 ORDER BY "tags"."id" LIMIT $2`)).WithArgs(tag.Name, 1).WillReturnRows(rows)
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name IN ($1)`)).WithArgs(tag.Name).WillReturnRows(rows)
 			mock.ExpectBegin()
 
-			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tags" ("name","category","value") VALUES ($1,$2,$3) RETURNING "id"`)).
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tags" ("name","category","value") VALUES ($1,$2,$3) ON CONFLICT ("name") DO NOTHING RETURNING "id"`)).
 				WithArgs(tag.Name, tag.Category, tag.Value).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 			mock.ExpectCommit()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name IN ($1)`)).WithArgs(tag.Name).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, tag.Name))
 
 			err := handlers.ProcessTags(gormDb, testRun)
 			Expect(err).NotTo(HaveOccurred())
@@ -708,14 +722,14 @@ var _ = Describe("Handlers", func() {
 			suiteRun := models.SuiteRun{SpecRuns: []models.SpecRun{specRun}}
 			testRun := &models.TestRun{SuiteRuns: []models.SuiteRun{suiteRun}}
 
-			rows := sqlmock.NewRows([]string{"id"})
+			rows := sqlmock.NewRows([]string{"id", "name"})
 
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name = $1 ORDER BY "tags"."id" LIMIT $2`)).WithArgs(tag.Name, 1).WillReturnRows(rows)
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name IN ($1)`)).WithArgs(tag.Name).WillReturnRows(rows)
 			mock.ExpectBegin()
 
-			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tags" ("name") VALUES ($1) RETURNING "id"`)).
-				WithArgs(tag.Name).WillReturnError(errors.New("database error"))
-			mock.ExpectCommit()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tags" ("name","category","value") VALUES ($1,$2,$3) ON CONFLICT ("name") DO NOTHING RETURNING "id"`)).
+				WithArgs(tag.Name, "", tag.Name).WillReturnError(errors.New("database error"))
+			mock.ExpectRollback()
 
 			err := handlers.ProcessTags(gormDb, testRun)
 			Expect(err).To(HaveOccurred())
@@ -764,8 +778,7 @@ var _ = Describe("Handlers", func() {
 			for _, suite := range testRun.SuiteRuns {
 				for _, spec := range suite.SpecRuns {
 					for _, tag := range spec.Tags {
-						mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name = $1 ORDER BY "tags"."id" LIMIT $2`)).WithArgs(tag.Name, 1).WillReturnError(errors.New("unknown error"))
-						mock.ExpectRollback()
+						mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name IN ($1)`)).WithArgs(tag.Name).WillReturnError(errors.New("unknown error"))
 					}
 				}
 			}
@@ -832,6 +845,11 @@ var _ = Describe("Handlers", func() {
 				WillReturnRows(mock.NewRows([]string{"id", "test_project_name", "test_seed", "git_branch", "git_sha", "build_trigger_actor", "build_url"}).
 					AddRow(expectedTestRun.ID, expectedTestRun.TestProjectName, expectedTestRun.TestSeed, expectedTestRun.GitBranch, expectedTestRun.GitSha, expectedTestRun.BuildTriggerActor, expectedTestRun.BuildUrl))
 
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET`)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 
@@ -853,6 +871,32 @@ var _ = Describe("Handlers", func() {
 			Expect(w.Code).To(Equal(http.StatusOK))
 		})
 
+		It("and saving the test run fails, it should return 500 Internal Server Error", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 ORDER BY "test_runs"."id" LIMIT $2`)).
+				WithArgs("1", 1).
+				WillReturnRows(mock.NewRows([]string{"id", "git_branch"}).AddRow(1, "main"))
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET`)).
+				WillReturnError(errors.New("database error"))
+			mock.ExpectRollback()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			req, err := http.NewRequest("PUT", "/endpoint", bytes.NewBufferString(`{"git_branch": "release"}`))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+
+			c.Request = req
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+			handler := handlers.NewHandler(gormDb)
+			handler.UpdateTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			Expect(w.Body.String()).To(ContainSubstring("error updating test run"))
+		})
+
 		It("with wrong POST payload, it should return status 200 OK", func() {
 			expectedTestRun := models.TestRun{
 				ID:              1,
@@ -866,6 +910,11 @@ var _ = Describe("Handlers", func() {
 				WillReturnRows(mock.NewRows([]string{"id", "test_project_name", "test_seed", "start_time", "end_time"}).
 					AddRow(expectedTestRun.ID, expectedTestRun.TestProjectName, expectedTestRun.TestSeed, expectedTestRun.StartTime, expectedTestRun.EndTime))
 
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET`)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 
@@ -931,6 +980,11 @@ var _ = Describe("Handlers", func() {
 				WillReturnRows(mock.NewRows([]string{"id", "test_project_name", "test_seed"}).
 					AddRow(expectedTestRun.ID, expectedTestRun.TestProjectName, expectedTestRun.TestSeed))
 
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET`)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 
@@ -1150,7 +1204,7 @@ func setupTestDB() *gorm.DB {
 	if err != nil {
 		panic("failed to connect database: " + err.Error())
 	}
-	if err := db.AutoMigrate(&models.ProjectDetails{}, &models.TestRun{}, &models.SuiteRun{}, &models.SpecRun{}, &models.Tag{}); err != nil {
+	if err := db.AutoMigrate(&models.ProjectDetails{}, &models.TestRun{}, &models.SuiteRun{}, &models.SpecRun{}, &models.Tag{}, &models.IdempotencyKey{}, &models.IngestJob{}, &models.TestCase{}, &models.DurationRegression{}); err != nil {
 		panic("failed to migrate database: " + err.Error())
 	}
 	return db
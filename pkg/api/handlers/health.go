@@ -0,0 +1,466 @@
+package handlers
+
+import (
+	"cmp"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const defaultHealthDays = 30
+
+// HealthWeights sets how much each component contributes to a health score.
+// Components without data are left out and the remaining weights rescaled.
+type HealthWeights struct {
+	PassRate      float64
+	Flakiness     float64
+	DurationTrend float64
+	Staleness     float64
+	LongFailing   float64
+}
+
+// HealthOptions controls how project health is computed.
+type HealthOptions struct {
+	Weights HealthWeights
+	// From and To bound the runs the components are computed over.
+	From time.Time
+	To   time.Time
+	// StaleDays is the age of the last run at which the staleness component
+	// reaches zero.
+	StaleDays int
+	// LongFailingRuns is the number of consecutive failures after which a spec
+	// counts as long failing.
+	LongFailingRuns int
+	// TeamName limits the report to the projects of one team when set.
+	TeamName string
+	// Projects limits the report to the projects with the given names when
+	// LimitProjects is set.
+	Projects      []string
+	LimitProjects bool
+}
+
+// HealthOptionsFromConfig reads the health weights and thresholds from the
+// reports section of the config.
+func HealthOptionsFromConfig() HealthOptions {
+	health := config.GetReports().Health
+	return HealthOptions{
+		Weights: HealthWeights{
+			PassRate:      health.Weights.PassRate,
+			Flakiness:     health.Weights.Flakiness,
+			DurationTrend: health.Weights.DurationTrend,
+			Staleness:     health.Weights.Staleness,
+			LongFailing:   health.Weights.LongFailing,
+		},
+		StaleDays:       health.StaleDays,
+		LongFailingRuns: health.LongFailingRuns,
+	}
+}
+
+type healthRun struct {
+	ProjectID uint64
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// FindProjectHealth scores every project between 0 and 100, worst first, and
+// averages the scores of the projects of each team. Each component of a score
+// is between 0 and 1, higher being healthier:
+//
+//   - pass rate: passed specs over passed and failed specs;
+//   - flakiness: the share of specs that did not flip between passing and
+//     failing at the same git sha;
+//   - duration trend: 1 minus the relative increase of the median run
+//     duration in the second half of the window over the first half;
+//   - staleness: 1 minus the age of the last run over StaleDays;
+//   - long failing: the share of specs whose last LongFailingRuns outcomes
+//     were not all failures.
+//
+// Components are computed over runs between From and To, except staleness
+// which looks at the last run ever. Projects without runs in the window are
+// only scored on staleness. Only the projects of the organization given by the
+// context of db are scored.
+func FindProjectHealth(db *gorm.DB, options HealthOptions) ([]models.ProjectHealth, []models.TeamHealth, error) {
+	projectQuery := auth.ScopeProjects(db.Statement.Context, db.Order("name"))
+	if options.TeamName != "" {
+		projectQuery = projectQuery.Where("team_name = ?", options.TeamName)
+	}
+	if options.LimitProjects {
+		projectQuery = projectQuery.Where("name IN ?", options.Projects)
+	}
+	var projects []models.ProjectDetails
+	if err := projectQuery.Find(&projects).Error; err != nil {
+		return nil, nil, err
+	}
+	projectIDs := make([]uint64, len(projects))
+	for i, project := range projects {
+		projectIDs[i] = project.ID
+	}
+
+	var runs []healthRun
+	if err := db.Model(&models.TestRun{}).
+		Select("project_id, start_time, end_time").
+		Where("project_id IN ? AND start_time >= ? AND start_time < ?", projectIDs, options.From, options.To).
+		Order("start_time, id").
+		Scan(&runs).Error; err != nil {
+		return nil, nil, err
+	}
+	runsByProject := map[uint64][]healthRun{}
+	for _, run := range runs {
+		runsByProject[run.ProjectID] = append(runsByProject[run.ProjectID], run)
+	}
+
+	var lastRuns []healthRun
+	if err := db.Model(&models.TestRun{}).
+		Select("project_id, start_time").
+		Where("project_id IN ?", projectIDs).
+		Where("start_time = (SELECT MAX(latest.start_time) FROM test_runs latest WHERE latest.project_id = test_runs.project_id)").
+		Scan(&lastRuns).Error; err != nil {
+		return nil, nil, err
+	}
+	lastRunTimes := map[uint64]time.Time{}
+	for _, run := range lastRuns {
+		lastRunTimes[run.ProjectID] = run.StartTime
+	}
+
+	var outcomes []specOutcome
+	if err := db.Table("spec_runs").
+		Select("test_runs.project_id, test_runs.id AS test_run_id, test_runs.start_time AS run_start_time, test_runs.git_sha, "+
+			"suite_runs.suite_name, spec_runs.spec_description, spec_runs.status").
+		Joins("JOIN suite_runs ON suite_runs.id = spec_runs.suite_id").
+		Joins("JOIN test_runs ON test_runs.id = suite_runs.test_run_id").
+		Where("test_runs.project_id IN ? AND test_runs.start_time >= ? AND test_runs.start_time < ?",
+			projectIDs, options.From, options.To).
+		Where("spec_runs.status = ? OR spec_runs.status IN ?", utils.StatusPassed, utils.FailedStatuses).
+		Order("test_runs.start_time, test_runs.id, spec_runs.id").
+		Scan(&outcomes).Error; err != nil {
+		return nil, nil, err
+	}
+	outcomesByProject := map[uint64][]specOutcome{}
+	for _, outcome := range outcomes {
+		outcomesByProject[outcome.ProjectID] = append(outcomesByProject[outcome.ProjectID], outcome)
+	}
+
+	health := make([]models.ProjectHealth, 0, len(projects))
+	for _, project := range projects {
+		entry := models.ProjectHealth{
+			ProjectID: project.UUID,
+			Name:      project.Name,
+			TeamName:  project.TeamName,
+			Runs:      len(runsByProject[project.ID]),
+		}
+
+		staleness := 0.0
+		if lastRunTime, ok := lastRunTimes[project.ID]; ok {
+			entry.LastRunTime = &lastRunTime
+			age := options.To.Sub(lastRunTime).Hours() / 24
+			staleness = clamp01(1 - age/float64(max(options.StaleDays, 1)))
+		}
+		entry.Components.Staleness = &staleness
+
+		if projectOutcomes := outcomesByProject[project.ID]; len(projectOutcomes) > 0 {
+			scoreSpecOutcomes(projectOutcomes, options, &entry)
+		}
+		scoreDurationTrend(runsByProject[project.ID], options, &entry)
+
+		entry.Score = healthScore(entry.Components, options.Weights)
+		health = append(health, entry)
+	}
+
+	slices.SortStableFunc(health, func(a, b models.ProjectHealth) int {
+		return compareScores(a.Score, b.Score)
+	})
+	return health, teamHealth(health), nil
+}
+
+// scoreSpecOutcomes sets the pass rate, flakiness and long failing components
+// of a project from its spec outcomes, oldest first.
+func scoreSpecOutcomes(outcomes []specOutcome, options HealthOptions, entry *models.ProjectHealth) {
+	var passed, failed int
+	history := map[specIdentity][]bool{}
+	for _, outcome := range outcomes {
+		isFailure := outcome.Status != utils.StatusPassed
+		if isFailure {
+			failed++
+		} else {
+			passed++
+		}
+		identity := specIdentity{outcome.SuiteName, outcome.SpecDescription}
+		history[identity] = append(history[identity], isFailure)
+	}
+	passRate := float64(passed) / float64(passed+failed)
+	entry.Components.PassRate = &passRate
+	entry.Specs = len(history)
+
+	for _, failures := range history {
+		if len(failures) < options.LongFailingRuns {
+			continue
+		}
+		if !slices.Contains(failures[len(failures)-options.LongFailingRuns:], false) {
+			entry.LongFailingSpecs++
+		}
+	}
+	longFailing := 1 - float64(entry.LongFailingSpecs)/float64(entry.Specs)
+	entry.Components.LongFailing = &longFailing
+
+	flaky := rankFlakySpecs(outcomes, FlakyOptions{
+		Scope:    FlakyScopeGitSha,
+		Window:   defaultFlakyWindow,
+		MinFlips: 1,
+	})
+	entry.FlakySpecs = len(flaky)
+	flakiness := clamp01(1 - float64(entry.FlakySpecs)/float64(entry.Specs))
+	entry.Components.Flakiness = &flakiness
+}
+
+// scoreDurationTrend compares the median duration of the runs in the second
+// half of the window with the first half. The component is left unset unless
+// both halves have finished runs.
+func scoreDurationTrend(runs []healthRun, options HealthOptions, entry *models.ProjectHealth) {
+	middle := options.From.Add(options.To.Sub(options.From) / 2)
+	var earlier, later []int64
+	for _, run := range runs {
+		duration := specDurationMs(run.StartTime, run.EndTime)
+		if duration <= 0 {
+			continue
+		}
+		if run.StartTime.Before(middle) {
+			earlier = append(earlier, duration)
+		} else {
+			later = append(later, duration)
+		}
+	}
+	if len(earlier) == 0 || len(later) == 0 {
+		return
+	}
+
+	change := medianMs(later)/medianMs(earlier) - 1
+	trend := clamp01(1 - change)
+	entry.DurationChange = &change
+	entry.Components.DurationTrend = &trend
+}
+
+func medianMs(durations []int64) float64 {
+	slices.Sort(durations)
+	middle := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return float64(durations[middle-1]+durations[middle]) / 2
+	}
+	return float64(durations[middle])
+}
+
+func clamp01(value float64) float64 {
+	return math.Min(math.Max(value, 0), 1)
+}
+
+// healthScore is the weighted mean of the components that are set, scaled to
+// 0 to 100. It is nil when no weighted component is set.
+func healthScore(components models.HealthComponents, weights HealthWeights) *float64 {
+	var sum, total float64
+	for _, component := range []struct {
+		value  *float64
+		weight float64
+	}{
+		{components.PassRate, weights.PassRate},
+		{components.Flakiness, weights.Flakiness},
+		{components.DurationTrend, weights.DurationTrend},
+		{components.Staleness, weights.Staleness},
+		{components.LongFailing, weights.LongFailing},
+	} {
+		if component.value == nil || component.weight <= 0 {
+			continue
+		}
+		sum += *component.value * component.weight
+		total += component.weight
+	}
+	if total == 0 {
+		return nil
+	}
+	score := math.Round(sum/total*1000) / 10
+	return &score
+}
+
+// teamHealth averages the scores of the projects of each team, worst first.
+// Projects without a team are grouped under an empty team name.
+func teamHealth(projects []models.ProjectHealth) []models.TeamHealth {
+	type totals struct {
+		sum    float64
+		scored int
+		count  int
+	}
+	byTeam := map[string]*totals{}
+	var order []string
+	for _, project := range projects {
+		team, ok := byTeam[project.TeamName]
+		if !ok {
+			team = &totals{}
+			byTeam[project.TeamName] = team
+			order = append(order, project.TeamName)
+		}
+		team.count++
+		if project.Score != nil {
+			team.sum += *project.Score
+			team.scored++
+		}
+	}
+
+	teams := make([]models.TeamHealth, 0, len(order))
+	for _, name := range order {
+		team := byTeam[name]
+		entry := models.TeamHealth{TeamName: name, Projects: team.count}
+		if team.scored > 0 {
+			score := math.Round(team.sum/float64(team.scored)*10) / 10
+			entry.Score = &score
+		}
+		teams = append(teams, entry)
+	}
+	slices.SortStableFunc(teams, func(a, b models.TeamHealth) int {
+		if c := compareScores(a.Score, b.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.TeamName, b.TeamName)
+	})
+	return teams
+}
+
+// compareScores orders lower scores first and missing scores last.
+func compareScores(a *float64, b *float64) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return cmp.Compare(*a, *b)
+}
+
+// healthOptionsFromQuery combines the configured weights with the days and
+// team query parameters, writing an error response and returning false when
+// they are invalid.
+func healthOptionsFromQuery(c *gin.Context) (HealthOptions, bool) {
+	days, err := QueryPositiveInt(c, "days", defaultHealthDays)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return HealthOptions{}, false
+	}
+
+	options := HealthOptionsFromConfig()
+	options.To = time.Now()
+	options.From = options.To.AddDate(0, 0, -days)
+	options.TeamName = c.Query("team")
+	if names, all := auth.AllowedProjects(c); !all {
+		options.Projects, options.LimitProjects = names, true
+	}
+	return options, true
+}
+
+// GetHealthReport returns the health score of every project, worst first, and
+// the average score of each team. Optional query parameters: days (the window
+// the components are computed over, default 30) and team.
+func (h *Handler) GetHealthReport(c *gin.Context) {
+	options, ok := healthOptionsFromQuery(c)
+	if !ok {
+		return
+	}
+
+	projects, teams, err := FindProjectHealth(h.db.WithContext(c.Request.Context()), options)
+	if err != nil {
+		log.Printf("error computing project health: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error computing project health"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"from":     options.From,
+		"to":       options.To,
+		"weights":  options.Weights,
+		"teams":    teams,
+		"projects": projects,
+	})
+}
+
+// healthRow is a project or team of the health overview page, formatted for
+// display.
+type healthRow struct {
+	Name     string
+	TeamName string
+	Score    string
+	Class    string
+	Details  []string
+}
+
+func formatHealthScore(score *float64) (string, string) {
+	switch {
+	case score == nil:
+		return "n/a", "is-light"
+	case *score >= 80:
+		return fmt.Sprintf("%.1f", *score), "is-success"
+	case *score >= 50:
+		return fmt.Sprintf("%.1f", *score), "is-warning"
+	default:
+		return fmt.Sprintf("%.1f", *score), "is-danger"
+	}
+}
+
+// ReportHealthHTML renders the health overview page. It accepts the same
+// query parameters as GetHealthReport.
+func (h *Handler) ReportHealthHTML(c *gin.Context) {
+	options, ok := healthOptionsFromQuery(c)
+	if !ok {
+		return
+	}
+
+	projects, teams, err := FindProjectHealth(h.db.WithContext(c.Request.Context()), options)
+	if err != nil {
+		log.Printf("error computing project health: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error computing project health"})
+		return
+	}
+
+	teamRows := make([]healthRow, len(teams))
+	for i, team := range teams {
+		score, class := formatHealthScore(team.Score)
+		teamRows[i] = healthRow{Name: team.TeamName, Score: score, Class: class,
+			Details: []string{fmt.Sprintf("%d projects", team.Projects)}}
+	}
+	projectRows := make([]healthRow, len(projects))
+	for i, project := range projects {
+		score, class := formatHealthScore(project.Score)
+		row := healthRow{Name: project.Name, TeamName: project.TeamName, Score: score, Class: class}
+		if project.Components.PassRate != nil {
+			row.Details = append(row.Details, fmt.Sprintf("pass rate %.1f%%", *project.Components.PassRate*100))
+		}
+		row.Details = append(row.Details,
+			fmt.Sprintf("%d runs", project.Runs),
+			fmt.Sprintf("%d flaky specs", project.FlakySpecs),
+			fmt.Sprintf("%d long failing specs", project.LongFailingSpecs))
+		if project.DurationChange != nil {
+			row.Details = append(row.Details, fmt.Sprintf("duration %+.0f%%", *project.DurationChange*100))
+		}
+		if project.LastRunTime != nil {
+			row.Details = append(row.Details, "last run "+utils.FormatDate(*project.LastRunTime))
+		} else {
+			row.Details = append(row.Details, "never run")
+		}
+		projectRows[i] = row
+	}
+
+	c.HTML(http.StatusOK, "health.html", gin.H{
+		"reportHeader": config.GetHeaderName(),
+		"from":         utils.FormatDate(options.From),
+		"to":           utils.FormatDate(options.To),
+		"teams":        teamRows,
+		"projects":     projectRows,
+	})
+}
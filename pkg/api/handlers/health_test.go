@@ -0,0 +1,213 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Project health", func() {
+	var (
+		db      *gorm.DB
+		now     time.Time
+		cart    models.ProjectDetails
+		search  models.ProjectDetails
+		options handlers.HealthOptions
+	)
+
+	addProject := func(name string, team string, uuid string) models.ProjectDetails {
+		project := models.ProjectDetails{Name: name, TeamName: team}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", uuid, project.ID).Error).NotTo(HaveOccurred())
+		project.UUID = uuid
+		return project
+	}
+
+	// addRun stores a run of a project that started daysAgo and took duration.
+	// Spec names map to their status.
+	addRun := func(project models.ProjectDetails, daysAgo int, duration time.Duration, specs map[string]string) {
+		start := now.AddDate(0, 0, -daysAgo)
+		suite := models.SuiteRun{SuiteName: "Suite"}
+		for description, status := range specs {
+			suite.SpecRuns = append(suite.SpecRuns, models.SpecRun{SpecDescription: description, Status: status})
+		}
+		testRun := models.TestRun{
+			ProjectID: project.ID,
+			GitSha:    start.Format("20060102"),
+			StartTime: start,
+			EndTime:   start.Add(duration),
+			SuiteRuns: []models.SuiteRun{suite},
+		}
+		Expect(db.Create(&testRun).Error).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		db = setupTestDB()
+		now = time.Now()
+		cart = addProject("Cart", "shop", "cart-uuid")
+		search = addProject("Search", "shop", "search-uuid")
+		addProject("Billing", "finance", "billing-uuid")
+		options = handlers.HealthOptions{
+			Weights: handlers.HealthWeights{
+				PassRate:      1,
+				Flakiness:     1,
+				DurationTrend: 1,
+				Staleness:     1,
+				LongFailing:   1,
+			},
+			From:            now.AddDate(0, 0, -30),
+			To:              now,
+			StaleDays:       10,
+			LongFailingRuns: 3,
+		}
+
+		for day := 21; day >= 1; day -= 4 {
+			addRun(cart, day, time.Minute, map[string]string{"adds items": "passed", "checks out": "passed"})
+		}
+		for day := 28; day >= 12; day -= 4 {
+			addRun(search, day, time.Minute*time.Duration(30-day), map[string]string{"finds items": "passed", "sorts": "failed"})
+		}
+	})
+
+	It("scores projects from their components and lists the worst first", func() {
+		projects, teams, err := handlers.FindProjectHealth(db, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(projects).To(HaveLen(3))
+
+		Expect(projects[0].Name).To(Equal("Billing"))
+		Expect(*projects[0].Score).To(Equal(0.0))
+		Expect(projects[0].LastRunTime).To(BeNil())
+		Expect(projects[0].Components.PassRate).To(BeNil())
+
+		searchHealth := projects[1]
+		Expect(searchHealth.Name).To(Equal("Search"))
+		Expect(searchHealth.Runs).To(Equal(5))
+		Expect(searchHealth.Specs).To(Equal(2))
+		Expect(searchHealth.LastRunTime.Unix()).To(Equal(now.AddDate(0, 0, -12).Unix()))
+		Expect(*searchHealth.Components.PassRate).To(Equal(0.5))
+		Expect(searchHealth.LongFailingSpecs).To(Equal(1))
+		Expect(*searchHealth.Components.LongFailing).To(Equal(0.5))
+		Expect(*searchHealth.Components.Staleness).To(BeNumerically("~", 0, 0.01))
+		Expect(*searchHealth.DurationChange).To(BeNumerically(">", 1))
+		Expect(*searchHealth.Components.DurationTrend).To(Equal(0.0))
+		Expect(*searchHealth.Components.Flakiness).To(Equal(1.0))
+
+		cartHealth := projects[2]
+		Expect(cartHealth.Name).To(Equal("Cart"))
+		Expect(*cartHealth.Score).To(BeNumerically(">", 95))
+		Expect(cartHealth.LastRunTime.Unix()).To(Equal(now.AddDate(0, 0, -1).Unix()))
+		Expect(*cartHealth.Components.DurationTrend).To(Equal(1.0))
+
+		Expect(teams).To(HaveLen(2))
+		Expect(teams[0].TeamName).To(Equal("finance"))
+		Expect(teams[1].TeamName).To(Equal("shop"))
+		Expect(teams[1].Projects).To(Equal(2))
+		Expect(*teams[1].Score).To(BeNumerically("~", (*searchHealth.Score+*cartHealth.Score)/2, 0.1))
+	})
+
+	It("leaves out components without weight or data", func() {
+		options.Weights = handlers.HealthWeights{PassRate: 1}
+		options.TeamName = "finance"
+
+		projects, teams, err := handlers.FindProjectHealth(db, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(projects).To(HaveLen(1))
+		Expect(projects[0].Score).To(BeNil())
+		Expect(teams).To(HaveLen(1))
+		Expect(teams[0].Score).To(BeNil())
+	})
+
+	Describe("endpoints", func() {
+		var router, scoped *gin.Engine
+
+		serve := func(engine *gin.Engine, path string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("GET", path, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			return w
+		}
+
+		get := func(path string) *httptest.ResponseRecorder {
+			return serve(router, path)
+		}
+
+		BeforeEach(func() {
+			gin.SetMode(gin.TestMode)
+			router = gin.New()
+			router.LoadHTMLGlob("../../views/health.html")
+			handler := handlers.NewHandler(db)
+			router.GET("/api/reports/health", handler.GetHealthReport)
+			router.GET("/reports/health/", handler.ReportHealthHTML)
+
+			scoped = gin.New()
+			scoped.LoadHTMLGlob("../../views/health.html")
+			scoped.Use(func(c *gin.Context) {
+				c.Set("scope", []interface{}{"fern.read", "fernproject.Cart"})
+			})
+			scoped.Use(auth.ScopeMiddleware(auth.NewDefaultProjectLookup(db)))
+			scoped.GET("/api/reports/health", handler.GetHealthReport)
+			scoped.GET("/reports/health/", handler.ReportHealthHTML)
+		})
+
+		It("returns the health of projects and teams as JSON", func() {
+			w := get("/api/reports/health?team=shop")
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var response struct {
+				Projects []models.ProjectHealth `json:"projects"`
+				Teams    []models.TeamHealth    `json:"teams"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Projects).To(HaveLen(2))
+			Expect(response.Projects[0].ProjectID).To(Equal("search-uuid"))
+			Expect(response.Projects[1].ProjectID).To(Equal("cart-uuid"))
+			Expect(response.Teams).To(HaveLen(1))
+		})
+
+		It("renders the health overview page", func() {
+			w := get("/reports/health/")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring("health-projects"))
+			Expect(w.Body.String()).To(ContainSubstring("Billing"))
+			Expect(w.Body.String()).To(ContainSubstring("never run"))
+			Expect(w.Body.String()).To(ContainSubstring("is-danger"))
+		})
+
+		It("only returns the health of the projects the caller's scopes allow", func() {
+			w := serve(scoped, "/api/reports/health")
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var response struct {
+				Projects []models.ProjectHealth `json:"projects"`
+				Teams    []models.TeamHealth    `json:"teams"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Projects).To(HaveLen(1))
+			Expect(response.Projects[0].ProjectID).To(Equal("cart-uuid"))
+			Expect(response.Teams).To(HaveLen(1))
+			Expect(response.Teams[0].Projects).To(Equal(1))
+		})
+
+		It("only renders the projects the caller's scopes allow", func() {
+			w := serve(scoped, "/reports/health/")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring("Cart"))
+			Expect(w.Body.String()).NotTo(ContainSubstring("Search"))
+			Expect(w.Body.String()).NotTo(ContainSubstring("Billing"))
+		})
+
+		It("rejects an invalid window", func() {
+			Expect(get("/api/reports/health?days=0").Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
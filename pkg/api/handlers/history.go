@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const defaultHistoryLimit = 50
+
+// TestHistoryOptions selects the page of a test's history that is returned.
+type TestHistoryOptions struct {
+	// Offset is the number of runs skipped before the page starts.
+	Offset int
+	// Limit caps the number of runs in the page.
+	Limit int
+	// Descending lists the most recent runs first.
+	Descending bool
+}
+
+// FindTestHistory returns the outcomes of the test case with the given
+// fingerprint across test runs, oldest first unless options.Descending is set,
+// together with summary statistics over the whole history. It returns
+// gorm.ErrRecordNotFound when no test case has the fingerprint, or when it
+// belongs to a project outside the organization given by the context of db or
+// one the caller may not access.
+func FindTestHistory(db *gorm.DB, fingerprint string, options TestHistoryOptions) (*models.TestHistory, error) {
+	history := models.TestHistory{Runs: []models.TestHistoryEntry{}}
+	if err := findTestCase(db, fingerprint, &history.TestCase); err != nil {
+		return nil, err
+	}
+
+	// The summary covers every run, so only read the columns it needs
+	var outcomes []struct {
+		Status    string
+		StartTime time.Time
+		EndTime   time.Time
+	}
+	if err := db.Table("spec_runs").
+		Select("spec_runs.status, spec_runs.start_time, spec_runs.end_time").
+		Where("spec_runs.test_case_id = ?", history.TestCase.ID).
+		Scan(&outcomes).Error; err != nil {
+		return nil, err
+	}
+
+	var durations []int64
+	for _, outcome := range outcomes {
+		history.Summary.Runs++
+		switch {
+		case outcome.Status == utils.StatusPassed:
+			history.Summary.Passed++
+		case outcome.Status == utils.StatusSkipped:
+			history.Summary.Skipped++
+			continue
+		case slices.Contains(utils.FailedStatuses, outcome.Status):
+			history.Summary.Failed++
+		}
+		if duration := specDurationMs(outcome.StartTime, outcome.EndTime); duration > 0 {
+			durations = append(durations, duration)
+		}
+	}
+	if decided := history.Summary.Passed + history.Summary.Failed; decided > 0 {
+		history.Summary.PassRate = float64(history.Summary.Passed) / float64(decided)
+	}
+	if len(durations) > 0 {
+		slices.Sort(durations)
+		median := durations[len(durations)/2]
+		if len(durations)%2 == 0 {
+			median = (durations[len(durations)/2-1] + median) / 2
+		}
+		history.Summary.MedianDurationMs = &median
+	}
+
+	direction := "ASC"
+	if options.Descending {
+		direction = "DESC"
+	}
+	if err := db.Table("spec_runs").
+		Select("test_runs.id AS test_run_id, spec_runs.id AS spec_run_id, spec_runs.status, spec_runs.message, "+
+			"spec_runs.start_time, spec_runs.end_time, test_runs.git_branch, test_runs.git_sha, test_runs.build_url").
+		Joins("JOIN suite_runs ON suite_runs.id = spec_runs.suite_id").
+		Joins("JOIN test_runs ON test_runs.id = suite_runs.test_run_id").
+		Where("spec_runs.test_case_id = ?", history.TestCase.ID).
+		Order(fmt.Sprintf("test_runs.start_time %[1]s, test_runs.id %[1]s, spec_runs.id %[1]s", direction)).
+		Offset(options.Offset).
+		Limit(options.Limit).
+		Scan(&history.Runs).Error; err != nil {
+		return nil, err
+	}
+	for i := range history.Runs {
+		history.Runs[i].DurationMs = specDurationMs(history.Runs[i].StartTime, history.Runs[i].EndTime)
+	}
+
+	return &history, nil
+}
+
+// specDurationMs returns the duration of a spec in milliseconds, or zero when
+// the importer did not record both ends.
+func specDurationMs(start time.Time, end time.Time) int64 {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start).Milliseconds()
+}
+
+// GetTestHistory returns the history of the test case with the given
+// fingerprint. Optional query parameters: limit, after (the next_cursor of
+// the previous page) and desc to list the most recent runs first.
+func (h *Handler) GetTestHistory(c *gin.Context) {
+	fingerprint := c.Param("fingerprint")
+
+	limit, err := QueryPositiveInt(c, "limit", defaultHistoryLimit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	options := TestHistoryOptions{Limit: limit}
+	if after := c.Query("after"); after != "" {
+		options.Offset = utils.DecodeCursor(&after)
+	}
+	if desc := c.Query("desc"); desc != "" {
+		if options.Descending, err = strconv.ParseBool(desc); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid desc parameter: %q", desc)})
+			return
+		}
+	}
+
+	history, err := FindTestHistory(h.db.WithContext(c.Request.Context()), fingerprint, options)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("test %s not found", fingerprint)})
+		return
+	} else if err != nil {
+		log.Printf("error loading history of test %s: %v", fingerprint, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading test history"})
+		return
+	}
+
+	nextCursor := ""
+	if end := options.Offset + len(history.Runs); end < history.Summary.Runs {
+		nextCursor = utils.EncodeCursor(end)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"test_case":   history.TestCase,
+		"summary":     history.Summary,
+		"runs":        history.Runs,
+		"next_cursor": nextCursor,
+	})
+}
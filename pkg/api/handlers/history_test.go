@@ -0,0 +1,147 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+var _ = Describe("Test history", func() {
+	var (
+		db          *gorm.DB
+		projectID   uint64
+		fingerprint string
+		runStart    time.Time
+	)
+
+	// addRun stores a run of the checkout spec that took the given time.
+	addRun := func(sha string, status string, duration time.Duration) {
+		runStart = runStart.Add(time.Hour)
+		testRun := models.TestRun{
+			ProjectID: projectID,
+			GitBranch: "main",
+			GitSha:    sha,
+			BuildUrl:  "https://ci.example.com/" + sha,
+			StartTime: runStart,
+			SuiteRuns: []models.SuiteRun{{SuiteName: "Cart", SpecRuns: []models.SpecRun{{
+				SpecDescription: "checks out",
+				Status:          status,
+				Message:         status + " on " + sha,
+				StartTime:       runStart,
+				EndTime:         runStart.Add(duration),
+			}}}},
+		}
+		Expect(handlers.LinkTestCases(db, &testRun)).To(Succeed())
+		Expect(db.Create(&testRun).Error).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		db = setupTestDB()
+		project := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		projectID = project.ID
+		fingerprint = utils.TestCaseFingerprint(projectID, "Cart", "checks out")
+		runStart = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+		addRun("sha1", "passed", 2*time.Second)
+		addRun("sha2", "failed", 4*time.Second)
+		addRun("sha3", "skipped", 0)
+		addRun("sha4", "passed", 3*time.Second)
+	})
+
+	It("lists the outcomes in chronological order with the run details", func() {
+		history, err := handlers.FindTestHistory(db, fingerprint, handlers.TestHistoryOptions{Limit: 10})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history.TestCase.SpecDescription).To(Equal("checks out"))
+		Expect(history.Runs).To(HaveLen(4))
+		Expect(history.Runs[0].GitSha).To(Equal("sha1"))
+		Expect(history.Runs[0].GitBranch).To(Equal("main"))
+		Expect(history.Runs[0].BuildUrl).To(Equal("https://ci.example.com/sha1"))
+		Expect(history.Runs[1].Message).To(Equal("failed on sha2"))
+		Expect(history.Runs[1].DurationMs).To(Equal(int64(4000)))
+		Expect(history.Runs[3].GitSha).To(Equal("sha4"))
+	})
+
+	It("summarizes the whole history", func() {
+		history, err := handlers.FindTestHistory(db, fingerprint, handlers.TestHistoryOptions{Limit: 1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history.Runs).To(HaveLen(1))
+		Expect(history.Summary.Runs).To(Equal(4))
+		Expect(history.Summary.Passed).To(Equal(2))
+		Expect(history.Summary.Failed).To(Equal(1))
+		Expect(history.Summary.Skipped).To(Equal(1))
+		Expect(history.Summary.PassRate).To(BeNumerically("~", 2.0/3.0))
+		Expect(*history.Summary.MedianDurationMs).To(Equal(int64(3000)))
+	})
+
+	It("pages through the most recent runs first", func() {
+		history, err := handlers.FindTestHistory(db, fingerprint, handlers.TestHistoryOptions{Offset: 1, Limit: 2, Descending: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history.Runs).To(HaveLen(2))
+		Expect(history.Runs[0].GitSha).To(Equal("sha3"))
+		Expect(history.Runs[1].GitSha).To(Equal("sha2"))
+	})
+
+	It("reports unknown fingerprints as not found", func() {
+		_, err := handlers.FindTestHistory(db, "unknown", handlers.TestHistoryOptions{Limit: 10})
+		Expect(err).To(MatchError(gorm.ErrRecordNotFound))
+	})
+
+	Context("GET /api/reports/tests/:fingerprint/history", func() {
+		var router *gin.Engine
+
+		get := func(path string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("GET", path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		BeforeEach(func() {
+			gin.SetMode(gin.TestMode)
+			router = gin.New()
+			router.GET("/api/reports/tests/:fingerprint/history", handlers.NewHandler(db).GetTestHistory)
+		})
+
+		It("returns a page of the history with a cursor for the next one", func() {
+			w := get("/api/reports/tests/" + fingerprint + "/history?limit=3")
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var response struct {
+				TestCase   models.TestCase           `json:"test_case"`
+				Summary    models.TestHistorySummary `json:"summary"`
+				Runs       []models.TestHistoryEntry `json:"runs"`
+				NextCursor string                    `json:"next_cursor"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.TestCase.Fingerprint).To(Equal(fingerprint))
+			Expect(response.Summary.Runs).To(Equal(4))
+			Expect(response.Runs).To(HaveLen(3))
+			Expect(response.NextCursor).To(Equal(utils.EncodeCursor(3)))
+
+			w = get("/api/reports/tests/" + fingerprint + "/history?limit=3&after=" + response.NextCursor)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			response.NextCursor = ""
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Runs).To(HaveLen(1))
+			Expect(response.Runs[0].GitSha).To(Equal("sha4"))
+			Expect(response.NextCursor).To(BeEmpty())
+		})
+
+		It("rejects invalid parameters and unknown tests", func() {
+			Expect(get("/api/reports/tests/" + fingerprint + "/history?limit=0").Code).To(Equal(http.StatusBadRequest))
+			Expect(get("/api/reports/tests/" + fingerprint + "/history?desc=maybe").Code).To(Equal(http.StatusBadRequest))
+			Expect(get("/api/reports/tests/unknown/history").Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
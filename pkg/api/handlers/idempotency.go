@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+var errIdempotencyKeyTaken = errors.New("idempotency key already used")
+
+// idempotencyKey returns the client supplied Idempotency-Key header that
+// identifies repeated uploads of the same test run. Uploads without the header
+// are never deduplicated, since a single CI build may upload one run per suite
+// or package with the same seed, git sha and build URL.
+func idempotencyKey(c *gin.Context) string {
+	return strings.TrimSpace(c.GetHeader(IdempotencyKeyHeader))
+}
+
+// fingerprintTestRun hashes a decoded test run so that retries of the same
+// payload can be told apart from conflicting ones.
+func fingerprintTestRun(testRun *models.TestRun) string {
+	data, err := json.Marshal(testRun)
+	if err != nil {
+		return ""
+	}
+	return fingerprintBytes(data)
+}
+
+// fingerprintReport hashes an uploaded report together with the query
+// parameters that carry its run metadata. The raw upload is used because
+// conversion may fill in defaults such as the current time.
+func fingerprintReport(c *gin.Context, body []byte) string {
+	return fingerprintBytes(body, []byte(c.Request.URL.RawQuery))
+}
+
+func fingerprintBytes(parts ...[]byte) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// replayIdempotentTestRun answers a request whose idempotency key was already
+// used. It reports false when the key is new and the run should be created.
+func (h *Handler) replayIdempotentTestRun(c *gin.Context, projectID uint64, key string, payloadHash string) bool {
+	var existing models.IdempotencyKey
+	err := h.db.Where("project_id = ? AND idempotency_key = ?", projectID, key).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false
+	} else if err != nil {
+		log.Printf("error looking up idempotency key: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error looking up idempotency key"})
+		return true
+	}
+
+	if existing.PayloadHash != payloadHash {
+		c.JSON(http.StatusConflict, gin.H{"error": "idempotency key was already used with a different payload"})
+		return true
+	}
+
+	var testRun models.TestRun
+	if err := h.db.Preload("SuiteRuns.SpecRuns.Tags").Preload("SuiteRuns.Tags").
+		Where("id = ?", existing.TestRunID).First(&testRun).Error; err != nil {
+		log.Printf("error loading test run %d for idempotency key: %v", existing.TestRunID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading original test run"})
+		return true
+	}
+
+	c.JSON(http.StatusOK, &testRun)
+	return true
+}
+
+// claimIdempotencyKey records the key for a newly saved run. When a concurrent
+// request claimed the key first it returns errIdempotencyKeyTaken so the
+// caller can roll back and replay the winner instead.
+func claimIdempotencyKey(tx *gorm.DB, testRun *models.TestRun, key string, payloadHash string) error {
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.IdempotencyKey{
+		ProjectID:      testRun.ProjectID,
+		IdempotencyKey: key,
+		PayloadHash:    payloadHash,
+		TestRunID:      testRun.ID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errIdempotencyKeyTaken
+	}
+	return nil
+}
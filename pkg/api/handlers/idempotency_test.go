@@ -0,0 +1,103 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Idempotent test run ingestion", func() {
+	var (
+		db     *gorm.DB
+		router *gin.Engine
+	)
+
+	post := func(path string, body string, key string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(handlers.IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	countRuns := func() int64 {
+		var count int64
+		Expect(db.Model(&models.TestRun{}).Count(&count).Error).NotTo(HaveOccurred())
+		return count
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		db = setupTestDB()
+
+		project := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "project-uuid", project.ID).Error).NotTo(HaveOccurred())
+
+		handler := handlers.NewHandler(db)
+		router = gin.New()
+		router.POST("/api/testrun", handler.CreateTestRun)
+		router.POST("/api/testrun/open", handler.OpenTestRun)
+		router.POST("/api/testrun/junit", handler.CreateTestRunFromJUnit)
+	})
+
+	It("replays a retried upload with the same Idempotency-Key", func() {
+		body := `{"test_project_id": "project-uuid", "test_seed": 1, "suite_runs": [{"suite_name": "s", "spec_runs": [{"status": "passed", "tags": [{"name": "smoke"}]}]}]}`
+
+		first := post("/api/testrun", body, "upload-1")
+		Expect(first.Code).To(Equal(http.StatusCreated))
+		second := post("/api/testrun", body, "upload-1")
+		Expect(second.Code).To(Equal(http.StatusOK))
+
+		var created, replayed models.TestRun
+		Expect(json.Unmarshal(first.Body.Bytes(), &created)).To(Succeed())
+		Expect(json.Unmarshal(second.Body.Bytes(), &replayed)).To(Succeed())
+		Expect(replayed.ID).To(Equal(created.ID))
+		Expect(replayed.SuiteRuns).To(HaveLen(1))
+		Expect(replayed.SuiteRuns[0].SpecRuns[0].Tags).To(HaveLen(1))
+		Expect(countRuns()).To(Equal(int64(1)))
+	})
+
+	It("rejects a reused Idempotency-Key with a different payload", func() {
+		Expect(post("/api/testrun", `{"test_project_id": "project-uuid", "test_seed": 1}`, "upload-1").Code).To(Equal(http.StatusCreated))
+		Expect(post("/api/testrun", `{"test_project_id": "project-uuid", "test_seed": 2}`, "upload-1").Code).To(Equal(http.StatusConflict))
+		Expect(countRuns()).To(Equal(int64(1)))
+	})
+
+	It("accepts several uploads from one build without a header", func() {
+		first := `{"test_project_id": "project-uuid", "test_seed": 1, "git_sha": "abc", "build_url": "https://ci/1", "suite_runs": [{"suite_name": "cart"}]}`
+		second := `{"test_project_id": "project-uuid", "test_seed": 1, "git_sha": "abc", "build_url": "https://ci/1", "suite_runs": [{"suite_name": "checkout"}]}`
+		Expect(post("/api/testrun", first, "").Code).To(Equal(http.StatusCreated))
+		Expect(post("/api/testrun", second, "").Code).To(Equal(http.StatusCreated))
+		Expect(countRuns()).To(Equal(int64(2)))
+	})
+
+	It("creates a new run for every upload without any key", func() {
+		body := `{"test_project_id": "project-uuid", "test_seed": 1}`
+		Expect(post("/api/testrun", body, "").Code).To(Equal(http.StatusCreated))
+		Expect(post("/api/testrun", body, "").Code).To(Equal(http.StatusCreated))
+		Expect(countRuns()).To(Equal(int64(2)))
+	})
+
+	It("replays retried opens and report imports", func() {
+		Expect(post("/api/testrun/open", `{"test_project_id": "project-uuid"}`, "open-1").Code).To(Equal(http.StatusCreated))
+		Expect(post("/api/testrun/open", `{"test_project_id": "project-uuid"}`, "open-1").Code).To(Equal(http.StatusOK))
+
+		report := `<testsuite name="s" tests="1"><testcase name="t"/></testsuite>`
+		Expect(post("/api/testrun/junit?project_id=project-uuid", report, "junit-1").Code).To(Equal(http.StatusCreated))
+		Expect(post("/api/testrun/junit?project_id=project-uuid", report, "junit-1").Code).To(Equal(http.StatusOK))
+		Expect(countRuns()).To(Equal(int64(2)))
+	})
+})
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/audit"
+	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// isAsyncIngest reports whether the client asked for the upload to be queued
+// with the async query parameter.
+func isAsyncIngest(c *gin.Context) bool {
+	async, _ := strconv.ParseBool(c.Query("async"))
+	return async
+}
+
+// enqueueIngestJob stores a validated test run as a queued ingest job and
+// responds with 202 and the job so that the client can poll for the result.
+func (h *Handler) enqueueIngestJob(c *gin.Context, testRun *models.TestRun, key string, payloadHash string) {
+	payload, err := json.Marshal(testRun)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job := models.IngestJob{
+		ProjectID:      testRun.ProjectID,
+		Status:         utils.StatusQueued,
+		Payload:        string(payload),
+		PayloadHash:    payloadHash,
+		IdempotencyKey: key,
+	}
+	if err := h.db.Create(&job).Error; err != nil {
+		log.Printf("error queueing ingest job: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error queueing ingest job"})
+		return
+	}
+
+	audit.Target(c, audit.TargetIngestJob, job.ID, testRun.TestProjectID)
+	audit.After(c, &job)
+	c.Header("Location", fmt.Sprintf("/api/ingest/jobs/%d", job.ID))
+	c.JSON(http.StatusAccepted, &job)
+}
+
+// GetIngestJob reports the status of a queued upload and, once it is done, the
+// ID of the test run that was created.
+func (h *Handler) GetIngestJob(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid job id %q", c.Param("id"))})
+		return
+	}
+
+	var job models.IngestJob
+	if err := auth.ScopeProjectRows(c, h.db.Where("id = ?", id), "ingest_jobs.project_id").First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("ingest job %d not found", id)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading ingest job"})
+		return
+	}
+
+	c.JSON(http.StatusOK, &job)
+}
+
+// ProcessNextIngestJob claims the oldest queued job and stores its test run.
+// It reports whether a job was found so that workers can back off when the
+// queue is empty. Jobs are claimed with SKIP LOCKED so that workers in several
+// server instances can share the table.
+func ProcessNextIngestJob(db *gorm.DB) (bool, error) {
+	var job models.IngestJob
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", utils.StatusQueued).
+			Order("id").
+			First(&job).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		job.Status = utils.StatusProcessing
+		job.StartedAt = &now
+		job.Attempts++
+		return tx.Model(&job).Select("status", "started_at", "attempts").Updates(&job).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	testRunID, jobErr := runIngestJob(db, &job)
+
+	now := time.Now()
+	job.FinishedAt = &now
+	job.TestRunID = testRunID
+	job.Status = utils.StatusDone
+	job.Error = ""
+	if jobErr != nil {
+		job.Status = utils.StatusFailed
+		job.Error = jobErr.Error()
+	}
+	return true, db.Model(&job).Select("status", "error", "test_run_id", "finished_at").Updates(&job).Error
+}
+
+func runIngestJob(db *gorm.DB, job *models.IngestJob) (*uint64, error) {
+	var testRun models.TestRun
+	if err := json.Unmarshal([]byte(job.Payload), &testRun); err != nil {
+		return nil, fmt.Errorf("invalid payload: %w", err)
+	}
+	testRun.ProjectID = job.ProjectID
+
+	if err := ProcessTags(db, &testRun); err != nil {
+		return nil, fmt.Errorf("error processing tags: %w", err)
+	}
+	SignFailures(&testRun)
+
+	err := saveTestRunRecord(db, &testRun, job.IdempotencyKey, job.PayloadHash)
+	if errors.Is(err, errIdempotencyKeyTaken) {
+		// A retry of the same upload was stored first; point at its run.
+		var existing models.IdempotencyKey
+		if err := db.Where("project_id = ? AND idempotency_key = ?", job.ProjectID, job.IdempotencyKey).
+			First(&existing).Error; err != nil {
+			return nil, fmt.Errorf("error looking up idempotency key: %w", err)
+		}
+		if existing.PayloadHash != job.PayloadHash {
+			return nil, errors.New("idempotency key was already used with a different payload")
+		}
+		return &existing.TestRunID, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error saving record: %w", err)
+	}
+	recordDurationRegressions(db, &testRun)
+	return &testRun.ID, nil
+}
+
+// RequeueStaleIngestJobs puts jobs that have been processing for longer than
+// timeout back on the queue, for example after a server was stopped mid job.
+func RequeueStaleIngestJobs(db *gorm.DB, timeout time.Duration) (int64, error) {
+	result := db.Model(&models.IngestJob{}).
+		Where("status = ? AND started_at < ?", utils.StatusProcessing, time.Now().Add(-timeout)).
+		Update("status", utils.StatusQueued)
+	return result.RowsAffected, result.Error
+}
+
+func requeueStaleIngestJobs(db *gorm.DB, timeout time.Duration) {
+	if requeued, err := RequeueStaleIngestJobs(db, timeout); err != nil {
+		log.Printf("error requeueing stale ingest jobs: %v", err)
+	} else if requeued > 0 {
+		log.Printf("Requeued %d stale ingest jobs", requeued)
+	}
+}
+
+// StartIngestWorkers starts a pool of workers that process queued ingest jobs
+// until ctx is done. Idle workers poll the queue every pollInterval. Jobs that
+// stayed in processing longer than jobTimeout, because their worker hung or
+// its server stopped, are requeued at start and then every pollInterval.
+func StartIngestWorkers(ctx context.Context, db *gorm.DB, workers int, pollInterval time.Duration, jobTimeout time.Duration) {
+	if workers <= 0 || pollInterval <= 0 {
+		log.Println("Ingest workers are disabled")
+		return
+	}
+
+	if jobTimeout > 0 {
+		requeueStaleIngestJobs(db, jobTimeout)
+		go func() {
+			ticker := time.NewTicker(pollInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					requeueStaleIngestJobs(db, jobTimeout)
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			ticker := time.NewTicker(pollInterval)
+			defer ticker.Stop()
+			for {
+				// Drain the queue before waiting for the next poll
+				for ctx.Err() == nil {
+					found, err := ProcessNextIngestJob(db)
+					if err != nil {
+						log.Printf("error processing ingest job: %v", err)
+					}
+					if !found || err != nil {
+						break
+					}
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+	}
+}
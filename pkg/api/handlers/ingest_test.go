@@ -0,0 +1,191 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Asynchronous ingestion", func() {
+	var (
+		db     *gorm.DB
+		router *gin.Engine
+	)
+
+	post := func(path string, body string, key string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(handlers.IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	getJob := func(id uint64) models.IngestJob {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/api/ingest/jobs/%d", id), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var job models.IngestJob
+		Expect(json.Unmarshal(w.Body.Bytes(), &job)).To(Succeed())
+		return job
+	}
+
+	enqueue := func(body string, key string) models.IngestJob {
+		w := post("/api/testrun?async=true", body, key)
+		Expect(w.Code).To(Equal(http.StatusAccepted))
+		var job models.IngestJob
+		Expect(json.Unmarshal(w.Body.Bytes(), &job)).To(Succeed())
+		Expect(w.Header().Get("Location")).To(Equal(fmt.Sprintf("/api/ingest/jobs/%d", job.ID)))
+		return job
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		db = setupTestDB()
+
+		project := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "project-uuid", project.ID).Error).NotTo(HaveOccurred())
+
+		handler := handlers.NewHandler(db)
+		router = gin.New()
+		router.POST("/api/testrun", handler.CreateTestRun)
+		router.GET("/api/ingest/jobs/:id", handler.GetIngestJob)
+	})
+
+	It("queues the upload and stores it when a worker picks it up", func() {
+		job := enqueue(`{"test_project_id": "project-uuid", "test_seed": 5, "suite_runs": [{"suite_name": "s", "spec_runs": [{"status": "passed", "tags": [{"name": "smoke"}]}]}]}`, "")
+		Expect(job.Status).To(Equal("queued"))
+		Expect(job.TestRunID).To(BeNil())
+
+		var count int64
+		Expect(db.Model(&models.TestRun{}).Count(&count).Error).NotTo(HaveOccurred())
+		Expect(count).To(BeZero())
+
+		found, err := handlers.ProcessNextIngestJob(db)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		job = getJob(job.ID)
+		Expect(job.Status).To(Equal("done"))
+		Expect(job.Attempts).To(Equal(1))
+		Expect(job.FinishedAt).NotTo(BeNil())
+		Expect(job.TestRunID).NotTo(BeNil())
+
+		var stored models.TestRun
+		Expect(db.Preload("SuiteRuns.SpecRuns.Tags").First(&stored, *job.TestRunID).Error).NotTo(HaveOccurred())
+		Expect(stored.TestSeed).To(Equal(uint64(5)))
+		Expect(stored.ProjectID).NotTo(BeZero())
+		Expect(stored.SuiteRuns[0].SpecRuns[0].Tags).To(HaveLen(1))
+
+		found, err = handlers.ProcessNextIngestJob(db)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("validates the project before queueing", func() {
+		Expect(post("/api/testrun?async=true", `{"test_project_id": "unknown"}`, "").Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("marks the job failed with the error", func() {
+		job := models.IngestJob{ProjectID: 1, Status: "queued", Payload: "not json"}
+		Expect(db.Create(&job).Error).NotTo(HaveOccurred())
+
+		_, err := handlers.ProcessNextIngestJob(db)
+		Expect(err).NotTo(HaveOccurred())
+
+		job = getJob(job.ID)
+		Expect(job.Status).To(Equal("failed"))
+		Expect(job.Error).To(ContainSubstring("invalid payload"))
+	})
+
+	It("resolves queued retries to the run of the first upload", func() {
+		body := `{"test_project_id": "project-uuid", "test_seed": 5}`
+		first := enqueue(body, "upload-1")
+		second := enqueue(body, "upload-1")
+
+		for i := 0; i < 2; i++ {
+			_, err := handlers.ProcessNextIngestJob(db)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		first, second = getJob(first.ID), getJob(second.ID)
+		Expect(second.Status).To(Equal("done"))
+		Expect(*second.TestRunID).To(Equal(*first.TestRunID))
+
+		// Once stored, a further retry is answered directly
+		Expect(post("/api/testrun?async=true", body, "upload-1").Code).To(Equal(http.StatusOK))
+	})
+
+	It("leaves no test cases behind when the idempotency key was claimed with another payload", func() {
+		first := enqueue(`{"test_project_id": "project-uuid", "suite_runs": [{"suite_name": "s", "spec_runs": [{"spec_description": "a", "status": "passed"}]}]}`, "upload-1")
+		second := enqueue(`{"test_project_id": "project-uuid", "suite_runs": [{"suite_name": "s", "spec_runs": [{"spec_description": "b", "status": "passed"}]}]}`, "upload-1")
+
+		for i := 0; i < 2; i++ {
+			_, err := handlers.ProcessNextIngestJob(db)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Expect(getJob(first.ID).Status).To(Equal("done"))
+		Expect(getJob(second.ID).Status).To(Equal("failed"))
+
+		var descriptions []string
+		Expect(db.Model(&models.TestCase{}).Pluck("spec_description", &descriptions).Error).NotTo(HaveOccurred())
+		Expect(descriptions).To(Equal([]string{"a"}))
+	})
+
+	It("requeues jobs stuck in processing", func() {
+		startedAt := time.Now().Add(-time.Hour)
+		job := models.IngestJob{ProjectID: 1, Status: "processing", Payload: "{}", StartedAt: &startedAt}
+		Expect(db.Create(&job).Error).NotTo(HaveOccurred())
+
+		requeued, err := handlers.RequeueStaleIngestJobs(db, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requeued).To(Equal(int64(1)))
+		Expect(getJob(job.ID).Status).To(Equal("queued"))
+	})
+
+	It("keeps requeueing jobs that get stuck while the workers run", func() {
+		sqlDB, err := db.DB()
+		Expect(err).NotTo(HaveOccurred())
+		sqlDB.SetMaxOpenConns(1) // every connection to :memory: is a new database
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		handlers.StartIngestWorkers(ctx, db, 1, 10*time.Millisecond, time.Minute)
+
+		// Claimed by a worker that died after the workers had started
+		startedAt := time.Now().Add(-time.Hour)
+		job := models.IngestJob{ProjectID: 1, Status: "processing", Payload: "{}", StartedAt: &startedAt}
+		Expect(db.Create(&job).Error).NotTo(HaveOccurred())
+
+		Eventually(func() string {
+			var stored models.IngestJob
+			Expect(db.First(&stored, job.ID).Error).NotTo(HaveOccurred())
+			return stored.Status
+		}).Should(Equal("done"))
+	})
+
+	It("returns 404 for an unknown job and 400 for an invalid id", func() {
+		for path, code := range map[string]int{"/api/ingest/jobs/999": http.StatusNotFound, "/api/ingest/jobs/abc": http.StatusBadRequest} {
+			req, _ := http.NewRequest("GET", path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(code))
+		}
+	})
+})
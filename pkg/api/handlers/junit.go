@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+// junitTimestampLayouts are the timestamp formats emitted by the common JUnit
+// producers (Surefire, Gradle, pytest, jest-junit).
+var junitTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	XMLName    xml.Name         `xml:"testsuite"`
+	Name       string           `xml:"name,attr"`
+	Timestamp  string           `xml:"timestamp,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties []junitProperty  `xml:"properties>property"`
+	TestCases  []junitTestCase  `xml:"testcase"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestCase struct {
+	Name       string          `xml:"name,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	Failure    *junitResult    `xml:"failure"`
+	Error      *junitResult    `xml:"error"`
+	Skipped    *junitResult    `xml:"skipped"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// ParseJUnitReport converts a JUnit XML document into a TestRun. Both a
+// <testsuites> root and a bare <testsuite> root are accepted; nested suites are
+// flattened. Failures and errors are reported as failed specs, and suite or
+// test case properties become tags.
+func ParseJUnitReport(r io.Reader) (*models.TestRun, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var suites []junitTestSuite
+	var root junitTestSuites
+	if err := xml.Unmarshal(data, &root); err == nil {
+		suites = root.TestSuites
+	} else {
+		var single junitTestSuite
+		if err := xml.Unmarshal(data, &single); err != nil {
+			return nil, fmt.Errorf("invalid JUnit XML: %v", err)
+		}
+		suites = []junitTestSuite{single}
+	}
+
+	testRun := &models.TestRun{}
+	for _, suite := range flattenJUnitSuites(suites) {
+		suiteRun := convertJUnitSuite(suite)
+		if testRun.StartTime.IsZero() || suiteRun.StartTime.Before(testRun.StartTime) {
+			testRun.StartTime = suiteRun.StartTime
+		}
+		if suiteRun.EndTime.After(testRun.EndTime) {
+			testRun.EndTime = suiteRun.EndTime
+		}
+		testRun.SuiteRuns = append(testRun.SuiteRuns, suiteRun)
+	}
+
+	if len(testRun.SuiteRuns) == 0 {
+		return nil, fmt.Errorf("invalid JUnit XML: no test suites found")
+	}
+	return testRun, nil
+}
+
+func flattenJUnitSuites(suites []junitTestSuite) []junitTestSuite {
+	var flattened []junitTestSuite
+	for _, suite := range suites {
+		if len(suite.TestCases) > 0 || len(suite.TestSuites) == 0 {
+			flattened = append(flattened, suite)
+		}
+		flattened = append(flattened, flattenJUnitSuites(suite.TestSuites)...)
+	}
+	return flattened
+}
+
+func convertJUnitSuite(suite junitTestSuite) models.SuiteRun {
+	startTime := parseJUnitTimestamp(suite.Timestamp)
+
+	suiteRun := models.SuiteRun{
+		SuiteName: suite.Name,
+		StartTime: startTime,
+		Tags:      convertJUnitProperties(suite.Properties),
+	}
+
+	// JUnit only records durations, so specs are laid out back to back from
+	// the suite timestamp.
+	specStart := startTime
+	for _, testCase := range suite.TestCases {
+		specEnd := specStart.Add(parseJUnitDuration(testCase.Time))
+		status, message := junitStatus(testCase)
+
+		description := testCase.Name
+		if testCase.ClassName != "" && testCase.ClassName != suite.Name {
+			description = testCase.ClassName + " " + testCase.Name
+		}
+
+		suiteRun.SpecRuns = append(suiteRun.SpecRuns, models.SpecRun{
+			SpecDescription: description,
+			Status:          status,
+			Message:         message,
+			Tags:            convertJUnitProperties(testCase.Properties),
+			StartTime:       specStart,
+			EndTime:         specEnd,
+		})
+		specStart = specEnd
+	}
+
+	suiteRun.EndTime = specStart
+	if suiteDuration := parseJUnitDuration(suite.Time); startTime.Add(suiteDuration).After(suiteRun.EndTime) {
+		suiteRun.EndTime = startTime.Add(suiteDuration)
+	}
+	return suiteRun
+}
+
+func junitStatus(testCase junitTestCase) (string, string) {
+	switch {
+	case testCase.Failure != nil:
+		return utils.StatusFailed, testCase.Failure.text()
+	case testCase.Error != nil:
+		return utils.StatusFailed, testCase.Error.text()
+	case testCase.Skipped != nil:
+		return utils.StatusSkipped, testCase.Skipped.text()
+	default:
+		return utils.StatusPassed, ""
+	}
+}
+
+func (r *junitResult) text() string {
+	parts := make([]string, 0, 2)
+	if message := strings.TrimSpace(r.Message); message != "" {
+		parts = append(parts, message)
+	}
+	if body := strings.TrimSpace(r.Body); body != "" && body != strings.TrimSpace(r.Message) {
+		parts = append(parts, body)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func convertJUnitProperties(properties []junitProperty) []models.Tag {
+	tags := make([]models.Tag, 0, len(properties))
+	for _, property := range properties {
+		name := strings.TrimSpace(property.Name)
+		if name == "" {
+			continue
+		}
+		if value := strings.TrimSpace(property.Value); value != "" {
+			name = name + ":" + value
+		}
+		tags = append(tags, ParseTagName(name))
+	}
+	return tags
+}
+
+func parseJUnitTimestamp(timestamp string) time.Time {
+	for _, layout := range junitTimestampLayouts {
+		if t, err := time.Parse(layout, strings.TrimSpace(timestamp)); err == nil {
+			return t
+		}
+	}
+	return time.Now()
+}
+
+// parseJUnitDuration parses a duration in seconds. Commas are thousands
+// separators when the value also has a decimal point, as in "1,234.5", and the
+// decimal separator otherwise, as in the "1,5" written by some locales.
+func parseJUnitDuration(seconds string) time.Duration {
+	seconds = strings.TrimSpace(seconds)
+	if strings.Contains(seconds, ".") {
+		seconds = strings.ReplaceAll(seconds, ",", "")
+	} else {
+		seconds = strings.ReplaceAll(seconds, ",", ".")
+	}
+	value, err := strconv.ParseFloat(seconds, 64)
+	if err != nil || math.IsNaN(value) || value < 0 {
+		return 0
+	}
+	return time.Duration(value * float64(time.Second))
+}
+
+// CreateTestRunFromJUnit ingests a JUnit XML report for the project given by the
+// project_id query parameter. Optional test_project_name, git_branch, git_sha,
+// build_trigger_actor and build_url query parameters carry the CI metadata that
+// JUnit lacks.
+func (h *Handler) CreateTestRunFromJUnit(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	testRun, err := ParseJUnitReport(bytes.NewReader(body))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	applyRunMetadataFromQuery(c, testRun)
+	h.saveTestRun(c, testRun, fingerprintReport(c, body))
+}
+
+// applyRunMetadataFromQuery fills the run level fields that test report
+// formats do not carry from the request's query parameters.
+func applyRunMetadataFromQuery(c *gin.Context, testRun *models.TestRun) {
+	testRun.TestProjectID = c.Query("project_id")
+	testRun.TestProjectName = c.Query("test_project_name")
+	testRun.GitBranch = c.Query("git_branch")
+	testRun.GitSha = c.Query("git_sha")
+	testRun.BuildTriggerActor = c.Query("build_trigger_actor")
+	testRun.BuildUrl = c.Query("build_url")
+}
@@ -0,0 +1,174 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+const junitReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="com.example.CartTest" timestamp="2025-06-01T12:00:00" time="3.5">
+    <properties>
+      <property name="owner" value="payments"/>
+      <property name="smoke"/>
+    </properties>
+    <testcase name="adds an item" classname="com.example.CartTest" time="1.25"/>
+    <testcase name="removes an item" classname="com.example.CartTest" time="0.75">
+      <failure message="expected 0 but was 1" type="AssertionError">at CartTest.java:42</failure>
+    </testcase>
+    <testcase name="checks out" classname="com.example.CheckoutFlow" time="0.5">
+      <error message="NullPointerException"/>
+    </testcase>
+    <testcase name="applies coupon" classname="com.example.CartTest">
+      <skipped message="not implemented"/>
+    </testcase>
+  </testsuite>
+  <testsuite name="com.example.EmptyTest" timestamp="2025-06-01T12:00:10" time="0"/>
+</testsuites>`
+
+var _ = Describe("ParseJUnitReport", func() {
+	It("maps suites, test cases and results", func() {
+		testRun, err := handlers.ParseJUnitReport(strings.NewReader(junitReport))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testRun.SuiteRuns).To(HaveLen(2))
+
+		suite := testRun.SuiteRuns[0]
+		Expect(suite.SuiteName).To(Equal("com.example.CartTest"))
+		Expect(suite.StartTime).To(Equal(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)))
+		Expect(suite.EndTime).To(Equal(suite.StartTime.Add(3500 * time.Millisecond)))
+		Expect(suite.Tags).To(ConsistOf(
+			models.Tag{Name: "owner:payments", Category: "owner", Value: "payments"},
+			models.Tag{Name: "smoke", Value: "smoke"},
+		))
+
+		Expect(suite.SpecRuns).To(HaveLen(4))
+		Expect(suite.SpecRuns[0].SpecDescription).To(Equal("adds an item"))
+		Expect(suite.SpecRuns[0].Status).To(Equal("passed"))
+		Expect(suite.SpecRuns[0].EndTime.Sub(suite.SpecRuns[0].StartTime)).To(Equal(1250 * time.Millisecond))
+
+		Expect(suite.SpecRuns[1].Status).To(Equal("failed"))
+		Expect(suite.SpecRuns[1].Message).To(Equal("expected 0 but was 1\nat CartTest.java:42"))
+		Expect(suite.SpecRuns[1].StartTime).To(Equal(suite.SpecRuns[0].EndTime))
+
+		Expect(suite.SpecRuns[2].SpecDescription).To(Equal("com.example.CheckoutFlow checks out"))
+		Expect(suite.SpecRuns[2].Status).To(Equal("failed"))
+		Expect(suite.SpecRuns[2].Message).To(Equal("NullPointerException"))
+
+		Expect(suite.SpecRuns[3].Status).To(Equal("skipped"))
+		Expect(suite.SpecRuns[3].Message).To(Equal("not implemented"))
+
+		Expect(testRun.StartTime).To(Equal(suite.StartTime))
+		Expect(testRun.EndTime).To(Equal(testRun.SuiteRuns[1].EndTime))
+	})
+
+	It("accepts a single testsuite root element", func() {
+		report := `<testsuite name="tests" timestamp="2025-06-01T12:00:00+02:00"><testcase classname="tests.test_api" name="test_get" time="0.1"/></testsuite>`
+
+		testRun, err := handlers.ParseJUnitReport(strings.NewReader(report))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testRun.SuiteRuns).To(HaveLen(1))
+		Expect(testRun.SuiteRuns[0].SpecRuns[0].SpecDescription).To(Equal("tests.test_api test_get"))
+	})
+
+	It("reads durations with thousands or decimal commas", func() {
+		report := `<testsuite name="tests" timestamp="2025-06-01T12:00:00Z"><testcase name="a" time="1,5"/><testcase name="b" time="1,234.5"/></testsuite>`
+
+		testRun, err := handlers.ParseJUnitReport(strings.NewReader(report))
+		Expect(err).NotTo(HaveOccurred())
+		specs := testRun.SuiteRuns[0].SpecRuns
+		Expect(specs[0].EndTime.Sub(specs[0].StartTime)).To(Equal(1500 * time.Millisecond))
+		Expect(specs[1].EndTime.Sub(specs[1].StartTime)).To(Equal(1234500 * time.Millisecond))
+	})
+
+	It("flattens nested test suites", func() {
+		report := `<testsuites><testsuite name="outer"><testsuite name="inner"><testcase name="a"/></testsuite></testsuite></testsuites>`
+
+		testRun, err := handlers.ParseJUnitReport(strings.NewReader(report))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testRun.SuiteRuns).To(HaveLen(1))
+		Expect(testRun.SuiteRuns[0].SuiteName).To(Equal("inner"))
+	})
+
+	It("returns an error for invalid XML", func() {
+		_, err := handlers.ParseJUnitReport(strings.NewReader(`{"not": "xml"}`))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("CreateTestRunFromJUnit", func() {
+	var (
+		db     *gorm.DB
+		router *gin.Engine
+	)
+
+	const projectUUID = "996ad860-2a9a-504f-8861-aeafd0b2ae29"
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		db = setupTestDB()
+
+		project := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", projectUUID, project.ID).Error).NotTo(HaveOccurred())
+
+		router = gin.New()
+		router.POST("/api/testrun/junit", handlers.NewHandler(db).CreateTestRunFromJUnit)
+	})
+
+	It("stores the converted test run with its tags", func() {
+		req, _ := http.NewRequest("POST", "/api/testrun/junit?project_id="+projectUUID+"&git_branch=main&git_sha=abc123", bytes.NewBufferString(junitReport))
+		req.Header.Set("Content-Type", "application/xml")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		Expect(w.Code).To(Equal(http.StatusCreated))
+
+		var created models.TestRun
+		Expect(json.Unmarshal(w.Body.Bytes(), &created)).To(Succeed())
+		Expect(created.ID).NotTo(BeZero())
+		Expect(created.GitBranch).To(Equal("main"))
+		Expect(created.GitSha).To(Equal("abc123"))
+
+		var specCount, tagCount int64
+		db.Model(&models.SpecRun{}).Count(&specCount)
+		db.Model(&models.Tag{}).Count(&tagCount)
+		Expect(specCount).To(Equal(int64(4)))
+		Expect(tagCount).To(Equal(int64(2)))
+	})
+
+	It("returns 400 for a malformed report", func() {
+		req, _ := http.NewRequest("POST", "/api/testrun/junit?project_id="+projectUUID, bytes.NewBufferString("<testsuites>"))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("returns 400 when the project id is missing", func() {
+		req, _ := http.NewRequest("POST", "/api/testrun/junit", bytes.NewBufferString(junitReport))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("returns 404 for an unknown project", func() {
+		req, _ := http.NewRequest("POST", "/api/testrun/junit?project_id=unknown", bytes.NewBufferString(junitReport))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+	})
+})
@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/audit"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errTestRunNotOpen = errors.New("test run is not in progress")
+
+type AppendSuiteRunsRequest struct {
+	SuiteRuns []models.SuiteRun `json:"suite_runs"`
+}
+
+// OpenTestRun creates an in progress test run that workers can append suite
+// runs to until it is closed.
+func (h *Handler) OpenTestRun(c *gin.Context) {
+	var testRun models.TestRun
+
+	if err := c.ShouldBindJSON(&testRun); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Hash before defaults are applied so that retries match
+	payloadHash := fingerprintTestRun(&testRun)
+
+	testRun.ID = 0
+	testRun.Status = utils.StatusInProgress
+	testRun.EndTime = time.Time{}
+	if testRun.StartTime.IsZero() {
+		testRun.StartTime = time.Now()
+	}
+
+	h.saveTestRun(c, &testRun, payloadHash)
+}
+
+// AppendSuiteRuns adds suite runs to an in progress test run. Suites are
+// appended under a shared lock so that many workers can report concurrently
+// while a close waits for them to finish.
+func (h *Handler) AppendSuiteRuns(c *gin.Context) {
+	testRunID, err := parseTestRunID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var request AppendSuiteRunsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(request.SuiteRuns) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one suite run is required"})
+		return
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		testRun, err := findOpenTestRun(c, tx, testRunID, "SHARE")
+		if err != nil {
+			return err
+		}
+
+		pending := models.TestRun{ProjectID: testRun.ProjectID, StartTime: testRun.StartTime, SuiteRuns: request.SuiteRuns}
+		if err := ProcessTags(tx, &pending); err != nil {
+			return fmt.Errorf("error processing tags: %w", err)
+		}
+		if err := LinkTestCases(tx, &pending); err != nil {
+			return fmt.Errorf("error linking test cases: %w", err)
+		}
+		SignFailures(&pending)
+		for i := range pending.SuiteRuns {
+			pending.SuiteRuns[i].ID = 0
+			pending.SuiteRuns[i].TestRunID = testRunID
+			for j := range pending.SuiteRuns[i].SpecRuns {
+				pending.SuiteRuns[i].SpecRuns[j].ID = 0
+			}
+		}
+		request.SuiteRuns = pending.SuiteRuns
+		if audit.Recording(c) {
+			audit.Target(c, audit.TargetTestRun, testRunID, projectUUID(tx, testRun.ProjectID))
+		}
+		return tx.Create(&request.SuiteRuns).Error
+	})
+	if err != nil {
+		respondLifecycleError(c, testRunID, err)
+		return
+	}
+
+	audit.After(c, gin.H{"appended_suite_runs": len(request.SuiteRuns)})
+
+	c.JSON(http.StatusCreated, request.SuiteRuns)
+}
+
+// CloseTestRun finalizes an in progress test run. The end time is taken from
+// the latest suite run and the status is failed if any spec failed.
+func (h *Handler) CloseTestRun(c *gin.Context) {
+	testRunID, err := parseTestRunID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var testRun models.TestRun
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		run, err := findOpenTestRun(c, tx, testRunID, "UPDATE")
+		if err != nil {
+			return err
+		}
+		audit.Before(c, run)
+
+		var suiteRuns []models.SuiteRun
+		if err := tx.Select("end_time").
+			Where("test_run_id = ?", testRunID).
+			Find(&suiteRuns).Error; err != nil {
+			return err
+		}
+
+		var failedSpecs int64
+		if err := tx.Model(&models.SpecRun{}).
+			Joins("JOIN suite_runs ON suite_runs.id = spec_runs.suite_id").
+			Where("suite_runs.test_run_id = ? AND spec_runs.status IN ?", testRunID, utils.FailedStatuses).
+			Count(&failedSpecs).Error; err != nil {
+			return err
+		}
+
+		run.EndTime = time.Time{}
+		for _, suiteRun := range suiteRuns {
+			if suiteRun.EndTime.After(run.EndTime) {
+				run.EndTime = suiteRun.EndTime
+			}
+		}
+		if run.EndTime.IsZero() {
+			run.EndTime = time.Now()
+		}
+		run.Status = utils.StatusPassed
+		if failedSpecs > 0 {
+			run.Status = utils.StatusFailed
+		}
+
+		testRun = *run
+		if audit.Recording(c) {
+			audit.Target(c, audit.TargetTestRun, testRunID, projectUUID(tx, run.ProjectID))
+		}
+		return tx.Model(run).Select("end_time", "status").Updates(run).Error
+	})
+	if err != nil {
+		respondLifecycleError(c, testRunID, err)
+		return
+	}
+	audit.After(c, &testRun)
+
+	var closed models.TestRun
+	if err := h.db.Preload("SuiteRuns.SpecRuns").First(&closed, testRunID).Error; err != nil {
+		log.Printf("error loading test run %d for duration regressions: %v", testRunID, err)
+	} else {
+		recordDurationRegressions(h.db, &closed)
+	}
+
+	c.JSON(http.StatusOK, &testRun)
+}
+
+func parseTestRunID(c *gin.Context) (uint64, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid test run id %q", c.Param("id"))
+	}
+	return id, nil
+}
+
+func findOpenTestRun(ctx context.Context, tx *gorm.DB, testRunID uint64, lock string) (*models.TestRun, error) {
+	var testRun models.TestRun
+	query := tx.Clauses(clause.Locking{Strength: lock}).Where("id = ?", testRunID)
+	if err := scopeTestRuns(ctx, query).First(&testRun).Error; err != nil {
+		return nil, err
+	}
+	if testRun.Status != utils.StatusInProgress {
+		return nil, errTestRunNotOpen
+	}
+	return &testRun, nil
+}
+
+func respondLifecycleError(c *gin.Context, testRunID uint64, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("test run %d not found", testRunID)})
+	case errors.Is(err, errTestRunNotOpen):
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("test run %d is not in progress", testRunID)})
+	default:
+		log.Printf("error updating test run %d: %v", testRunID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error updating test run"})
+	}
+}
+
+// ReapAbandonedTestRuns marks in progress test runs that were opened more than
+// timeout ago as abandoned and returns how many were marked. Runs are aged by
+// the time the server stored them rather than the start time the client
+// reported, which may come from a skewed clock.
+func ReapAbandonedTestRuns(db *gorm.DB, timeout time.Duration) (int64, error) {
+	now := time.Now()
+	result := db.Model(&models.TestRun{}).
+		Where("status = ? AND created_at < ?", utils.StatusInProgress, now.Add(-timeout)).
+		Updates(map[string]interface{}{"status": utils.StatusAbandoned, "end_time": now})
+	return result.RowsAffected, result.Error
+}
+
+// StartTestRunReaper periodically reaps abandoned test runs until ctx is done.
+func StartTestRunReaper(ctx context.Context, db *gorm.DB, timeout time.Duration, interval time.Duration) {
+	if timeout <= 0 || interval <= 0 {
+		log.Println("Test run reaper is disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				reaped, err := ReapAbandonedTestRuns(db, timeout)
+				if err != nil {
+					log.Printf("error reaping abandoned test runs: %v", err)
+				} else if reaped > 0 {
+					log.Printf("Marked %d test runs as abandoned", reaped)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,135 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Test run lifecycle", func() {
+	var (
+		db     *gorm.DB
+		router *gin.Engine
+	)
+
+	post := func(path string, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	openRun := func() models.TestRun {
+		w := post("/api/testrun/open", `{"test_project_id": "project-uuid", "git_branch": "main"}`)
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		var testRun models.TestRun
+		Expect(json.Unmarshal(w.Body.Bytes(), &testRun)).To(Succeed())
+		return testRun
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		db = setupTestDB()
+
+		project := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "project-uuid", project.ID).Error).NotTo(HaveOccurred())
+
+		handler := handlers.NewHandler(db)
+		router = gin.New()
+		router.POST("/api/testrun/open", handler.OpenTestRun)
+		router.POST("/api/testrun/:id/suites", handler.AppendSuiteRuns)
+		router.POST("/api/testrun/:id/close", handler.CloseTestRun)
+	})
+
+	It("opens a run without an end time", func() {
+		testRun := openRun()
+		Expect(testRun.ID).NotTo(BeZero())
+		Expect(testRun.Status).To(Equal("in_progress"))
+		Expect(testRun.StartTime).NotTo(BeZero())
+		Expect(testRun.EndTime).To(BeZero())
+	})
+
+	It("appends suites from several workers and closes with an aggregate status", func() {
+		testRun := openRun()
+		suiteEnd := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+
+		w := post(fmt.Sprintf("/api/testrun/%d/suites", testRun.ID), `{"suite_runs": [{"suite_name": "worker 1", "end_time": "2025-06-01T12:10:00Z", "spec_runs": [{"spec_description": "a", "status": "passed", "tags": [{"name": "smoke"}]}]}]}`)
+		Expect(w.Code).To(Equal(http.StatusCreated))
+
+		w = post(fmt.Sprintf("/api/testrun/%d/suites", testRun.ID), fmt.Sprintf(`{"suite_runs": [{"suite_name": "worker 2", "end_time": "%s", "spec_runs": [{"spec_description": "b", "status": "failed"}]}]}`, suiteEnd.Format(time.RFC3339)))
+		Expect(w.Code).To(Equal(http.StatusCreated))
+
+		w = post(fmt.Sprintf("/api/testrun/%d/close", testRun.ID), "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		var stored models.TestRun
+		Expect(db.Preload("SuiteRuns.SpecRuns.Tags").First(&stored, testRun.ID).Error).NotTo(HaveOccurred())
+		Expect(stored.Status).To(Equal("failed"))
+		Expect(stored.EndTime.Equal(suiteEnd)).To(BeTrue())
+		Expect(stored.SuiteRuns).To(HaveLen(2))
+		Expect(stored.SuiteRuns[0].SpecRuns[0].Tags).To(HaveLen(1))
+	})
+
+	It("marks a run with only passing specs as passed", func() {
+		testRun := openRun()
+		Expect(post(fmt.Sprintf("/api/testrun/%d/suites", testRun.ID), `{"suite_runs": [{"suite_name": "s", "spec_runs": [{"status": "passed"}, {"status": "skipped"}]}]}`).Code).To(Equal(http.StatusCreated))
+
+		w := post(fmt.Sprintf("/api/testrun/%d/close", testRun.ID), "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		var closed models.TestRun
+		Expect(json.Unmarshal(w.Body.Bytes(), &closed)).To(Succeed())
+		Expect(closed.Status).To(Equal("passed"))
+	})
+
+	It("rejects appends and closes once the run is closed", func() {
+		testRun := openRun()
+		Expect(post(fmt.Sprintf("/api/testrun/%d/close", testRun.ID), "").Code).To(Equal(http.StatusOK))
+
+		Expect(post(fmt.Sprintf("/api/testrun/%d/suites", testRun.ID), `{"suite_runs": [{"suite_name": "late"}]}`).Code).To(Equal(http.StatusConflict))
+		Expect(post(fmt.Sprintf("/api/testrun/%d/close", testRun.ID), "").Code).To(Equal(http.StatusConflict))
+	})
+
+	It("returns 404 for an unknown run", func() {
+		Expect(post("/api/testrun/999/suites", `{"suite_runs": [{"suite_name": "s"}]}`).Code).To(Equal(http.StatusNotFound))
+		Expect(post("/api/testrun/999/close", "").Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("returns 400 for an invalid id or empty payload", func() {
+		Expect(post("/api/testrun/abc/close", "").Code).To(Equal(http.StatusBadRequest))
+		Expect(post("/api/testrun/1/suites", `{"suite_runs": []}`).Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("reaps runs that stayed open past the timeout", func() {
+		stale := models.TestRun{Status: "in_progress", StartTime: time.Now()}
+		// Reported by a runner whose clock is hours behind
+		fresh := models.TestRun{Status: "in_progress", StartTime: time.Now().Add(-2 * time.Hour)}
+		closed := models.TestRun{Status: "passed", StartTime: time.Now().Add(-2 * time.Hour)}
+		Expect(db.Create(&[]*models.TestRun{&stale, &fresh, &closed}).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE test_runs SET created_at = ?", time.Now()).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE test_runs SET created_at = ? WHERE id IN ?", time.Now().Add(-2*time.Hour), []uint64{stale.ID, closed.ID}).Error).NotTo(HaveOccurred())
+
+		reaped, err := handlers.ReapAbandonedTestRuns(db, time.Hour)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reaped).To(Equal(int64(1)))
+
+		Expect(db.First(&stale, stale.ID).Error).NotTo(HaveOccurred())
+		Expect(stale.Status).To(Equal("abandoned"))
+		Expect(stale.EndTime).NotTo(BeZero())
+		Expect(db.First(&fresh, fresh.ID).Error).NotTo(HaveOccurred())
+		Expect(fresh.Status).To(Equal("in_progress"))
+	})
+})
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultOrderDependencyDays  = 30
+	defaultOrderDependencyLimit = 50
+)
+
+// OrderDependencyOptions selects the runs searched for order dependent specs.
+type OrderDependencyOptions struct {
+	// Since limits the analysis to runs started after it.
+	Since time.Time
+	// Limit caps the number of specs returned.
+	Limit int
+}
+
+type seedOutcome struct {
+	TestSeed        uint64
+	RunStartTime    time.Time
+	GitSha          string
+	SuiteName       string
+	SpecDescription string
+	Status          string
+	Message         string
+}
+
+// FindOrderDependentSpecs lists the specs of a project that, at the same git
+// sha, always fail under some randomization seeds and always pass under
+// others. Since the code is the same, the failures depend on the order the
+// specs ran in, which usually means another spec leaks state. Seeds under
+// which a spec both passed and failed are reported as mixed; they point to
+// plain flakiness rather than ordering. Runs without a seed are ignored.
+// Specs are ordered by the last time they ran, most recent first.
+func FindOrderDependentSpecs(db *gorm.DB, projectID uint64, options OrderDependencyOptions) ([]models.OrderDependentSpec, error) {
+	var outcomes []seedOutcome
+	if err := db.Table("spec_runs").
+		Select("test_runs.test_seed, test_runs.start_time AS run_start_time, test_runs.git_sha, "+
+			"suite_runs.suite_name, spec_runs.spec_description, spec_runs.status, spec_runs.message").
+		Joins("JOIN suite_runs ON suite_runs.id = spec_runs.suite_id").
+		Joins("JOIN test_runs ON test_runs.id = suite_runs.test_run_id").
+		Where("test_runs.project_id = ? AND test_runs.start_time >= ?", projectID, options.Since).
+		Where("test_runs.test_seed <> 0 AND test_runs.git_sha <> ''").
+		Where("spec_runs.status = ? OR spec_runs.status IN ?", utils.StatusPassed, utils.FailedStatuses).
+		Order("test_runs.start_time, test_runs.id").
+		Scan(&outcomes).Error; err != nil {
+		return nil, err
+	}
+
+	type groupKey struct {
+		spec   specIdentity
+		gitSha string
+	}
+	type seedResults struct {
+		passed int
+		failed int
+	}
+	groups := map[groupKey]map[uint64]*seedResults{}
+	specs := map[groupKey]*models.OrderDependentSpec{}
+	var order []groupKey
+	for _, outcome := range outcomes {
+		key := groupKey{specIdentity{outcome.SuiteName, outcome.SpecDescription}, outcome.GitSha}
+		spec, ok := specs[key]
+		if !ok {
+			spec = &models.OrderDependentSpec{
+				SuiteName:       outcome.SuiteName,
+				SpecDescription: outcome.SpecDescription,
+				GitSha:          outcome.GitSha,
+				FailingSeeds:    []uint64{},
+				PassingSeeds:    []uint64{},
+				MixedSeeds:      []uint64{},
+			}
+			specs[key] = spec
+			groups[key] = map[uint64]*seedResults{}
+			order = append(order, key)
+		}
+		spec.LastSeen = outcome.RunStartTime
+
+		results, ok := groups[key][outcome.TestSeed]
+		if !ok {
+			results = &seedResults{}
+			groups[key][outcome.TestSeed] = results
+		}
+		if outcome.Status == utils.StatusPassed {
+			results.passed++
+		} else {
+			results.failed++
+			spec.LastFailureMessage = outcome.Message
+		}
+	}
+
+	dependent := []models.OrderDependentSpec{}
+	for _, key := range order {
+		spec := specs[key]
+		for seed, results := range groups[key] {
+			switch {
+			case results.failed == 0:
+				spec.PassingSeeds = append(spec.PassingSeeds, seed)
+			case results.passed == 0:
+				spec.FailingSeeds = append(spec.FailingSeeds, seed)
+			default:
+				spec.MixedSeeds = append(spec.MixedSeeds, seed)
+			}
+		}
+		if len(spec.FailingSeeds) == 0 || len(spec.PassingSeeds) == 0 {
+			continue
+		}
+		slices.Sort(spec.FailingSeeds)
+		slices.Sort(spec.PassingSeeds)
+		slices.Sort(spec.MixedSeeds)
+		dependent = append(dependent, *spec)
+	}
+
+	sort.SliceStable(dependent, func(i, j int) bool {
+		return dependent[i].LastSeen.After(dependent[j].LastSeen)
+	})
+	if options.Limit > 0 && len(dependent) > options.Limit {
+		dependent = dependent[:options.Limit]
+	}
+	return dependent, nil
+}
+
+// GetOrderDependentSpecs returns the specs of the project with the given UUID
+// whose outcome at a git sha depends on the randomization seed. Optional query
+// parameters: days (how far back to look, default 30) and limit.
+func (h *Handler) GetOrderDependentSpecs(c *gin.Context) {
+	projectUUID := c.Param("projectUUID")
+
+	days, err := QueryPositiveInt(c, "days", defaultOrderDependencyDays)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	limit, err := QueryPositiveInt(c, "limit", defaultOrderDependencyLimit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	options := OrderDependencyOptions{Since: time.Now().AddDate(0, 0, -days), Limit: limit}
+
+	projectID, err := getProjectIDByUUID(c, h.db, projectUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Project ID %s not found", projectUUID)})
+		return
+	}
+
+	specs, err := FindOrderDependentSpecs(h.db, projectID, options)
+	if err != nil {
+		log.Printf("error finding order dependent specs for project %s: %v", projectUUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error finding order dependent specs"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id": projectUUID,
+		"since":      options.Since,
+		"specs":      specs,
+	})
+}
@@ -0,0 +1,130 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Order dependent specs", func() {
+	var (
+		db        *gorm.DB
+		projectID uint64
+		runStart  time.Time
+	)
+
+	// addRun stores a run of the Cart suite at sha with the given seed. Spec
+	// names map to their status.
+	addRun := func(sha string, seed uint64, specs map[string]string) {
+		runStart = runStart.Add(time.Hour)
+		suite := models.SuiteRun{SuiteName: "Cart"}
+		for description, status := range specs {
+			suite.SpecRuns = append(suite.SpecRuns, models.SpecRun{SpecDescription: description, Status: status, Message: status + " with seed"})
+		}
+		testRun := models.TestRun{
+			ProjectID: projectID,
+			TestSeed:  seed,
+			GitSha:    sha,
+			StartTime: runStart,
+			SuiteRuns: []models.SuiteRun{suite},
+		}
+		Expect(db.Create(&testRun).Error).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		db = setupTestDB()
+		project := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "project-uuid", project.ID).Error).NotTo(HaveOccurred())
+		projectID = project.ID
+		runStart = time.Now().Add(-7 * 24 * time.Hour)
+	})
+
+	It("lists specs that fail under some seeds and pass under others at the same sha", func() {
+		addRun("sha1", 11, map[string]string{"checks out": "failed", "adds items": "passed", "totals": "passed"})
+		addRun("sha1", 22, map[string]string{"checks out": "passed", "adds items": "passed", "totals": "failed"})
+		addRun("sha1", 11, map[string]string{"checks out": "timedout", "adds items": "passed", "totals": "passed"})
+		addRun("sha1", 33, map[string]string{"checks out": "passed", "adds items": "skipped", "totals": "failed"})
+		addRun("sha1", 44, map[string]string{"checks out": "failed"})
+		addRun("sha1", 44, map[string]string{"checks out": "passed"})
+		// A spec that fails at a different sha is a regression, not ordering
+		addRun("sha2", 55, map[string]string{"adds items": "failed"})
+		// Runs without a seed are ignored
+		addRun("sha1", 0, map[string]string{"adds items": "failed"})
+
+		specs, err := handlers.FindOrderDependentSpecs(db, projectID, handlers.OrderDependencyOptions{Since: runStart.AddDate(0, 0, -1)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(specs).To(HaveLen(2))
+
+		Expect(specs[0].SpecDescription).To(Equal("checks out"))
+		Expect(specs[0].GitSha).To(Equal("sha1"))
+		Expect(specs[0].FailingSeeds).To(Equal([]uint64{11}))
+		Expect(specs[0].PassingSeeds).To(Equal([]uint64{22, 33}))
+		Expect(specs[0].MixedSeeds).To(Equal([]uint64{44}))
+		Expect(specs[0].LastFailureMessage).To(Equal("failed with seed"))
+
+		Expect(specs[1].SpecDescription).To(Equal("totals"))
+		Expect(specs[1].FailingSeeds).To(Equal([]uint64{22, 33}))
+		Expect(specs[1].PassingSeeds).To(Equal([]uint64{11}))
+		Expect(specs[1].MixedSeeds).To(BeEmpty())
+	})
+
+	Describe("GET /api/reports/order-dependent/:projectUUID", func() {
+		var router *gin.Engine
+
+		get := func(path string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("GET", path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		BeforeEach(func() {
+			gin.SetMode(gin.TestMode)
+			router = gin.New()
+			router.GET("/api/reports/order-dependent/:projectUUID", handlers.NewHandler(db).GetOrderDependentSpecs)
+		})
+
+		It("returns the order dependent specs of the project", func() {
+			addRun("sha1", 11, map[string]string{"checks out": "failed"})
+			addRun("sha1", 22, map[string]string{"checks out": "passed"})
+
+			w := get("/api/reports/order-dependent/project-uuid?days=30")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var response struct {
+				Specs []models.OrderDependentSpec `json:"specs"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Specs).To(HaveLen(1))
+			Expect(response.Specs[0].FailingSeeds).To(Equal([]uint64{11}))
+			Expect(response.Specs[0].PassingSeeds).To(Equal([]uint64{22}))
+		})
+
+		It("returns an empty list when no spec depends on the seed", func() {
+			addRun("sha1", 11, map[string]string{"checks out": "passed"})
+
+			w := get("/api/reports/order-dependent/project-uuid")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var response struct {
+				Specs []models.OrderDependentSpec `json:"specs"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Specs).NotTo(BeNil())
+			Expect(response.Specs).To(BeEmpty())
+		})
+
+		It("rejects invalid parameters and unknown projects", func() {
+			Expect(get("/api/reports/order-dependent/project-uuid?days=0").Code).To(Equal(http.StatusBadRequest))
+			Expect(get("/api/reports/order-dependent/unknown").Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
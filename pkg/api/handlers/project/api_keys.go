@@ -0,0 +1,160 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/audit"
+	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"gorm.io/gorm"
+)
+
+type createAPIKeyRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// findProject loads the project with the UUID in the path, writing an error
+// response and returning false when it cannot.
+func (h *ProjectHandler) findProject(c *gin.Context) (models.ProjectDetails, bool) {
+	uuid := c.Param("uuid")
+
+	var project models.ProjectDetails
+	if err := auth.ScopeProjects(c, h.db.Where("uuid = ?", uuid)).First(&project).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Project ID %s not found", uuid)})
+			return project, false
+		}
+		log.Printf("Error fetching project %s: %s", uuid, err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching project"})
+		return project, false
+	}
+	return project, true
+}
+
+// rejectAPIKeyCaller stops requests authenticated with an API key, so that a
+// leaked key cannot be used to mint or revoke keys.
+func rejectAPIKeyCaller(c *gin.Context) bool {
+	if _, ok := c.Get("apiKeyID"); ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "API keys cannot manage API keys"})
+		return true
+	}
+	return false
+}
+
+// CreateAPIKey creates an API key that can upload test runs to the project.
+// The key itself is only returned by this call; just its hash is stored.
+func (h *ProjectHandler) CreateAPIKey(c *gin.Context) {
+	if rejectAPIKeyCaller(c) {
+		return
+	}
+
+	var request createAPIKeyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, ok := h.findProject(c)
+	if !ok {
+		return
+	}
+
+	key, err := auth.GenerateAPIKey()
+	if err != nil {
+		log.Printf("Failed to generate API key: %s", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create API key"})
+		return
+	}
+	apiKey := models.ProjectAPIKey{
+		ProjectID: project.ID,
+		Name:      strings.TrimSpace(request.Name),
+		Prefix:    key[:auth.APIKeyPrefixLength],
+		KeyHash:   auth.HashAPIKey(key),
+	}
+	if err := h.db.Create(&apiKey).Error; err != nil {
+		log.Printf("Failed to create API key: %s", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create API key"})
+		return
+	}
+
+	log.Printf("Created API key %d for project %s", apiKey.ID, project.UUID)
+	audit.Target(c, audit.TargetAPIKey, apiKey.ID, project.UUID)
+	audit.After(c, apiKey)
+	c.JSON(http.StatusCreated, struct {
+		models.ProjectAPIKey
+		Key string `json:"key"`
+	}{apiKey, key})
+}
+
+// GetAPIKeys lists the API keys of the project, revoked ones included.
+func (h *ProjectHandler) GetAPIKeys(c *gin.Context) {
+	project, ok := h.findProject(c)
+	if !ok {
+		return
+	}
+
+	keys := []models.ProjectAPIKey{}
+	if err := h.db.Where("project_id = ?", project.ID).Order("created_at ASC, id ASC").Find(&keys).Error; err != nil {
+		log.Printf("Error fetching API keys for project %s: %s", project.UUID, err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching API keys"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id": project.UUID,
+		"keys":       keys,
+	})
+}
+
+// RevokeAPIKey revokes an API key of the project. Revoked keys are kept so
+// that they still show up when listed.
+func (h *ProjectHandler) RevokeAPIKey(c *gin.Context) {
+	if rejectAPIKeyCaller(c) {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid API key ID"})
+		return
+	}
+
+	project, ok := h.findProject(c)
+	if !ok {
+		return
+	}
+
+	var apiKey models.ProjectAPIKey
+	if err := h.db.Where("id = ? AND project_id = ?", id, project.ID).First(&apiKey).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("API key %d not found", id)})
+			return
+		}
+		log.Printf("Error fetching API key %d: %s", id, err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching API key"})
+		return
+	}
+
+	audit.Target(c, audit.TargetAPIKey, apiKey.ID, project.UUID)
+	audit.Before(c, apiKey)
+	if apiKey.RevokedAt == nil {
+		now := time.Now()
+		if err := h.db.Model(&apiKey).Update("revoked_at", now).Error; err != nil {
+			log.Printf("Failed to revoke API key %d: %s", id, err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke API key"})
+			return
+		}
+		apiKey.RevokedAt = &now
+		log.Printf("Revoked API key %d of project %s", id, project.UUID)
+	}
+	audit.After(c, apiKey)
+
+	c.JSON(http.StatusOK, apiKey)
+}
@@ -0,0 +1,137 @@
+package project_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers/project"
+	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Project API keys", func() {
+	var (
+		keyDb    *gorm.DB
+		router   *gin.Engine
+		apiKeyID any
+	)
+
+	request := func(method string, path string, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	type createdKey struct {
+		models.ProjectAPIKey
+		Key string `json:"key"`
+	}
+
+	createKey := func(name string) createdKey {
+		w := request("POST", "/api/project/project-uuid/keys", fmt.Sprintf(`{"name": %q}`, name))
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		var created createdKey
+		Expect(json.Unmarshal(w.Body.Bytes(), &created)).To(Succeed())
+		return created
+	}
+
+	BeforeEach(func() {
+		var err error
+		keyDb, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keyDb.AutoMigrate(&models.ProjectDetails{}, &models.ProjectAPIKey{})).To(Succeed())
+
+		cart := models.ProjectDetails{Name: "Cart"}
+		Expect(keyDb.Create(&cart).Error).NotTo(HaveOccurred())
+		Expect(keyDb.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "project-uuid", cart.ID).Error).NotTo(HaveOccurred())
+		search := models.ProjectDetails{Name: "Search"}
+		Expect(keyDb.Create(&search).Error).NotTo(HaveOccurred())
+		Expect(keyDb.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "other-uuid", search.ID).Error).NotTo(HaveOccurred())
+
+		apiKeyID = nil
+		gin.SetMode(gin.TestMode)
+		router = gin.New()
+		router.Use(func(c *gin.Context) {
+			if apiKeyID != nil {
+				c.Set("apiKeyID", apiKeyID)
+			}
+		})
+		handler := project.NewProjectHandler(keyDb)
+		router.GET("/api/project/:uuid/keys", handler.GetAPIKeys)
+		router.POST("/api/project/:uuid/keys", handler.CreateAPIKey)
+		router.DELETE("/api/project/:uuid/keys/:id", handler.RevokeAPIKey)
+	})
+
+	It("creates keys that are only returned once and stored hashed", func() {
+		created := createKey(" ci ")
+		Expect(created.Name).To(Equal("ci"))
+		Expect(created.Key).To(HavePrefix(created.Prefix))
+
+		var stored models.ProjectAPIKey
+		Expect(keyDb.First(&stored, created.ID).Error).NotTo(HaveOccurred())
+		Expect(stored.KeyHash).To(Equal(auth.HashAPIKey(created.Key)))
+		Expect(stored.KeyHash).NotTo(ContainSubstring(created.Key))
+
+		apiKey, err := auth.NewDefaultAPIKeyVerifier(keyDb).VerifyAPIKey(context.Background(), created.Key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apiKey.Project.Name).To(Equal("Cart"))
+
+		w := request("GET", "/api/project/project-uuid/keys", "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).NotTo(ContainSubstring(created.Key))
+		var response struct {
+			Keys []models.ProjectAPIKey `json:"keys"`
+		}
+		Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+		Expect(response.Keys).To(HaveLen(1))
+		Expect(response.Keys[0].Prefix).To(Equal(created.Prefix))
+
+		w = request("GET", "/api/project/other-uuid/keys", "")
+		Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+		Expect(response.Keys).To(BeEmpty())
+	})
+
+	It("revokes keys of the project only", func() {
+		created := createKey("ci")
+
+		w := request("DELETE", fmt.Sprintf("/api/project/other-uuid/keys/%d", created.ID), "")
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+
+		w = request("DELETE", fmt.Sprintf("/api/project/project-uuid/keys/%d", created.ID), "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var revoked models.ProjectAPIKey
+		Expect(json.Unmarshal(w.Body.Bytes(), &revoked)).To(Succeed())
+		Expect(revoked.RevokedAt).NotTo(BeNil())
+
+		_, err := auth.NewDefaultAPIKeyVerifier(keyDb).VerifyAPIKey(context.Background(), created.Key)
+		Expect(err).To(MatchError(auth.ErrInvalidAPIKey))
+	})
+
+	It("does not let API keys manage keys", func() {
+		created := createKey("ci")
+		apiKeyID = created.ID
+
+		Expect(request("POST", "/api/project/project-uuid/keys", `{"name": "more"}`).Code).To(Equal(http.StatusForbidden))
+		Expect(request("DELETE", fmt.Sprintf("/api/project/project-uuid/keys/%d", created.ID), "").Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("rejects invalid requests and unknown projects", func() {
+		Expect(request("POST", "/api/project/project-uuid/keys", `{}`).Code).To(Equal(http.StatusBadRequest))
+		Expect(request("POST", "/api/project/unknown/keys", `{"name": "ci"}`).Code).To(Equal(http.StatusNotFound))
+		Expect(request("GET", "/api/project/unknown/keys", "").Code).To(Equal(http.StatusNotFound))
+		Expect(request("DELETE", "/api/project/project-uuid/keys/abc", "").Code).To(Equal(http.StatusBadRequest))
+		Expect(request("DELETE", "/api/project/project-uuid/keys/999", "").Code).To(Equal(http.StatusNotFound))
+	})
+})
@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/audit"
+	"github.com/guidewire/fern-reporter/pkg/auth"
 	"github.com/guidewire/fern-reporter/pkg/models"
 	"gorm.io/gorm"
 )
@@ -22,6 +24,9 @@ func NewProjectHandler(db *gorm.DB) *ProjectHandler {
 	return &ProjectHandler{db: db}
 }
 
+// CreateProject creates a project in the caller's organization, or in the
+// default organization when the caller has none. Project names are unique
+// within an organization.
 func (h *ProjectHandler) CreateProject(c *gin.Context) {
 	var project models.ProjectDetails
 
@@ -30,8 +35,12 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 		return
 	}
 	project.Name = strings.TrimSpace(project.Name)
+	project.OrganizationID = models.DefaultOrganizationID
+	if organizationID, ok := auth.OrganizationFromContext(c); ok {
+		project.OrganizationID = organizationID
+	}
 
-	if err := h.db.Where("name = ?", project.Name).First(&project).Error; err == nil {
+	if err := h.db.Where("name = ? AND organization_id = ?", project.Name, project.OrganizationID).First(&project).Error; err == nil {
 		log.Printf("Project %s already exists", project.Name)
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Project Name already exists"})
 		return
@@ -46,6 +55,8 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 		return
 	}
 	log.Printf("Created project, Name: %s, UUID: %s", project.Name, project.UUID)
+	audit.Target(c, audit.TargetProject, project.UUID, project.UUID)
+	audit.After(c, project)
 	c.JSON(http.StatusCreated, project)
 }
 
@@ -58,16 +69,16 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 		return
 	}
 
-	if err := h.db.Where("uuid = ?", id).First(&existing).Error; err != nil {
+	if err := auth.ScopeProjects(c, h.db.Where("uuid = ?", id)).First(&existing).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
 		return
 	}
 	project.Name = strings.TrimSpace(project.Name)
 
-	// Check for name uniqueness excluding current UUID
+	// Check for name uniqueness within the organization excluding current UUID
 	var count int64
 	if err := h.db.Model(&models.ProjectDetails{}).
-		Where("name = ? AND uuid != ?", project.Name, id).
+		Where("name = ? AND uuid != ? AND organization_id = ?", project.Name, id, existing.OrganizationID).
 		Count(&count).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error while checking for duplicates"})
 		return
@@ -82,6 +93,7 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	project.ID = existing.ID
 	project.UUID = existing.UUID
 	project.CreatedAt = existing.CreatedAt
+	project.OrganizationID = existing.OrganizationID
 
 	if err := h.db.Save(&project).Error; err != nil {
 		log.Printf("Failed to update project: %s", err.Error())
@@ -90,13 +102,22 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	}
 
 	log.Printf("Updated project, Name: %s, UUID: %s", project.Name, project.UUID)
+	audit.Target(c, audit.TargetProject, project.UUID, project.UUID)
+	audit.Before(c, existing)
+	audit.After(c, project)
 	c.JSON(http.StatusOK, project)
 }
 
 func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 	uuid := c.Param("uuid")
 
-	if err := h.db.Where("uuid = ?", uuid).Delete(&models.ProjectDetails{}).Error; err != nil {
+	var existing models.ProjectDetails
+	if audit.Recording(c) && auth.ScopeProjects(c, h.db.Where("uuid = ?", uuid)).First(&existing).Error == nil {
+		audit.Target(c, audit.TargetProject, uuid, uuid)
+		audit.Before(c, existing)
+	}
+
+	if err := auth.ScopeProjects(c, h.db.Where("uuid = ?", uuid)).Delete(&models.ProjectDetails{}).Error; err != nil {
 		log.Printf("Failed to delete project: %s", err.Error())
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete project"})
 		return
@@ -109,7 +130,8 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 func (h *ProjectHandler) GetAllProjects(c *gin.Context) {
 	var projects []models.ProjectDetails
 
-	if err := h.db.Order("name ASC").Find(&projects).Error; err != nil {
+	query := auth.FilterAllowedProjects(c, auth.ScopeProjects(c, h.db.Order("name ASC")))
+	if err := query.Find(&projects).Error; err != nil {
 		log.Printf("Error fetching projects: %s", err.Error())
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching projects"})
 		return
@@ -124,7 +146,8 @@ func (h *ProjectHandler) GetAllProjectsForReport(c *gin.Context) {
 		Name string `json:"name"`
 		UUID string `json:"uuid"`
 	}
-	h.db.Table("project_details").
+	query := auth.ScopeProjects(c, h.db.Table("project_details"))
+	auth.FilterAllowedProjects(c, query).
 		Order("name ASC").
 		Find(&projects)
 
@@ -132,3 +155,29 @@ func (h *ProjectHandler) GetAllProjectsForReport(c *gin.Context) {
 		"projects": projects,
 	})
 }
+
+// GetProjectTests lists every test known for the project with when it was
+// first and last seen and the status of its latest run.
+func (h *ProjectHandler) GetProjectTests(c *gin.Context) {
+	uuid := c.Param("uuid")
+
+	project, ok := h.findProject(c)
+	if !ok {
+		return
+	}
+
+	var tests []models.TestCase
+	if err := h.db.Where("project_id = ?", project.ID).
+		Order("suite_name ASC, spec_description ASC").
+		Find(&tests).Error; err != nil {
+		log.Printf("Error fetching tests for project %s: %s", uuid, err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching tests"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id": uuid,
+		"total":      len(tests),
+		"tests":      tests,
+	})
+}
@@ -8,10 +8,12 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gin-gonic/gin"
 	"github.com/guidewire/fern-reporter/pkg/api/handlers/project"
+	"github.com/guidewire/fern-reporter/pkg/auth"
 	"github.com/guidewire/fern-reporter/pkg/models"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"net/http"
 	"net/http/httptest"
@@ -88,13 +90,13 @@ var _ = Describe("Project Handlers", func() {
 				fmt.Printf("Error serializing SuiteRuns: %v", err)
 				return
 			}
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "project_details" WHERE name = $1 ORDER BY "project_details"."id" LIMIT $2`)).
-				WithArgs(projRequest.Name, 1).
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "project_details" WHERE name = $1 AND organization_id = $2 ORDER BY "project_details"."id" LIMIT $3`)).
+				WithArgs(projRequest.Name, models.DefaultOrganizationID, 1).
 				WillReturnError(gorm.ErrRecordNotFound)
 
 			mock.ExpectBegin()
-			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "project_details" ("name","team_name","comment","updated_at") VALUES ($1,$2,$3,$4) RETURNING *`)).
-				WithArgs(projRequest.Name, projRequest.TeamName, projRequest.Comment, sqlmock.AnyArg()).
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "project_details" ("name","team_name","comment","organization_id","updated_at") VALUES ($1,$2,$3,$4,$5) RETURNING *`)).
+				WithArgs(projRequest.Name, projRequest.TeamName, projRequest.Comment, models.DefaultOrganizationID, sqlmock.AnyArg()).
 				WillReturnRows(
 					sqlmock.NewRows([]string{"id", "uuid", "name", "team_name", "comment", "created_at", "updated_at"}).
 						AddRow(1, projectID, projRequest.Name, projRequest.TeamName, projRequest.Comment, time.Now(), time.Now()),
@@ -132,8 +134,8 @@ var _ = Describe("Project Handlers", func() {
 			projectRows := sqlmock.NewRows([]string{"id", "uuid", "name", "team_name", "comment"}).
 				AddRow(1, projectID, projRequest.Name, projRequest.TeamName, projRequest.Comment)
 
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "project_details" WHERE name = $1 ORDER BY "project_details"."id" LIMIT $2`)).
-				WithArgs(projRequest.Name, 1).
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "project_details" WHERE name = $1 AND organization_id = $2 ORDER BY "project_details"."id" LIMIT $3`)).
+				WithArgs(projRequest.Name, models.DefaultOrganizationID, 1).
 				WillReturnRows(projectRows)
 
 			req := httptest.NewRequest(http.MethodPost, "/api/project", bytes.NewBuffer([]byte(reqBody)))
@@ -165,8 +167,8 @@ var _ = Describe("Project Handlers", func() {
 		}
 		It("with proper details, it should update one and return project object back", func() {
 
-			projectRows := sqlmock.NewRows([]string{"id", "uuid", "name", "team_name", "comment"}).
-				AddRow(1, projectID, projRequest.Name, projRequest.TeamName, projRequest.Comment)
+			projectRows := sqlmock.NewRows([]string{"id", "uuid", "name", "team_name", "comment", "organization_id"}).
+				AddRow(1, projectID, projRequest.Name, projRequest.TeamName, projRequest.Comment, 2)
 
 			reqBody, err := json.Marshal(projRequest)
 			if err != nil {
@@ -178,13 +180,13 @@ var _ = Describe("Project Handlers", func() {
 				WithArgs(projectID, 1).
 				WillReturnRows(projectRows)
 
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "project_details" WHERE name = $1 AND uuid != $2`)).
-				WithArgs(projRequest.Name, projRequest.UUID).
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "project_details" WHERE name = $1 AND uuid != $2 AND organization_id = $3`)).
+				WithArgs(projRequest.Name, projRequest.UUID, 2).
 				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
 			mock.ExpectBegin()
-			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "project_details" SET "name"=$1,"team_name"=$2,"comment"=$3,"created_at"=$4,"updated_at"=$5 WHERE "id" = $6`)).
-				WithArgs(projRequest.Name, projRequest.TeamName, projRequest.Comment, sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "project_details" SET "name"=$1,"team_name"=$2,"comment"=$3,"organization_id"=$4,"created_at"=$5,"updated_at"=$6 WHERE "id" = $7`)).
+				WithArgs(projRequest.Name, projRequest.TeamName, projRequest.Comment, 2, sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
 				WillReturnResult(sqlmock.NewResult(0, 1))
 			mock.ExpectCommit()
 
@@ -210,8 +212,8 @@ var _ = Describe("Project Handlers", func() {
 		})
 		It("with duplicate project name, it should not update the details and return 400", func() {
 
-			projectRows := sqlmock.NewRows([]string{"id", "uuid", "name", "team_name", "comment"}).
-				AddRow(1, projectID, projRequest.Name, projRequest.TeamName, projRequest.Comment)
+			projectRows := sqlmock.NewRows([]string{"id", "uuid", "name", "team_name", "comment", "organization_id"}).
+				AddRow(1, projectID, projRequest.Name, projRequest.TeamName, projRequest.Comment, 2)
 
 			reqBody, err := json.Marshal(projRequest)
 			if err != nil {
@@ -223,8 +225,8 @@ var _ = Describe("Project Handlers", func() {
 				WithArgs(projectID, 1).
 				WillReturnRows(projectRows)
 
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "project_details" WHERE name = $1 AND uuid != $2`)).
-				WithArgs(projRequest.Name, projRequest.UUID).
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "project_details" WHERE name = $1 AND uuid != $2 AND organization_id = $3`)).
+				WithArgs(projRequest.Name, projRequest.UUID, 2).
 				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
 			req := httptest.NewRequest(http.MethodPost, "/api/project", bytes.NewBuffer([]byte(reqBody)))
@@ -308,4 +310,179 @@ var _ = Describe("Project Handlers", func() {
 			Expect(w.Body.String()).To(Equal(`{"message":"Project ID 96ad860-2a9a-504f-8861-aeafd0b2ae29 deleted"}`))
 		})
 	})
+
+	Context("when get project tests is invoked", func() {
+		projectID := "96ad860-2a9a-504f-8861-aeafd0b2ae29"
+
+		It("should list the project's tests", func() {
+			seen := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "project_details" WHERE uuid = $1 ORDER BY "project_details"."id" LIMIT $2`)).
+				WithArgs(projectID, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "uuid", "name"}).AddRow(1, projectID, "First Project"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_cases" WHERE project_id = $1 ORDER BY suite_name ASC, spec_description ASC`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "fingerprint", "suite_name", "spec_description", "first_seen", "last_seen", "last_status"}).
+					AddRow(1, 1, "abc", "Cart", "adds items", seen, seen.Add(time.Hour), "failed"))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = append(c.Params, gin.Param{Key: "uuid", Value: projectID})
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/project/"+projectID+"/tests", nil)
+
+			project.NewProjectHandler(gormDb).GetProjectTests(c)
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var response struct {
+				Total int               `json:"total"`
+				Tests []models.TestCase `json:"tests"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Total).To(Equal(1))
+			Expect(response.Tests[0].Fingerprint).To(Equal("abc"))
+			Expect(response.Tests[0].LastStatus).To(Equal("failed"))
+			Expect(response.Tests[0].FirstSeen).To(Equal(seen))
+		})
+
+		It("should return 404 for an unknown project", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "project_details" WHERE uuid = $1 ORDER BY "project_details"."id" LIMIT $2`)).
+				WithArgs(projectID, 1).
+				WillReturnError(gorm.ErrRecordNotFound)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = append(c.Params, gin.Param{Key: "uuid", Value: projectID})
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/project/"+projectID+"/tests", nil)
+
+			project.NewProjectHandler(gormDb).GetProjectTests(c)
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
+
+var _ = Describe("Project organizations", func() {
+	var (
+		orgDb  *gorm.DB
+		router *gin.Engine
+	)
+
+	request := func(organizationID uint64, method string, path string, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req = req.WithContext(auth.WithOrganization(req.Context(), organizationID))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	projectNames := func(organizationID uint64) []string {
+		w := request(organizationID, "GET", "/api/project/", "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var projects []models.ProjectDetails
+		Expect(json.Unmarshal(w.Body.Bytes(), &projects)).To(Succeed())
+		names := []string{}
+		for _, project := range projects {
+			names = append(names, project.Name)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		var err error
+		orgDb, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orgDb.AutoMigrate(&models.ProjectDetails{})).To(Succeed())
+
+		gin.SetMode(gin.TestMode)
+		router = gin.New()
+		handler := project.NewProjectHandler(orgDb)
+		router.POST("/api/project", handler.CreateProject)
+		router.GET("/api/project/", handler.GetAllProjects)
+		router.DELETE("/api/project/:uuid", handler.DeleteProject)
+	})
+
+	It("should only require project names to be unique within an organization", func() {
+		Expect(request(1, "POST", "/api/project", `{"name": "Cart"}`).Code).To(Equal(http.StatusCreated))
+		Expect(request(2, "POST", "/api/project", `{"name": "Cart"}`).Code).To(Equal(http.StatusCreated))
+		Expect(request(2, "POST", "/api/project", `{"name": "Search"}`).Code).To(Equal(http.StatusCreated))
+
+		w := request(2, "POST", "/api/project", `{"name": "Cart"}`)
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Body.String()).To(ContainSubstring("Project Name already exists"))
+
+		var organizations []uint64
+		Expect(orgDb.Model(&models.ProjectDetails{}).Order("id").Pluck("organization_id", &organizations).Error).NotTo(HaveOccurred())
+		Expect(organizations).To(Equal([]uint64{1, 2, 2}))
+	})
+
+	It("should only list and delete the projects of the caller's organization", func() {
+		Expect(orgDb.Create(&models.ProjectDetails{Name: "Cart"}).Error).NotTo(HaveOccurred())
+		Expect(orgDb.Create(&models.ProjectDetails{Name: "Search", OrganizationID: 2}).Error).NotTo(HaveOccurred())
+		Expect(orgDb.Exec("UPDATE project_details SET uuid = name").Error).NotTo(HaveOccurred())
+
+		Expect(projectNames(1)).To(Equal([]string{"Cart"}))
+		Expect(projectNames(2)).To(Equal([]string{"Search"}))
+
+		Expect(request(1, "DELETE", "/api/project/Search", "").Code).To(Equal(http.StatusOK))
+		Expect(projectNames(2)).To(Equal([]string{"Search"}))
+	})
+})
+
+var _ = Describe("Project listings for scoped callers", func() {
+	var (
+		listDb *gorm.DB
+		router *gin.Engine
+	)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	BeforeEach(func() {
+		var err error
+		listDb, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listDb.AutoMigrate(&models.ProjectDetails{})).To(Succeed())
+		for _, name := range []string{"Billing", "Cart", "Search"} {
+			Expect(listDb.Create(&models.ProjectDetails{Name: name}).Error).NotTo(HaveOccurred())
+		}
+
+		gin.SetMode(gin.TestMode)
+		router = gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("scope", []interface{}{"fern.read", "fernproject.Cart", "fernproject.Search"})
+		})
+		router.Use(auth.ScopeMiddleware(auth.NewDefaultProjectLookup(listDb)))
+		handler := project.NewProjectHandler(listDb)
+		router.GET("/api/project", handler.GetAllProjects)
+		router.GET("/api/reports/projects/", handler.GetAllProjectsForReport)
+	})
+
+	It("should only list the projects the caller's scopes allow", func() {
+		w := get("/api/project")
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		var projects []models.ProjectDetails
+		Expect(json.Unmarshal(w.Body.Bytes(), &projects)).To(Succeed())
+		Expect(projects).To(HaveLen(2))
+		Expect(projects[0].Name).To(Equal("Cart"))
+		Expect(projects[1].Name).To(Equal("Search"))
+	})
+
+	It("should only report the projects the caller's scopes allow", func() {
+		w := get("/api/reports/projects/")
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		var response struct {
+			Projects []struct {
+				Name string `json:"name"`
+			} `json:"projects"`
+		}
+		Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+		Expect(response.Projects).To(HaveLen(2))
+		Expect(response.Projects[0].Name).To(Equal("Cart"))
+		Expect(response.Projects[1].Name).To(Equal("Search"))
+	})
 })
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	BaselineSourceMergeBase     = "merge_base"
+	BaselineSourceDefaultBranch = "default_branch"
+)
+
+// BaselineOptions controls how the baseline of a test run is chosen.
+type BaselineOptions struct {
+	// MergeBaseSha, when set, selects the most recent completed run of that
+	// commit. A prefix of the sha is enough.
+	MergeBaseSha string
+	// Branch selects the most recent completed run on the branch that started
+	// before the test run. It is used when MergeBaseSha is not set or no run of
+	// the merge base exists.
+	Branch string
+}
+
+// FindBaselineTestRun picks the run of the same project that a test run should
+// be compared with and reports which option selected it. It returns
+// gorm.ErrRecordNotFound when there is no suitable run.
+func FindBaselineTestRun(db *gorm.DB, testRun *models.TestRun, options BaselineOptions) (*models.TestRun, string, error) {
+	completed := func() *gorm.DB {
+		return db.Preload("SuiteRuns.SpecRuns").
+			Where("project_id = ? AND id <> ?", testRun.ProjectID, testRun.ID).
+			Where("status NOT IN ?", []string{utils.StatusInProgress, utils.StatusAbandoned}).
+			Order("start_time DESC, id DESC")
+	}
+
+	var baseline models.TestRun
+	if options.MergeBaseSha != "" {
+		err := completed().Where("git_sha LIKE ?", options.MergeBaseSha+"%").First(&baseline).Error
+		if err == nil {
+			return &baseline, BaselineSourceMergeBase, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", err
+		}
+	}
+
+	if options.Branch == "" {
+		return nil, "", gorm.ErrRecordNotFound
+	}
+	if err := completed().Where("git_branch = ? AND start_time <= ?", options.Branch, testRun.StartTime).
+		First(&baseline).Error; err != nil {
+		return nil, "", err
+	}
+	return &baseline, BaselineSourceDefaultBranch, nil
+}
+
+// FindTestRunRegressions lists the specs that passed in the baseline of a test
+// run but fail in the run itself. The test run must have its suite and spec
+// runs loaded. When no baseline exists the result has no baseline and no
+// regressions.
+func FindTestRunRegressions(db *gorm.DB, testRun *models.TestRun, options BaselineOptions) (models.TestRunRegressions, error) {
+	result := models.TestRunRegressions{
+		TestRunID:   testRun.ID,
+		Regressions: []models.SpecDiff{},
+	}
+
+	baseline, source, err := FindBaselineTestRun(db, testRun, options)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+
+	result.Baseline = NewBaselineRun(baseline)
+	result.BaselineSource = source
+	result.Regressions = RegressedSpecs(baseline, testRun)
+	return result, nil
+}
+
+// NewBaselineRun describes a baseline run without its suites.
+func NewBaselineRun(baseline *models.TestRun) *models.BaselineRun {
+	return &models.BaselineRun{
+		TestRunID: baseline.ID,
+		GitBranch: baseline.GitBranch,
+		GitSha:    baseline.GitSha,
+		StartTime: baseline.StartTime,
+		BuildUrl:  baseline.BuildUrl,
+	}
+}
+
+// RegressedSpecs lists the specs that passed in the baseline run and fail in
+// the test run.
+func RegressedSpecs(baseline *models.TestRun, testRun *models.TestRun) []models.SpecDiff {
+	regressions := []models.SpecDiff{}
+	for _, spec := range DiffTestRuns(baseline, testRun, defaultSlowerFactor).NewlyFailing {
+		if spec.BaseStatus == utils.StatusPassed {
+			regressions = append(regressions, spec)
+		}
+	}
+	return regressions
+}
+
+// GetTestRunRegressions compares a test run with a baseline chosen by Fern and
+// returns the specs that regressed. Optional query parameters: merge_base (the
+// sha the run's branch was forked from) and branch (defaults to the configured
+// default branch).
+func (h *Handler) GetTestRunRegressions(c *gin.Context) {
+	testRunID, err := parseTestRunID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var testRun models.TestRun
+	if err := scopeTestRuns(c, h.db.Preload("SuiteRuns.SpecRuns").Where("id = ?", testRunID)).First(&testRun).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("test run %d not found", testRunID)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading test run"})
+		return
+	}
+
+	regressions, err := FindTestRunRegressions(h.db, &testRun, BaselineOptions{
+		MergeBaseSha: c.Query("merge_base"),
+		Branch:       c.DefaultQuery("branch", config.GetReports().DefaultBranch),
+	})
+	if err != nil {
+		log.Printf("error finding regressions of test run %d: %v", testRunID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error finding regressions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, regressions)
+}
@@ -0,0 +1,152 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Test run regressions", func() {
+	var (
+		db        *gorm.DB
+		projectID uint64
+		runStart  time.Time
+	)
+
+	// addRun stores a run of the Cart suite whose specs have the given statuses.
+	addRun := func(branch string, sha string, status string, specs map[string]string) models.TestRun {
+		runStart = runStart.Add(time.Hour)
+		suite := models.SuiteRun{SuiteName: "Cart"}
+		for description, specStatus := range specs {
+			suite.SpecRuns = append(suite.SpecRuns, models.SpecRun{SpecDescription: description, Status: specStatus})
+		}
+		testRun := models.TestRun{
+			ProjectID: projectID,
+			StartTime: runStart,
+			Status:    status,
+			GitBranch: branch,
+			GitSha:    sha,
+			SuiteRuns: []models.SuiteRun{suite},
+		}
+		Expect(db.Create(&testRun).Error).NotTo(HaveOccurred())
+		Expect(db.Preload("SuiteRuns.SpecRuns").First(&testRun, testRun.ID).Error).NotTo(HaveOccurred())
+		return testRun
+	}
+
+	BeforeEach(func() {
+		db = setupTestDB()
+		project := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		projectID = project.ID
+		runStart = time.Now().Add(-24 * time.Hour)
+	})
+
+	It("compares with the latest completed run on the branch", func() {
+		addRun("main", "aaa111", "passed", map[string]string{"adds items": "failed", "totals": "passed"})
+		latest := addRun("main", "bbb222", "passed", map[string]string{"adds items": "passed", "totals": "passed", "checks out": "skipped"})
+		addRun("main", "ccc333", "in_progress", map[string]string{"adds items": "failed"})
+		head := addRun("feature", "ddd444", "failed", map[string]string{"adds items": "failed", "totals": "passed", "checks out": "failed"})
+
+		result, err := handlers.FindTestRunRegressions(db, &head, handlers.BaselineOptions{Branch: "main"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Baseline).NotTo(BeNil())
+		Expect(result.Baseline.TestRunID).To(Equal(latest.ID))
+		Expect(result.BaselineSource).To(Equal(handlers.BaselineSourceDefaultBranch))
+		Expect(result.Regressions).To(HaveLen(1))
+		Expect(result.Regressions[0].SpecDescription).To(Equal("adds items"))
+	})
+
+	It("prefers a run of the merge base", func() {
+		mergeBase := addRun("main", "aaa111", "passed", map[string]string{"adds items": "passed"})
+		addRun("main", "bbb222", "failed", map[string]string{"adds items": "failed"})
+		head := addRun("feature", "ddd444", "failed", map[string]string{"adds items": "failed"})
+
+		result, err := handlers.FindTestRunRegressions(db, &head, handlers.BaselineOptions{MergeBaseSha: "aaa", Branch: "main"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Baseline.TestRunID).To(Equal(mergeBase.ID))
+		Expect(result.BaselineSource).To(Equal(handlers.BaselineSourceMergeBase))
+		Expect(result.Regressions).To(HaveLen(1))
+
+		result, err = handlers.FindTestRunRegressions(db, &head, handlers.BaselineOptions{MergeBaseSha: "fff", Branch: "main"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.BaselineSource).To(Equal(handlers.BaselineSourceDefaultBranch))
+		Expect(result.Regressions).To(BeEmpty())
+	})
+
+	It("compares with runs that were uploaded whole rather than opened and closed", func() {
+		legacy := addRun("main", "aaa111", "", map[string]string{"adds items": "passed"})
+		head := addRun("feature", "ddd444", "failed", map[string]string{"adds items": "failed"})
+
+		result, err := handlers.FindTestRunRegressions(db, &head, handlers.BaselineOptions{Branch: "main"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Baseline).NotTo(BeNil())
+		Expect(result.Baseline.TestRunID).To(Equal(legacy.ID))
+		Expect(result.Regressions).To(HaveLen(1))
+	})
+
+	It("reports no baseline when there is no earlier run", func() {
+		head := addRun("main", "aaa111", "failed", map[string]string{"adds items": "failed"})
+		addRun("main", "bbb222", "passed", map[string]string{"adds items": "passed"})
+
+		result, err := handlers.FindTestRunRegressions(db, &head, handlers.BaselineOptions{Branch: "main"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Baseline).To(BeNil())
+		Expect(result.Regressions).NotTo(BeNil())
+		Expect(result.Regressions).To(BeEmpty())
+	})
+
+	Context("REST endpoint", func() {
+		var router *gin.Engine
+
+		get := func(path string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("GET", path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		BeforeEach(func() {
+			_, err := config.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+
+			gin.SetMode(gin.TestMode)
+			router = gin.New()
+			router.GET("/api/reports/testruns/:id/regressions", handlers.NewHandler(db).GetTestRunRegressions)
+		})
+
+		It("uses the configured default branch", func() {
+			addRun("main", "aaa111", "passed", map[string]string{"adds items": "passed"})
+			addRun("trunk", "bbb222", "failed", map[string]string{"adds items": "failed"})
+			head := addRun("feature", "ddd444", "failed", map[string]string{"adds items": "failed"})
+
+			w := get(fmt.Sprintf("/api/reports/testruns/%d/regressions", head.ID))
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var result models.TestRunRegressions
+			Expect(json.Unmarshal(w.Body.Bytes(), &result)).To(Succeed())
+			Expect(result.Baseline.GitBranch).To(Equal("main"))
+			Expect(result.Regressions).To(HaveLen(1))
+
+			w = get(fmt.Sprintf("/api/reports/testruns/%d/regressions?branch=trunk", head.ID))
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(json.Unmarshal(w.Body.Bytes(), &result)).To(Succeed())
+			Expect(result.Baseline.GitBranch).To(Equal("trunk"))
+			Expect(result.Regressions).To(BeEmpty())
+		})
+
+		It("rejects invalid and unknown runs", func() {
+			Expect(get("/api/reports/testruns/abc/regressions").Code).To(Equal(http.StatusBadRequest))
+			Expect(get("/api/reports/testruns/999/regressions").Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/auth"
 	"github.com/guidewire/fern-reporter/pkg/models"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -118,6 +119,37 @@ var _ = Describe("ReportTestRunAll Handler", func() {
 		Expect(tags[0].(map[string]interface{})["name"]).To(Equal("env"))
 	})
 
+	It("should only return the runs of the projects the caller's scopes allow", func() {
+		other := models.ProjectDetails{Name: "Other Project"}
+		Expect(db.Create(&other).Error).NotTo(HaveOccurred())
+		Expect(db.Create(&models.TestRun{ProjectID: other.ID, StartTime: time.Now(), EndTime: time.Now()}).Error).NotTo(HaveOccurred())
+
+		scoped := gin.New()
+		scoped.Use(func(c *gin.Context) {
+			c.Set("scope", []interface{}{"fern.read", "fernproject.Other Project"})
+		})
+		scoped.Use(auth.ScopeMiddleware(auth.NewDefaultProjectLookup(db)))
+		scoped.GET("/api/reports/testruns/", handlers.NewHandler(db).ReportTestRunAll)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/reports/testruns/", nil)
+		scoped.ServeHTTP(w, req)
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		var response struct {
+			TestRuns []models.TestRun `json:"testRuns"`
+		}
+		Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+		Expect(response.TestRuns).To(HaveLen(1))
+		Expect(response.TestRuns[0].Project.Name).To(Equal("Other Project"))
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("GET", "/api/reports/testruns/", nil)
+		router.ServeHTTP(w, req)
+		Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+		Expect(response.TestRuns).To(HaveLen(2))
+	})
+
 	It("should return test run by ID with project and tags", func() {
 		// Create a fresh recorder and request for /:id
 		var testRun models.TestRun
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/guidewire/fern-reporter/pkg/auth"
 	"github.com/guidewire/fern-reporter/pkg/models"
 	"sort"
 )
@@ -37,6 +38,7 @@ func (h SummaryHandler) GetSummary(c *gin.Context) {
 		Preload("SuiteRuns.SpecRuns.Tags").
 		Preload("SuiteRuns.Tags").
 		Order("start_time")
+	query = auth.ScopeProjects(c, query)
 
 	if err := query.Find(&testRun).Error; err != nil {
 		log.Printf("Error finding TestRun for projectUUID=%s, seed=%s: %v", projectUUID, seedParam, err)
@@ -79,8 +79,13 @@ func setupTestEnvWithTaggedSpecs(groups []TestRunSpecGroup) testEnv {
 			}
 			Expect(db.Create(&specRun).Error).ToNot(HaveOccurred())
 
-			for _, tag := range taggedSpec.Tags {
-				Expect(db.Create(&tag).Error).ToNot(HaveOccurred())
+			// Tag names are unique, so reuse the tag when several specs share it
+			for j := range taggedSpec.Tags {
+				tag := &taggedSpec.Tags[j]
+				if tag.Name == "" {
+					tag.Name = tag.Category + ":" + tag.Value
+				}
+				Expect(db.Where(models.Tag{Name: tag.Name}).FirstOrCreate(tag).Error).ToNot(HaveOccurred())
 			}
 
 			Expect(db.Model(&specRun).Association("Tags").Append(taggedSpec.Tags)).To(Succeed())
@@ -0,0 +1,50 @@
+package handlers_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Batch tag resolution", func() {
+	It("resolves every tag of a run to one stored tag per name", func() {
+		db := setupTestDB()
+		Expect(db.Create(&models.Tag{Name: "smoke", Value: "smoke"}).Error).NotTo(HaveOccurred())
+
+		var specs []models.SpecRun
+		for i := 0; i < 1500; i++ {
+			specs = append(specs, models.SpecRun{Tags: []models.Tag{{Name: "smoke"}, {Name: fmt.Sprintf("case:%d", i)}}})
+		}
+		testRun := &models.TestRun{SuiteRuns: []models.SuiteRun{{Tags: []models.Tag{{Name: "team:payments"}}, SpecRuns: specs}}}
+
+		Expect(handlers.ProcessTags(db, testRun)).To(Succeed())
+
+		var count int64
+		Expect(db.Model(&models.Tag{}).Count(&count).Error).NotTo(HaveOccurred())
+		Expect(count).To(Equal(int64(1502)))
+
+		suite := testRun.SuiteRuns[0]
+		Expect(suite.Tags[0].ID).NotTo(BeZero())
+		Expect(suite.Tags[0].Category).To(Equal("team"))
+		Expect(suite.SpecRuns[0].Tags[0].ID).To(Equal(suite.SpecRuns[1499].Tags[0].ID))
+		Expect(suite.SpecRuns[1499].Tags[1].Name).To(Equal("case:1499"))
+		Expect(suite.SpecRuns[1499].Tags[1].ID).NotTo(BeZero())
+	})
+
+	It("reuses tags created by an earlier run", func() {
+		db := setupTestDB()
+
+		first, err := handlers.ResolveTags(db, []string{"a", "b", "a"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first).To(HaveLen(2))
+
+		second, err := handlers.ResolveTags(db, []string{"b", "c"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second["b"].ID).To(Equal(first["b"].ID))
+		Expect(second["c"].ID).NotTo(BeZero())
+	})
+})
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LinkTestCases points every spec run of the test run at its entry in the
+// test case catalog, adding entries for tests that have not been seen before
+// and moving the first seen, last seen and last status of the others. Like
+// ProcessTags it works on the whole run at once so that large uploads need a
+// handful of queries.
+func LinkTestCases(db *gorm.DB, testRun *models.TestRun) error {
+	seenAt := testRun.StartTime
+	if seenAt.IsZero() {
+		seenAt = time.Now()
+	}
+
+	// Collect the distinct tests of the run and the status each ended with
+	cases := map[string]*models.TestCase{}
+	var fingerprints []string
+	for _, suite := range testRun.SuiteRuns {
+		for _, spec := range suite.SpecRuns {
+			fingerprint := utils.TestCaseFingerprint(testRun.ProjectID, suite.SuiteName, spec.SpecDescription)
+			if testCase, ok := cases[fingerprint]; ok {
+				testCase.LastStatus = spec.Status
+				continue
+			}
+			cases[fingerprint] = &models.TestCase{
+				ProjectID:       testRun.ProjectID,
+				Fingerprint:     fingerprint,
+				SuiteName:       utils.NormalizeTestName(suite.SuiteName),
+				SpecDescription: utils.NormalizeTestName(spec.SpecDescription),
+				FirstSeen:       seenAt,
+				LastSeen:        seenAt,
+				LastStatus:      spec.Status,
+			}
+			fingerprints = append(fingerprints, fingerprint)
+		}
+	}
+	if len(fingerprints) == 0 {
+		return nil
+	}
+
+	ids := map[string]uint64{}
+	statusGroups := map[string][]uint64{}
+	var existingIDs []uint64
+	for start := 0; start < len(fingerprints); start += lookupBatchSize {
+		batch := fingerprints[start:min(start+lookupBatchSize, len(fingerprints))]
+		found, err := findTestCases(db, testRun.ProjectID, batch)
+		if err != nil {
+			return err
+		}
+
+		var missing []models.TestCase
+		for _, fingerprint := range batch {
+			if existing, ok := found[fingerprint]; ok {
+				ids[fingerprint] = existing.ID
+				existingIDs = append(existingIDs, existing.ID)
+				statusGroups[cases[fingerprint].LastStatus] = append(statusGroups[cases[fingerprint].LastStatus], existing.ID)
+			} else {
+				missing = append(missing, *cases[fingerprint])
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		if err := db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "project_id"}, {Name: "fingerprint"}},
+			DoNothing: true,
+		}).Create(&missing).Error; err != nil {
+			return err
+		}
+
+		// Read back rather than trust the insert, which skips tests that a
+		// concurrent upload added first.
+		created := make([]string, 0, len(missing))
+		for _, testCase := range missing {
+			created = append(created, testCase.Fingerprint)
+		}
+		found, err = findTestCases(db, testRun.ProjectID, created)
+		if err != nil {
+			return err
+		}
+		for _, fingerprint := range created {
+			existing, ok := found[fingerprint]
+			if !ok {
+				return fmt.Errorf("test case %s could not be created", fingerprint)
+			}
+			ids[fingerprint] = existing.ID
+		}
+	}
+
+	// Runs can arrive out of order, so only move the bounds outwards
+	for start := 0; start < len(existingIDs); start += lookupBatchSize {
+		batch := existingIDs[start:min(start+lookupBatchSize, len(existingIDs))]
+		if err := db.Model(&models.TestCase{}).
+			Where("id IN ? AND first_seen > ?", batch, seenAt).
+			Update("first_seen", seenAt).Error; err != nil {
+			return err
+		}
+	}
+	for status, caseIDs := range statusGroups {
+		for start := 0; start < len(caseIDs); start += lookupBatchSize {
+			batch := caseIDs[start:min(start+lookupBatchSize, len(caseIDs))]
+			if err := db.Model(&models.TestCase{}).
+				Where("id IN ? AND last_seen <= ?", batch, seenAt).
+				Updates(map[string]interface{}{"last_seen": seenAt, "last_status": status}).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, suite := range testRun.SuiteRuns {
+		for j, spec := range suite.SpecRuns {
+			id := ids[utils.TestCaseFingerprint(testRun.ProjectID, suite.SuiteName, spec.SpecDescription)]
+			testRun.SuiteRuns[i].SpecRuns[j].TestCaseID = &id
+		}
+	}
+	return nil
+}
+
+func findTestCases(db *gorm.DB, projectID uint64, fingerprints []string) (map[string]models.TestCase, error) {
+	var found []models.TestCase
+	if err := db.Where("project_id = ? AND fingerprint IN ?", projectID, fingerprints).Find(&found).Error; err != nil {
+		return nil, err
+	}
+	testCases := make(map[string]models.TestCase, len(found))
+	for _, testCase := range found {
+		testCases[testCase.Fingerprint] = testCase
+	}
+	return testCases, nil
+}
+
+// findTestCase loads the test case with the given fingerprint from the projects
+// of the organization given by the context of db that the caller may access.
+func findTestCase(db *gorm.DB, fingerprint string, testCase *models.TestCase) error {
+	ctx := db.Statement.Context
+	query := auth.ScopeProjectRows(ctx, db.Where("fingerprint = ?", fingerprint), "test_cases.project_id")
+	return auth.FilterAllowedProjectRows(ctx, query, "test_cases.project_id").First(testCase).Error
+}
@@ -0,0 +1,88 @@
+package handlers_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("LinkTestCases", func() {
+	var (
+		db        *gorm.DB
+		projectID uint64
+		start     time.Time
+	)
+
+	newRun := func(startTime time.Time, specs ...models.SpecRun) *models.TestRun {
+		return &models.TestRun{
+			ProjectID: projectID,
+			StartTime: startTime,
+			SuiteRuns: []models.SuiteRun{{SuiteName: "Cart  Suite", SpecRuns: specs}},
+		}
+	}
+
+	BeforeEach(func() {
+		db = setupTestDB()
+		project := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		projectID = project.ID
+		start = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	})
+
+	It("adds new tests to the catalog and links the spec runs", func() {
+		testRun := newRun(start,
+			models.SpecRun{SpecDescription: "adds items", Status: "passed"},
+			models.SpecRun{SpecDescription: "checks out", Status: "failed"})
+		Expect(handlers.LinkTestCases(db, testRun)).To(Succeed())
+		Expect(db.Create(testRun).Error).NotTo(HaveOccurred())
+
+		var testCases []models.TestCase
+		Expect(db.Order("spec_description").Find(&testCases).Error).NotTo(HaveOccurred())
+		Expect(testCases).To(HaveLen(2))
+		Expect(testCases[0].SuiteName).To(Equal("Cart Suite"))
+		Expect(testCases[1].LastStatus).To(Equal("failed"))
+		Expect(testCases[1].FirstSeen.Equal(start)).To(BeTrue())
+
+		var stored models.SpecRun
+		Expect(db.Where("spec_description = ?", "checks out").First(&stored).Error).NotTo(HaveOccurred())
+		Expect(*stored.TestCaseID).To(Equal(testCases[1].ID))
+	})
+
+	It("reuses the entry across runs and ignores whitespace changes", func() {
+		first := newRun(start, models.SpecRun{SpecDescription: "adds items", Status: "failed"})
+		Expect(handlers.LinkTestCases(db, first)).To(Succeed())
+
+		later := newRun(start.Add(time.Hour), models.SpecRun{SpecDescription: " adds\titems ", Status: "passed"})
+		Expect(handlers.LinkTestCases(db, later)).To(Succeed())
+
+		earlier := newRun(start.Add(-time.Hour), models.SpecRun{SpecDescription: "adds items", Status: "skipped"})
+		Expect(handlers.LinkTestCases(db, earlier)).To(Succeed())
+
+		Expect(*later.SuiteRuns[0].SpecRuns[0].TestCaseID).To(Equal(*first.SuiteRuns[0].SpecRuns[0].TestCaseID))
+
+		var testCase models.TestCase
+		Expect(db.First(&testCase).Error).NotTo(HaveOccurred())
+		Expect(testCase.FirstSeen.Equal(start.Add(-time.Hour))).To(BeTrue())
+		Expect(testCase.LastSeen.Equal(start.Add(time.Hour))).To(BeTrue())
+		Expect(testCase.LastStatus).To(Equal("passed"))
+	})
+
+	It("keeps tests of different projects apart", func() {
+		other := models.ProjectDetails{Name: "Checkout"}
+		Expect(db.Create(&other).Error).NotTo(HaveOccurred())
+
+		Expect(handlers.LinkTestCases(db, newRun(start, models.SpecRun{SpecDescription: "adds items"}))).To(Succeed())
+		otherRun := newRun(start, models.SpecRun{SpecDescription: "adds items"})
+		otherRun.ProjectID = other.ID
+		Expect(handlers.LinkTestCases(db, otherRun)).To(Succeed())
+
+		var count int64
+		Expect(db.Model(&models.TestCase{}).Count(&count).Error).NotTo(HaveOccurred())
+		Expect(count).To(Equal(int64(2)))
+	})
+})
@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/audit"
+	"github.com/guidewire/fern-reporter/pkg/auth"
 	"github.com/guidewire/fern-reporter/pkg/models"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -98,3 +101,207 @@ var _ = Describe("TestRun Handler JSON Response", func() {
 		Expect(run).ToNot(HaveKey("tags"), "Expected 'tags' to be absent from the response")
 	})
 })
+
+var _ = Describe("TestRun Handler audit", func() {
+	var (
+		router  *gin.Engine
+		db      *gorm.DB
+		testRun models.TestRun
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		db, _ = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		Expect(db.AutoMigrate(&models.TestRun{}, &models.ProjectDetails{}, &models.AuditEvent{})).To(Succeed())
+
+		project := models.ProjectDetails{Name: "Demo Project"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "project-uuid", project.ID).Error).NotTo(HaveOccurred())
+
+		testRun = models.TestRun{ProjectID: project.ID, GitBranch: "main", Status: "failed"}
+		Expect(db.Create(&testRun).Error).NotTo(HaveOccurred())
+
+		handler := handlers.NewHandler(db)
+		router = gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("subject", "alice")
+		})
+		router.Use(audit.Middleware(audit.NewDefaultRecorder(db)))
+		router.PUT("/api/testrun/:id", handler.UpdateTestRun)
+		router.DELETE("/api/testrun/:id", handler.DeleteTestRun)
+	})
+
+	It("should record who deleted a test run and what it was", func() {
+		req, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/testrun/%d", testRun.ID), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		var events []models.AuditEvent
+		Expect(db.Find(&events).Error).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Actor).To(Equal("alice"))
+		Expect(events[0].TargetType).To(Equal(audit.TargetTestRun))
+		Expect(events[0].TargetID).To(Equal(fmt.Sprint(testRun.ID)))
+		Expect(events[0].ProjectID).To(Equal("project-uuid"))
+		Expect(events[0].Before).To(HaveKeyWithValue("git_branch", "main"))
+		Expect(events[0].After).To(BeNil())
+	})
+
+	It("should record the fields a test run update changed", func() {
+		req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/testrun/%d", testRun.ID), strings.NewReader(`{"status": "passed"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		var events []models.AuditEvent
+		Expect(db.Find(&events).Error).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].ProjectID).To(Equal("project-uuid"))
+		Expect(events[0].Changes).To(Equal(map[string]models.AuditChange{
+			"status": {Before: "failed", After: "passed"},
+		}))
+	})
+})
+
+var _ = Describe("TestRun Handler organizations", func() {
+	var (
+		router     *gin.Engine
+		db         *gorm.DB
+		defaultRun models.TestRun
+		retailRun  models.TestRun
+	)
+
+	request := func(method string, path string, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		db, _ = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		Expect(db.AutoMigrate(&models.TestRun{}, &models.ProjectDetails{}, &models.SuiteRun{}, &models.SpecRun{}, &models.Tag{})).To(Succeed())
+
+		cart := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&cart).Error).NotTo(HaveOccurred())
+		retailCart := models.ProjectDetails{Name: "Cart", OrganizationID: 2}
+		Expect(db.Create(&retailCart).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "retail-cart-uuid", retailCart.ID).Error).NotTo(HaveOccurred())
+
+		defaultRun = models.TestRun{ProjectID: cart.ID, GitBranch: "main"}
+		Expect(db.Create(&defaultRun).Error).NotTo(HaveOccurred())
+		retailRun = models.TestRun{ProjectID: retailCart.ID, GitBranch: "retail"}
+		Expect(db.Create(&retailRun).Error).NotTo(HaveOccurred())
+
+		handler := handlers.NewHandler(db)
+		router = gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Request = c.Request.WithContext(auth.WithOrganization(c.Request.Context(), models.DefaultOrganizationID))
+		})
+		router.POST("/api/testrun", handler.CreateTestRun)
+		router.GET("/api/testrun/", handler.GetTestRunAll)
+		router.GET("/api/testrun/:id", handler.GetTestRunByID)
+		router.PUT("/api/testrun/:id", handler.UpdateTestRun)
+		router.DELETE("/api/testrun/:id", handler.DeleteTestRun)
+	})
+
+	It("should only list the runs of the caller's organization", func() {
+		w := request("GET", "/api/testrun/", "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var testRuns []models.TestRun
+		Expect(json.Unmarshal(w.Body.Bytes(), &testRuns)).To(Succeed())
+		Expect(testRuns).To(HaveLen(1))
+		Expect(testRuns[0].ID).To(Equal(defaultRun.ID))
+	})
+
+	It("should not read, delete or upload to runs of other organizations", func() {
+		var testRun models.TestRun
+		Expect(json.Unmarshal(request("GET", fmt.Sprintf("/api/testrun/%d", retailRun.ID), "").Body.Bytes(), &testRun)).To(Succeed())
+		Expect(testRun.ID).To(BeZero())
+
+		Expect(request("DELETE", fmt.Sprintf("/api/testrun/%d", retailRun.ID), "").Code).To(Equal(http.StatusNotFound))
+		Expect(db.First(&models.TestRun{}, retailRun.ID).Error).NotTo(HaveOccurred())
+
+		Expect(request("POST", "/api/testrun", `{"test_project_id": "retail-cart-uuid"}`).Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should not let an update move a run to another project or run", func() {
+		body := fmt.Sprintf(`{"id": %d, "project_id": %d, "git_branch": "moved"}`, retailRun.ID, retailRun.ProjectID)
+		Expect(request("PUT", fmt.Sprintf("/api/testrun/%d", defaultRun.ID), body).Code).To(Equal(http.StatusOK))
+
+		var updated, untouched models.TestRun
+		Expect(db.First(&updated, defaultRun.ID).Error).NotTo(HaveOccurred())
+		Expect(updated.ProjectID).To(Equal(defaultRun.ProjectID))
+		Expect(updated.GitBranch).To(Equal("moved"))
+		Expect(db.First(&untouched, retailRun.ID).Error).NotTo(HaveOccurred())
+		Expect(untouched.GitBranch).To(Equal("retail"))
+	})
+
+	It("should not let an update move suite runs over from another run", func() {
+		retailSuite := models.SuiteRun{TestRunID: retailRun.ID, SuiteName: "Checkout"}
+		Expect(db.Create(&retailSuite).Error).NotTo(HaveOccurred())
+
+		body := fmt.Sprintf(`{"suite_runs": [{"id": %d, "suite_name": "Moved"}]}`, retailSuite.ID)
+		Expect(request("PUT", fmt.Sprintf("/api/testrun/%d", defaultRun.ID), body).Code).To(Equal(http.StatusOK))
+
+		var untouched models.SuiteRun
+		Expect(db.First(&untouched, retailSuite.ID).Error).NotTo(HaveOccurred())
+		Expect(untouched.TestRunID).To(Equal(retailRun.ID))
+		Expect(untouched.SuiteName).To(Equal("Checkout"))
+	})
+
+	It("should reject an update with an invalid body", func() {
+		Expect(request("PUT", fmt.Sprintf("/api/testrun/%d", defaultRun.ID), `{"git_branch": `).Code).To(Equal(http.StatusBadRequest))
+
+		var unchanged models.TestRun
+		Expect(db.First(&unchanged, defaultRun.ID).Error).NotTo(HaveOccurred())
+		Expect(unchanged.GitBranch).To(Equal("main"))
+	})
+})
+
+var _ = Describe("TestRun Handler project scopes", func() {
+	var (
+		router    *gin.Engine
+		db        *gorm.DB
+		searchRun models.TestRun
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		db = setupTestDB()
+
+		cart := models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&cart).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "cart-uuid", cart.ID).Error).NotTo(HaveOccurred())
+		search := models.ProjectDetails{Name: "Search"}
+		Expect(db.Create(&search).Error).NotTo(HaveOccurred())
+
+		searchRun = models.TestRun{ProjectID: search.ID, GitBranch: "main"}
+		Expect(db.Create(&searchRun).Error).NotTo(HaveOccurred())
+
+		router = gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("scope", []interface{}{"fern.write", "fernproject.Cart"})
+		})
+		router.Use(auth.ScopeMiddleware(auth.NewDefaultProjectLookup(db)))
+		router.POST("/api/testrun", handlers.NewHandler(db).CreateTestRun)
+	})
+
+	It("should not let an upload overwrite a run of another project", func() {
+		body := fmt.Sprintf(`{"id": %d, "test_project_id": "cart-uuid", "git_branch": "overwritten"}`, searchRun.ID)
+		req, _ := http.NewRequest("POST", "/api/testrun", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+
+		var untouched models.TestRun
+		Expect(db.First(&untouched, searchRun.ID).Error).NotTo(HaveOccurred())
+		Expect(untouched.ProjectID).To(Equal(searchRun.ProjectID))
+		Expect(untouched.GitBranch).To(Equal("main"))
+	})
+})
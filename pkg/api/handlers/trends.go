@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const defaultTrendDays = 30
+
+// trendIntervals lists the date_trunc fields a trend can be bucketed by.
+var trendIntervals = []string{"day", "week"}
+
+// TrendOptions selects the runs of a project that a trend covers and how they
+// are bucketed.
+type TrendOptions struct {
+	// Interval is "day" or "week".
+	Interval string
+	From     time.Time
+	To       time.Time
+	// Branch limits the trend to runs of one branch when set.
+	Branch string
+}
+
+type trendRow struct {
+	Bucket             time.Time
+	Runs               int
+	Total              int64
+	Passed             int64
+	Failed             int64
+	Skipped            int64
+	P50DurationSeconds *float64
+	P95DurationSeconds *float64
+}
+
+// FindProjectTrends buckets the runs of a project by start time and returns
+// spec counts, pass rate and run duration percentiles per bucket, oldest
+// first. Buckets without runs are omitted. Runs that have not ended are
+// counted but do not contribute to the duration percentiles.
+func FindProjectTrends(db *gorm.DB, projectID uint64, options TrendOptions) ([]models.TrendBucket, error) {
+	filterRuns := func(query *gorm.DB) *gorm.DB {
+		query = query.Where("test_runs.project_id = ? AND test_runs.start_time >= ? AND test_runs.start_time < ?",
+			projectID, options.From, options.To)
+		if options.Branch != "" {
+			query = query.Where("test_runs.git_branch = ?", options.Branch)
+		}
+		return query
+	}
+
+	runs := filterRuns(db.Table("test_runs").
+		Select("test_runs.id, date_trunc(?, test_runs.start_time) AS bucket, "+
+			"CASE WHEN test_runs.end_time > test_runs.start_time "+
+			"THEN EXTRACT(EPOCH FROM (test_runs.end_time - test_runs.start_time)) END AS duration_seconds", options.Interval))
+
+	specs := filterRuns(db.Table("spec_runs").
+		Select("suite_runs.test_run_id, COUNT(*) AS total, "+
+			"COUNT(*) FILTER (WHERE spec_runs.status = ?) AS passed, "+
+			"COUNT(*) FILTER (WHERE spec_runs.status IN ?) AS failed, "+
+			"COUNT(*) FILTER (WHERE spec_runs.status = ?) AS skipped",
+			utils.StatusPassed, utils.FailedStatuses, utils.StatusSkipped).
+		Joins("JOIN suite_runs ON suite_runs.id = spec_runs.suite_id").
+		Joins("JOIN test_runs ON test_runs.id = suite_runs.test_run_id")).
+		Group("suite_runs.test_run_id")
+
+	var rows []trendRow
+	if err := db.Table("(?) AS runs", runs).
+		Select("runs.bucket, COUNT(*) AS runs, "+
+			"COALESCE(SUM(specs.total), 0) AS total, COALESCE(SUM(specs.passed), 0) AS passed, "+
+			"COALESCE(SUM(specs.failed), 0) AS failed, COALESCE(SUM(specs.skipped), 0) AS skipped, "+
+			"percentile_cont(0.5) WITHIN GROUP (ORDER BY runs.duration_seconds) AS p50_duration_seconds, "+
+			"percentile_cont(0.95) WITHIN GROUP (ORDER BY runs.duration_seconds) AS p95_duration_seconds").
+		Joins("LEFT JOIN (?) AS specs ON specs.test_run_id = runs.id", specs).
+		Group("runs.bucket").
+		Order("runs.bucket").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	buckets := make([]models.TrendBucket, 0, len(rows))
+	for _, row := range rows {
+		bucket := models.TrendBucket{
+			Start:         row.Bucket,
+			Runs:          row.Runs,
+			Total:         row.Total,
+			Passed:        row.Passed,
+			Failed:        row.Failed,
+			Skipped:       row.Skipped,
+			P50DurationMs: secondsToMs(row.P50DurationSeconds),
+			P95DurationMs: secondsToMs(row.P95DurationSeconds),
+		}
+		if decided := row.Passed + row.Failed; decided > 0 {
+			passRate := float64(row.Passed) / float64(decided)
+			bucket.PassRate = &passRate
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, nil
+}
+
+func secondsToMs(seconds *float64) *int64 {
+	if seconds == nil {
+		return nil
+	}
+	ms := int64(math.Round(*seconds * 1000))
+	return &ms
+}
+
+// GetProjectTrends returns the pass rate and run duration trend of the project
+// with the given UUID. Optional query parameters: interval (day or week,
+// default day), from and to (2006-01-02T15:04:05, defaulting to the last 30
+// days) and branch.
+func (h *Handler) GetProjectTrends(c *gin.Context) {
+	projectUUID := c.Param("projectUUID")
+
+	interval := c.DefaultQuery("interval", "day")
+	if !slices.Contains(trendIntervals, interval) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid interval parameter: %q, expected day or week", interval)})
+		return
+	}
+	now := time.Now()
+	from, err := ParseTimeFromStringWithDefault(c.Query("from"), now.AddDate(0, 0, -defaultTrendDays))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid from parameter: %q", c.Query("from"))})
+		return
+	}
+	to, err := ParseTimeFromStringWithDefault(c.Query("to"), now)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid to parameter: %q", c.Query("to"))})
+		return
+	}
+
+	projectID, err := getProjectIDByUUID(c, h.db, projectUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Project ID %s not found", projectUUID)})
+		return
+	}
+
+	buckets, err := FindProjectTrends(h.db, projectID, TrendOptions{
+		Interval: interval,
+		From:     from,
+		To:       to,
+		Branch:   c.Query("branch"),
+	})
+	if err != nil {
+		log.Printf("error computing trends for project %s: %v", projectUUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error computing trends"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id": projectUUID,
+		"interval":   interval,
+		"from":       from,
+		"to":         to,
+		"branch":     c.Query("branch"),
+		"buckets":    buckets,
+	})
+}
@@ -0,0 +1,103 @@
+package handlers_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Project trends", func() {
+	var (
+		db     *sql.DB
+		mock   sqlmock.Sqlmock
+		router *gin.Engine
+	)
+
+	projectQuery := regexp.QuoteMeta(`SELECT * FROM "project_details" WHERE uuid = $1 ORDER BY "project_details"."id" LIMIT $2`)
+	trendQuery := `SELECT runs.bucket, COUNT\(\*\) AS runs, .*percentile_cont\(0.5\) WITHIN GROUP \(ORDER BY runs.duration_seconds\) AS p50_duration_seconds, ` +
+		`percentile_cont\(0.95\) WITHIN GROUP \(ORDER BY runs.duration_seconds\) AS p95_duration_seconds ` +
+		`FROM \(SELECT test_runs.id, date_trunc\(\$1, test_runs.start_time\) AS bucket, .* AND test_runs.git_branch = \$5\) AS runs ` +
+		`LEFT JOIN \(SELECT suite_runs.test_run_id, .*\) AS specs ON specs.test_run_id = runs.id GROUP BY "runs"."bucket" ORDER BY runs.bucket`
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	BeforeEach(func() {
+		db, mock, _ = sqlmock.New()
+		gormDb, _ := gorm.Open(postgres.New(postgres.Config{
+			DSN:                  "sqlmock_db_0",
+			DriverName:           "postgres",
+			Conn:                 db,
+			PreferSimpleProtocol: true,
+		}), &gorm.Config{})
+
+		gin.SetMode(gin.TestMode)
+		router = gin.New()
+		router.GET("/api/reports/trends/:projectUUID", handlers.NewHandler(gormDb).GetProjectTrends)
+	})
+
+	AfterEach(func() {
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		_ = db.Close()
+	})
+
+	It("returns one bucket per interval with pass rate and duration percentiles", func() {
+		monday := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(projectQuery).
+			WithArgs("project-uuid", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "uuid"}).AddRow(7, "project-uuid"))
+		mock.ExpectQuery(trendQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"bucket", "runs", "total", "passed", "failed", "skipped", "p50_duration_seconds", "p95_duration_seconds"}).
+				AddRow(monday, 3, 30, 24, 3, 3, 61.5, 120.25).
+				AddRow(monday.AddDate(0, 0, 7), 1, 0, 0, 0, 0, nil, nil))
+
+		w := get("/api/reports/trends/project-uuid?interval=week&branch=main&from=2025-06-01T00:00:00&to=2025-06-15T00:00:00")
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		var response struct {
+			Interval string               `json:"interval"`
+			Buckets  []models.TrendBucket `json:"buckets"`
+		}
+		Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+		Expect(response.Interval).To(Equal("week"))
+		Expect(response.Buckets).To(HaveLen(2))
+
+		first := response.Buckets[0]
+		Expect(first.Start).To(Equal(monday))
+		Expect(first.Runs).To(Equal(3))
+		Expect(first.Total).To(Equal(int64(30)))
+		Expect(*first.PassRate).To(BeNumerically("~", 24.0/27.0))
+		Expect(*first.P50DurationMs).To(Equal(int64(61500)))
+		Expect(*first.P95DurationMs).To(Equal(int64(120250)))
+
+		Expect(response.Buckets[1].PassRate).To(BeNil())
+		Expect(response.Buckets[1].P50DurationMs).To(BeNil())
+	})
+
+	It("rejects invalid parameters and unknown projects", func() {
+		Expect(get("/api/reports/trends/project-uuid?interval=month").Code).To(Equal(http.StatusBadRequest))
+		Expect(get("/api/reports/trends/project-uuid?from=yesterday").Code).To(Equal(http.StatusBadRequest))
+
+		mock.ExpectQuery(projectQuery).
+			WithArgs("unknown", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "uuid"}))
+		Expect(get("/api/reports/trends/unknown").Code).To(Equal(http.StatusNotFound))
+	})
+})
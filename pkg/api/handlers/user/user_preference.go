@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/audit"
+	"github.com/guidewire/fern-reporter/pkg/auth"
 	"github.com/guidewire/fern-reporter/pkg/models"
 	"github.com/guidewire/fern-reporter/pkg/utils"
 	"gorm.io/gorm"
@@ -76,7 +78,7 @@ func (h *UserHandler) SaveFavouriteProject(c *gin.Context) {
 	}
 
 	var project models.ProjectDetails
-	if err := h.db.Where("uuid = ?", favouriteRequest.Favourite).First(&project).Error; err != nil {
+	if err := auth.ScopeProjects(c, h.db.Where("uuid = ?", favouriteRequest.Favourite)).First(&project).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Project id %s not found", favouriteRequest.Favourite)})
 		return
 	}
@@ -103,7 +105,10 @@ func (h *UserHandler) SaveFavouriteProject(c *gin.Context) {
 			return
 		}
 		log.Printf("Saved favourite project %s, for the user cookie %s", project.UUID, ucookie)
-
+		audit.Target(c, audit.TargetUser, user.ID, project.UUID)
+		audit.After(c, gin.H{"favourite": project.UUID})
+	} else {
+		audit.Skip(c)
 	}
 	c.JSON(http.StatusCreated, gin.H{
 		"status": "success",
@@ -121,7 +126,7 @@ func (h *UserHandler) DeleteFavouriteProject(c *gin.Context) {
 	}
 
 	var project models.ProjectDetails
-	if err := h.db.Where("uuid = ?", projectUUID).First(&project).Error; err != nil {
+	if err := auth.ScopeProjects(c, h.db.Where("uuid = ?", projectUUID)).First(&project).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Project %s not found", projectUUID)})
 		return
 	}
@@ -138,6 +143,8 @@ func (h *UserHandler) DeleteFavouriteProject(c *gin.Context) {
 		return
 	}
 	log.Printf("favourite project %s deleted successfully for the user cookie %s", project.UUID, ucookie)
+	audit.Target(c, audit.TargetUser, user.ID, project.UUID)
+	audit.Before(c, gin.H{"favourite": project.UUID})
 	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Favourite Project %s deleted successfully", project.UUID)})
 }
 
@@ -151,10 +158,11 @@ func (h *UserHandler) GetFavouriteProject(c *gin.Context) {
     }
 
     var uuids []string
-    err = h.db.
+    query := h.db.
         Table("preferred_projects").
         Joins("JOIN project_details ON preferred_projects.project_id = project_details.id").
-        Where("preferred_projects.user_id = ? AND preferred_projects.group_id IS NULL", user.ID).
+        Where("preferred_projects.user_id = ? AND preferred_projects.group_id IS NULL", user.ID)
+    err = auth.ScopeProjects(c, query).
         Pluck("project_details.uuid", &uuids).Error
 
     if err != nil {
@@ -176,10 +184,12 @@ func (h *UserHandler) SaveUserPreference(c *gin.Context) {
 	}
 
 	// Check if user exists
-	_, err := GetUserObject(h, ucookie)
+	user, err := GetUserObject(h, ucookie)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("User ID not found: %v", err)})
 	}
+	audit.Target(c, audit.TargetUser, user.ID, "")
+	audit.Before(c, gin.H{"is_dark": user.IsDark, "timezone": user.Timezone})
 
 	// Save Preference to DB
 	result := h.db.Model(&models.AppUser{}).
@@ -202,6 +212,12 @@ func (h *UserHandler) SaveUserPreference(c *gin.Context) {
 	}
 
 	log.Printf("user preference updated for the cookie %s", ucookie)
+	if audit.Recording(c) {
+		var updated models.AppUser
+		if err := h.db.Where("cookie = ?", ucookie).First(&updated).Error; err == nil {
+			audit.After(c, gin.H{"is_dark": updated.IsDark, "timezone": updated.Timezone})
+		}
+	}
 	c.JSON(http.StatusAccepted, gin.H{
 		"status": "success",
 	})
@@ -266,6 +282,8 @@ func (h *UserHandler) SavePreferredProject(c *gin.Context) {
 
 	// 3. Prepare all new preferred entries
 	var preferredEntries []models.PreferredProject
+	var savedGroupIDs []uint64
+	var savedProjects []string
 
 	for _, group := range preferredRequest.Preferred {
 		var groupModel models.ProjectGroup
@@ -289,13 +307,15 @@ func (h *UserHandler) SavePreferredProject(c *gin.Context) {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch group"})
 			return
 		}
+		savedGroupIDs = append(savedGroupIDs, groupModel.GroupID)
 
 		for _, projectUUID := range group.Projects {
 			var project models.ProjectDetails
-			if err := tx.Where("uuid = ?", projectUUID).First(&project).Error; err != nil {
+			if err := auth.ScopeProjects(c, tx.Where("uuid = ?", projectUUID)).First(&project).Error; err != nil {
 				// Optionally skip or log; skipping here
 				continue
 			}
+			savedProjects = append(savedProjects, project.UUID)
 
 			preferredEntries = append(preferredEntries, models.PreferredProject{
 				UserID:    user.ID,
@@ -321,6 +341,8 @@ func (h *UserHandler) SavePreferredProject(c *gin.Context) {
 	}
 
 	log.Printf("Preferred project updated for the Group Ids %v", groupIDs)
+	audit.Target(c, audit.TargetUser, user.ID, "")
+	audit.After(c, gin.H{"group_ids": savedGroupIDs, "projects": savedProjects})
 	c.JSON(http.StatusCreated, gin.H{"status": "success"})
 }
 
@@ -340,9 +362,10 @@ func (h *UserHandler) GetPreferredProject(c *gin.Context) {
 
 	// 2. Get preferred projects with their group and project details
 	var preferred []models.PreferredProject
-	err := h.db.Preload("Project").
+	query := h.db.Preload("Project").
 		Preload("Group").
-		Where("user_id = ?", user.ID).
+		Where("user_id = ?", user.ID)
+	err := auth.ScopeProjectRows(c, query, "preferred_projects.project_id").
 		Find(&preferred).Error
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "error fetching preferences"})
@@ -436,6 +459,8 @@ func (h *UserHandler) DeletePreferredProject(c *gin.Context) {
 	}
 
 	log.Printf("Deleted preferred project updated for the Group Ids %v", groupIDs)
+	audit.Target(c, audit.TargetUser, user.ID, "")
+	audit.Before(c, gin.H{"group_ids": groupIDs})
 	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
 }
 
@@ -3,9 +3,12 @@ package routers
 import (
 	"github.com/guidewire/fern-reporter/config"
 	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/api/handlers/admin"
+	"github.com/guidewire/fern-reporter/pkg/api/handlers/gate"
 	"github.com/guidewire/fern-reporter/pkg/api/handlers/project"
 	"github.com/guidewire/fern-reporter/pkg/api/handlers/summary"
 	"github.com/guidewire/fern-reporter/pkg/api/handlers/user"
+	"github.com/guidewire/fern-reporter/pkg/audit"
 	"github.com/guidewire/fern-reporter/pkg/auth"
 	"github.com/guidewire/fern-reporter/pkg/db"
 
@@ -32,25 +35,37 @@ func RegisterRouters(router *gin.Engine) {
 	userHandler := user.NewUserHandler(db.GetDb())
 	projectHandler := project.NewProjectHandler(db.GetDb())
 	summaryHandler := summary.NewSummaryHandler(db.GetDb())
+	gateHandler := gate.NewGateHandler(db.GetDb())
+	adminHandler := admin.NewAdminHandler(db.GetDb())
 
 	authEnabled := config.GetAuth().Enabled
+	projectLookup := auth.NewDefaultProjectLookup(db.GetDb())
 
 	var api *gin.RouterGroup
 	if authEnabled {
-		api = router.Group("/api", auth.ScopeMiddleware())
+		api = router.Group("/api", auth.ScopeMiddleware(projectLookup))
 	} else {
 		api = router.Group("/api")
 	}
 
-	api.Use()
+	api.Use(audit.Middleware(audit.NewDefaultRecorder(db.GetDb())))
 	{
 		testRun = api.Group("/testrun/")
 		testRun.GET("/", handler.GetTestRunAll)
 		testRun.GET("/:id", handler.GetTestRunByID)
 		testRun.POST("/", handler.CreateTestRun)
+		testRun.POST("/junit", handler.CreateTestRunFromJUnit)
+		testRun.POST("/gotest", handler.CreateTestRunFromGoTest)
+		testRun.POST("/ginkgo", handler.CreateTestRunFromGinkgo)
+		testRun.POST("/open", handler.OpenTestRun)
+		testRun.POST("/:id/suites", handler.AppendSuiteRuns)
+		testRun.POST("/:id/close", handler.CloseTestRun)
 		testRun.PUT("/:id", handler.UpdateTestRun)
 		testRun.DELETE("/:id", handler.DeleteTestRun)
 
+		ingest := api.Group("/ingest")
+		ingest.GET("/jobs/:id", handler.GetIngestJob)
+
 		testReport := api.Group("/reports")
 		testReport.GET("/projects/", projectHandler.GetAllProjectsForReport)
 		testReport.GET("/summary/:projectId/", handler.GetTestSummary)
@@ -58,6 +73,20 @@ func RegisterRouters(router *gin.Engine) {
 		testReport.GET("/testruns/", handler.ReportTestRunAll)
 		testReport.GET("/testruns", handler.ReportTestRunAll)
 		testReport.GET("/testruns/:id/", handler.ReportTestRunById)
+		testReport.GET("/testruns/:id/failures", handler.GetTestRunFailureClusters)
+		testReport.GET("/testruns/:id/diff/:baseId", handler.GetTestRunDiff)
+		testReport.GET("/testruns/:id/regressions", handler.GetTestRunRegressions)
+		testReport.GET("/flaky/:projectUUID", handler.GetFlakySpecs)
+		testReport.GET("/order-dependent/:projectUUID", handler.GetOrderDependentSpecs)
+		testReport.GET("/tests/:fingerprint/history", handler.GetTestHistory)
+		testReport.GET("/tests/:fingerprint/culprit", handler.GetCulprit)
+		testReport.GET("/failures/:projectUUID", handler.GetProjectFailureClusters)
+		testReport.GET("/trends/:projectUUID", handler.GetProjectTrends)
+		testReport.GET("/durations/:projectUUID", handler.GetSpecDurationLeaderboard)
+		testReport.GET("/durations/:projectUUID/slow", handler.GetSlowSpecs)
+		testReport.GET("/duration-regressions/:projectUUID", handler.GetDurationRegressions)
+		testReport.GET("/testruns/:id/duration-regressions", handler.GetTestRunDurationRegressions)
+		testReport.GET("/health", handler.GetHealthReport)
 
 		// Project
 		project := api.Group("/project")
@@ -65,6 +94,17 @@ func RegisterRouters(router *gin.Engine) {
 		project.POST("", projectHandler.CreateProject)
 		project.PUT("/:uuid", projectHandler.UpdateProject)
 		project.DELETE("/:uuid", projectHandler.DeleteProject)
+		project.GET("/:uuid/tests", projectHandler.GetProjectTests)
+		project.GET("/:uuid/keys", projectHandler.GetAPIKeys)
+		project.POST("/:uuid/keys", projectHandler.CreateAPIKey)
+		project.DELETE("/:uuid/keys/:id", projectHandler.RevokeAPIKey)
+
+		// Quality Gates
+		gates := api.Group("/gates")
+		gates.GET("/:projectUUID", gateHandler.GetGate)
+		gates.PUT("/:projectUUID", gateHandler.SaveGate)
+		gates.DELETE("/:projectUUID", gateHandler.DeleteGate)
+		gates.POST("/:projectUUID/evaluate/:testRunId", gateHandler.EvaluateGate)
 
 		// User Preference
 		user := api.Group("/user")
@@ -77,11 +117,19 @@ func RegisterRouters(router *gin.Engine) {
 		user.GET("/preferred", userHandler.GetPreferredProject)
 		user.DELETE("/preferred", userHandler.DeletePreferredProject)
 
+		// Administration
+		admin := api.Group("/admin")
+		admin.GET("/roles", adminHandler.GetRoleBindings)
+		admin.POST("/roles", adminHandler.CreateRoleBinding)
+		admin.DELETE("/roles/:id", adminHandler.DeleteRoleBinding)
+		admin.GET("/audit", adminHandler.GetAuditEvents)
+		admin.GET("/organizations", adminHandler.GetOrganizations)
+		admin.POST("/organizations", adminHandler.CreateOrganization)
 	}
 
 	var reports *gin.RouterGroup
 	if authEnabled {
-		reports = router.Group("/reports/testruns", auth.ScopeMiddleware())
+		reports = router.Group("/reports/testruns", auth.ScopeMiddleware(projectLookup))
 	} else {
 		reports = router.Group("/reports/testruns")
 	}
@@ -90,11 +138,24 @@ func RegisterRouters(router *gin.Engine) {
 	{
 		reports.GET("/", handler.ReportTestRunAllHTML)
 		reports.GET("/:id", handler.ReportTestRunByIdHTML)
+		reports.GET("/:id/diff/:baseId", handler.ReportTestRunDiffHTML)
+	}
+
+	var health *gin.RouterGroup
+	if authEnabled {
+		health = router.Group("/reports/health", auth.ScopeMiddleware(projectLookup))
+	} else {
+		health = router.Group("/reports/health")
+	}
+
+	health.Use()
+	{
+		health.GET("/", handler.ReportHealthHTML)
 	}
 
 	var ping *gin.RouterGroup
 	if authEnabled {
-		ping = router.Group("/ping", auth.ScopeMiddleware())
+		ping = router.Group("/ping", auth.ScopeMiddleware(projectLookup))
 	} else {
 		ping = router.Group("/ping")
 	}
@@ -12,6 +12,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/guidewire/fern-reporter/config"
 	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/api/handlers/admin"
+	"github.com/guidewire/fern-reporter/pkg/api/handlers/gate"
 	"github.com/guidewire/fern-reporter/pkg/api/handlers/user"
 	"github.com/guidewire/fern-reporter/pkg/api/routers"
 	. "github.com/onsi/ginkgo/v2"
@@ -52,6 +54,8 @@ var _ = Describe("RegisterRouters", func() {
 			handler := handlers.NewHandler(gormDb)
 			userHandler := user.NewUserHandler(gormDb)
 			projectHandler := project.NewProjectHandler(gormDb)
+			gateHandler := gate.NewGateHandler(gormDb)
+			adminHandler := admin.NewAdminHandler(gormDb)
 
 			routers.RegisterRouters(router)
 
@@ -61,13 +65,51 @@ var _ = Describe("RegisterRouters", func() {
 			ExpectRoute(router, "GET", "/api/testrun/", handler.GetTestRunAll)
 			ExpectRoute(router, "GET", "/api/testrun/:id", handler.GetTestRunByID)
 			ExpectRoute(router, "POST", "/api/testrun/", handler.CreateTestRun)
+			ExpectRoute(router, "POST", "/api/testrun/junit", handler.CreateTestRunFromJUnit)
+			ExpectRoute(router, "POST", "/api/testrun/gotest", handler.CreateTestRunFromGoTest)
+			ExpectRoute(router, "POST", "/api/testrun/ginkgo", handler.CreateTestRunFromGinkgo)
+			ExpectRoute(router, "POST", "/api/testrun/open", handler.OpenTestRun)
+			ExpectRoute(router, "POST", "/api/testrun/:id/suites", handler.AppendSuiteRuns)
+			ExpectRoute(router, "POST", "/api/testrun/:id/close", handler.CloseTestRun)
 			ExpectRoute(router, "PUT", "/api/testrun/:id", handler.UpdateTestRun)
 			ExpectRoute(router, "DELETE", "/api/testrun/:id", handler.DeleteTestRun)
 
+			ExpectRoute(router, "GET", "/api/ingest/jobs/:id", handler.GetIngestJob)
+			ExpectRoute(router, "GET", "/api/reports/flaky/:projectUUID", handler.GetFlakySpecs)
+			ExpectRoute(router, "GET", "/api/reports/order-dependent/:projectUUID", handler.GetOrderDependentSpecs)
+			ExpectRoute(router, "GET", "/api/reports/tests/:fingerprint/history", handler.GetTestHistory)
+			ExpectRoute(router, "GET", "/api/reports/tests/:fingerprint/culprit", handler.GetCulprit)
+			ExpectRoute(router, "GET", "/api/reports/failures/:projectUUID", handler.GetProjectFailureClusters)
+			ExpectRoute(router, "GET", "/api/reports/trends/:projectUUID", handler.GetProjectTrends)
+			ExpectRoute(router, "GET", "/api/reports/durations/:projectUUID", handler.GetSpecDurationLeaderboard)
+			ExpectRoute(router, "GET", "/api/reports/durations/:projectUUID/slow", handler.GetSlowSpecs)
+			ExpectRoute(router, "GET", "/api/reports/duration-regressions/:projectUUID", handler.GetDurationRegressions)
+			ExpectRoute(router, "GET", "/api/reports/testruns/:id/duration-regressions", handler.GetTestRunDurationRegressions)
+			ExpectRoute(router, "GET", "/api/reports/health", handler.GetHealthReport)
+			ExpectRoute(router, "GET", "/api/reports/testruns/:id/failures", handler.GetTestRunFailureClusters)
+			ExpectRoute(router, "GET", "/api/reports/testruns/:id/diff/:baseId", handler.GetTestRunDiff)
+			ExpectRoute(router, "GET", "/api/reports/testruns/:id/regressions", handler.GetTestRunRegressions)
+
 			ExpectRoute(router, "GET", "/api/project", projectHandler.GetAllProjects)
 			ExpectRoute(router, "POST", "/api/project", projectHandler.CreateProject)
 			ExpectRoute(router, "PUT", "/api/project/:uuid", projectHandler.UpdateProject)
 			ExpectRoute(router, "DELETE", "/api/project/:uuid", projectHandler.DeleteProject)
+			ExpectRoute(router, "GET", "/api/project/:uuid/tests", projectHandler.GetProjectTests)
+			ExpectRoute(router, "GET", "/api/project/:uuid/keys", projectHandler.GetAPIKeys)
+			ExpectRoute(router, "POST", "/api/project/:uuid/keys", projectHandler.CreateAPIKey)
+			ExpectRoute(router, "DELETE", "/api/project/:uuid/keys/:id", projectHandler.RevokeAPIKey)
+
+			ExpectRoute(router, "GET", "/api/admin/roles", adminHandler.GetRoleBindings)
+			ExpectRoute(router, "POST", "/api/admin/roles", adminHandler.CreateRoleBinding)
+			ExpectRoute(router, "DELETE", "/api/admin/roles/:id", adminHandler.DeleteRoleBinding)
+			ExpectRoute(router, "GET", "/api/admin/audit", adminHandler.GetAuditEvents)
+			ExpectRoute(router, "GET", "/api/admin/organizations", adminHandler.GetOrganizations)
+			ExpectRoute(router, "POST", "/api/admin/organizations", adminHandler.CreateOrganization)
+
+			ExpectRoute(router, "GET", "/api/gates/:projectUUID", gateHandler.GetGate)
+			ExpectRoute(router, "PUT", "/api/gates/:projectUUID", gateHandler.SaveGate)
+			ExpectRoute(router, "DELETE", "/api/gates/:projectUUID", gateHandler.DeleteGate)
+			ExpectRoute(router, "POST", "/api/gates/:projectUUID/evaluate/:testRunId", gateHandler.EvaluateGate)
 
 			ExpectRoute(router, "POST", "/api/user/favourite", userHandler.SaveFavouriteProject)
 			ExpectRoute(router, "DELETE", "/api/user/favourite/:projectUUID", userHandler.DeleteFavouriteProject)
@@ -89,6 +131,8 @@ var _ = Describe("RegisterRouters", func() {
 			// Check if report routes are registered correctly
 			ExpectRoute(router, "GET", "/reports/testruns/", handler.ReportTestRunAllHTML)
 			ExpectRoute(router, "GET", "/reports/testruns/:id", handler.ReportTestRunByIdHTML)
+			ExpectRoute(router, "GET", "/reports/testruns/:id/diff/:baseId", handler.ReportTestRunDiffHTML)
+			ExpectRoute(router, "GET", "/reports/health/", handler.ReportHealthHTML)
 		})
 	})
 
@@ -110,6 +154,22 @@ var _ = Describe("RegisterRouters", func() {
 			ExpectRoute(router, "POST", "/api/testrun/", handler.CreateTestRun)
 			ExpectRoute(router, "PUT", "/api/testrun/:id", handler.UpdateTestRun)
 			ExpectRoute(router, "DELETE", "/api/testrun/:id", handler.DeleteTestRun)
+
+			ExpectRoute(router, "GET", "/api/ingest/jobs/:id", handler.GetIngestJob)
+			ExpectRoute(router, "GET", "/api/reports/flaky/:projectUUID", handler.GetFlakySpecs)
+			ExpectRoute(router, "GET", "/api/reports/order-dependent/:projectUUID", handler.GetOrderDependentSpecs)
+			ExpectRoute(router, "GET", "/api/reports/tests/:fingerprint/history", handler.GetTestHistory)
+			ExpectRoute(router, "GET", "/api/reports/tests/:fingerprint/culprit", handler.GetCulprit)
+			ExpectRoute(router, "GET", "/api/reports/failures/:projectUUID", handler.GetProjectFailureClusters)
+			ExpectRoute(router, "GET", "/api/reports/trends/:projectUUID", handler.GetProjectTrends)
+			ExpectRoute(router, "GET", "/api/reports/durations/:projectUUID", handler.GetSpecDurationLeaderboard)
+			ExpectRoute(router, "GET", "/api/reports/durations/:projectUUID/slow", handler.GetSlowSpecs)
+			ExpectRoute(router, "GET", "/api/reports/duration-regressions/:projectUUID", handler.GetDurationRegressions)
+			ExpectRoute(router, "GET", "/api/reports/testruns/:id/duration-regressions", handler.GetTestRunDurationRegressions)
+			ExpectRoute(router, "GET", "/api/reports/health", handler.GetHealthReport)
+			ExpectRoute(router, "GET", "/api/reports/testruns/:id/failures", handler.GetTestRunFailureClusters)
+			ExpectRoute(router, "GET", "/api/reports/testruns/:id/diff/:baseId", handler.GetTestRunDiff)
+			ExpectRoute(router, "GET", "/api/reports/testruns/:id/regressions", handler.GetTestRunRegressions)
 		})
 
 		It("should register report routes", func() {
@@ -126,6 +186,8 @@ var _ = Describe("RegisterRouters", func() {
 			// Check if report routes are registered correctly
 			ExpectRoute(router, "GET", "/reports/testruns/", handler.ReportTestRunAllHTML)
 			ExpectRoute(router, "GET", "/reports/testruns/:id", handler.ReportTestRunByIdHTML)
+			ExpectRoute(router, "GET", "/reports/testruns/:id/diff/:baseId", handler.ReportTestRunDiffHTML)
+			ExpectRoute(router, "GET", "/reports/health/", handler.ReportHealthHTML)
 		})
 	})
 })
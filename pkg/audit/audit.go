@@ -0,0 +1,231 @@
+// Package audit records the mutations made through the API in the append-only
+// audit_events table.
+//
+// Middleware records every successful POST, PUT, PATCH and DELETE request
+// with its caller and route. Handlers describe what they changed with Target,
+// Before and After; requests that turn out not to change anything, such as
+// replayed uploads, call Skip.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	ActorTypeUser      = "user"
+	ActorTypeAPIKey    = "api_key"
+	ActorTypeCookie    = "cookie"
+	ActorTypeAnonymous = "anonymous"
+
+	TargetTestRun      = "test_run"
+	TargetIngestJob    = "ingest_job"
+	TargetProject      = "project"
+	TargetAPIKey       = "api_key"
+	TargetQualityGate  = "quality_gate"
+	TargetUser         = "user"
+	TargetRoleBinding  = "role_binding"
+	TargetOrganization = "organization"
+
+	eventKey = "fernAuditEvent"
+)
+
+// Recorder interface for storing audit events
+type Recorder interface {
+	Record(ctx context.Context, event *models.AuditEvent) error
+}
+
+// DefaultRecorder struct for storing audit events in the database
+type DefaultRecorder struct {
+	db *gorm.DB
+}
+
+// NewDefaultRecorder creates a new Recorder
+func NewDefaultRecorder(db *gorm.DB) *DefaultRecorder {
+	return &DefaultRecorder{db: db}
+}
+
+func (r *DefaultRecorder) Record(ctx context.Context, event *models.AuditEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+// pendingEvent collects what a handler reports about its mutation.
+type pendingEvent struct {
+	targetType string
+	targetID   string
+	projectID  string
+	before     map[string]any
+	after      map[string]any
+	skip       bool
+}
+
+var mutatingMethods = map[string]bool{
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// Middleware Middleware for recording mutations. It must run after the auth
+// middlewares so that the caller is known. Requests that fail are not
+// recorded, and neither are those to routes that do not mutate anything,
+// which are listed in readOnlyRoutes. Errors storing an event are logged; the
+// mutation has already happened by then.
+func Middleware(recorder Recorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !mutatingMethods[c.Request.Method] || readOnlyRoutes[c.Request.Method+" "+c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		pending := &pendingEvent{}
+		c.Set(eventKey, pending)
+		c.Next()
+
+		status := c.Writer.Status()
+		if pending.skip || status >= http.StatusBadRequest || c.FullPath() == "" {
+			return
+		}
+
+		event := models.AuditEvent{
+			Method:     c.Request.Method,
+			Route:      c.FullPath(),
+			Path:       c.Request.URL.Path,
+			Status:     status,
+			TargetType: pending.targetType,
+			TargetID:   pending.targetID,
+			ProjectID:  pending.projectID,
+			Before:     pending.before,
+			After:      pending.after,
+			Changes:    diff(pending.before, pending.after),
+		}
+		event.ActorType, event.Actor = actor(c)
+		if organizationID, ok := auth.OrganizationFromContext(c); ok {
+			event.OrganizationID = organizationID
+		}
+		if err := recorder.Record(c.Request.Context(), &event); err != nil {
+			log.Printf("Failed to record audit event for %s %s: %v", event.Method, event.Path, err)
+		}
+	}
+}
+
+// readOnlyRoutes are "METHOD route" pairs that use a mutating method without
+// changing anything.
+var readOnlyRoutes = map[string]bool{
+	"POST /api/gates/:projectUUID/evaluate/:testRunId": true,
+}
+
+// actor identifies the caller by API key, JWT subject or user cookie, in that
+// order.
+func actor(c *gin.Context) (string, string) {
+	if id, ok := c.Get("apiKeyID"); ok {
+		return ActorTypeAPIKey, fmt.Sprint(id)
+	}
+	if subject := c.GetString("subject"); subject != "" {
+		return ActorTypeUser, subject
+	}
+	if cookie, err := c.Cookie(utils.CookieName); err == nil && cookie != "" {
+		return ActorTypeCookie, cookie
+	}
+	return ActorTypeAnonymous, ""
+}
+
+func pending(c *gin.Context) *pendingEvent {
+	value, ok := c.Get(eventKey)
+	if !ok {
+		return nil
+	}
+	return value.(*pendingEvent)
+}
+
+// Recording reports whether the request will be recorded, so that handlers
+// can skip the queries they only need for the audit event.
+func Recording(c *gin.Context) bool {
+	return pending(c) != nil
+}
+
+// Target sets the type and ID of the record a mutation changed, and the UUID
+// of its project when it belongs to one.
+func Target(c *gin.Context, targetType string, targetID any, projectUUID string) {
+	if event := pending(c); event != nil {
+		event.targetType = targetType
+		event.targetID = fmt.Sprint(targetID)
+		event.projectID = projectUUID
+	}
+}
+
+// Before takes a snapshot of the target before it is changed.
+func Before(c *gin.Context, value any) {
+	if event := pending(c); event != nil {
+		event.before = snapshot(value)
+	}
+}
+
+// After takes a snapshot of the target once it is changed.
+func After(c *gin.Context, value any) {
+	if event := pending(c); event != nil {
+		event.after = snapshot(value)
+	}
+}
+
+// Skip keeps the request from being recorded.
+func Skip(c *gin.Context) {
+	if event := pending(c); event != nil {
+		event.skip = true
+	}
+}
+
+// snapshot returns the fields of value as it is encoded to JSON. Lists of
+// nested records, such as the suite runs of a test run, are left out.
+func snapshot(value any) map[string]any {
+	if value == nil {
+		return nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("Failed to encode audit snapshot: %v", err)
+		return nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil
+	}
+	for key, field := range fields {
+		if list, ok := field.([]any); ok && slices.ContainsFunc(list, isRecord) {
+			delete(fields, key)
+		}
+	}
+	return fields
+}
+
+func isRecord(value any) bool {
+	_, ok := value.(map[string]any)
+	return ok
+}
+
+// diff returns the fields whose values differ between before and after.
+func diff(before map[string]any, after map[string]any) map[string]models.AuditChange {
+	changes := map[string]models.AuditChange{}
+	for key, value := range before {
+		if other, ok := after[key]; !ok || !reflect.DeepEqual(value, other) {
+			changes[key] = models.AuditChange{Before: value, After: after[key]}
+		}
+	}
+	for key, value := range after {
+		if _, ok := before[key]; !ok {
+			changes[key] = models.AuditChange{After: value}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,162 @@
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/audit"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+type failingRecorder struct{}
+
+func (failingRecorder) Record(context.Context, *models.AuditEvent) error {
+	return fmt.Errorf("error")
+}
+
+var _ = Describe("Middleware", func() {
+	var (
+		auditDb *gorm.DB
+		router  *gin.Engine
+	)
+
+	serve := func(method string, path string, prepare func(*http.Request)) int {
+		req, _ := http.NewRequest(method, path, nil)
+		if prepare != nil {
+			prepare(req)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	recorded := func() []models.AuditEvent {
+		var events []models.AuditEvent
+		Expect(auditDb.Order("id").Find(&events).Error).NotTo(HaveOccurred())
+		return events
+	}
+
+	BeforeEach(func() {
+		var err error
+		auditDb, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auditDb.AutoMigrate(&models.AuditEvent{})).To(Succeed())
+
+		gin.SetMode(gin.TestMode)
+		router = gin.New()
+		router.Use(func(c *gin.Context) {
+			if subject := c.GetHeader("X-Subject"); subject != "" {
+				c.Set("subject", subject)
+			}
+			if c.GetHeader("X-Api-Key-Id") != "" {
+				c.Set("apiKeyID", uint64(7))
+			}
+		})
+		router.Use(audit.Middleware(audit.NewDefaultRecorder(auditDb)))
+
+		router.PUT("/api/testrun/:id", func(c *gin.Context) {
+			before := models.TestRun{
+				ID:        1,
+				Status:    "failed",
+				GitBranch: "main",
+				SuiteRuns: []models.SuiteRun{{ID: 1, SuiteName: "suite"}},
+			}
+			after := before
+			after.Status = "passed"
+			audit.Target(c, audit.TargetTestRun, before.ID, "project-uuid")
+			audit.Before(c, before)
+			audit.After(c, after)
+			c.JSON(http.StatusOK, after)
+		})
+		router.DELETE("/api/testrun/:id", func(c *gin.Context) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "test run not found"})
+		})
+		router.POST("/api/testrun/", func(c *gin.Context) {
+			audit.Skip(c)
+			c.JSON(http.StatusOK, gin.H{})
+		})
+		router.GET("/api/testrun/", func(c *gin.Context) {
+			Expect(audit.Recording(c)).To(BeFalse())
+			c.JSON(http.StatusOK, gin.H{})
+		})
+		router.POST("/api/gates/:projectUUID/evaluate/:testRunId", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{})
+		})
+		router.POST("/api/user/favourite", func(c *gin.Context) {
+			c.JSON(http.StatusCreated, gin.H{})
+		})
+	})
+
+	It("should record the caller, route, target and changes of a mutation", func() {
+		Expect(serve("PUT", "/api/testrun/1", func(req *http.Request) {
+			req.Header.Set("X-Subject", "alice")
+		})).To(Equal(http.StatusOK))
+
+		events := recorded()
+		Expect(events).To(HaveLen(1))
+		event := events[0]
+		Expect(event.ActorType).To(Equal(audit.ActorTypeUser))
+		Expect(event.Actor).To(Equal("alice"))
+		Expect(event.Method).To(Equal("PUT"))
+		Expect(event.Route).To(Equal("/api/testrun/:id"))
+		Expect(event.Path).To(Equal("/api/testrun/1"))
+		Expect(event.Status).To(Equal(http.StatusOK))
+		Expect(event.TargetType).To(Equal(audit.TargetTestRun))
+		Expect(event.TargetID).To(Equal("1"))
+		Expect(event.ProjectID).To(Equal("project-uuid"))
+		Expect(event.CreatedAt).NotTo(BeZero())
+		Expect(event.Before).To(HaveKeyWithValue("status", "failed"))
+		Expect(event.Before).NotTo(HaveKey("suite_runs"))
+		Expect(event.After).To(HaveKeyWithValue("status", "passed"))
+		Expect(event.Changes).To(Equal(map[string]models.AuditChange{
+			"status": {Before: "failed", After: "passed"},
+		}))
+	})
+
+	It("should identify callers by API key before subject and by cookie last", func() {
+		serve("PUT", "/api/testrun/1", func(req *http.Request) {
+			req.Header.Set("X-Api-Key-Id", "7")
+			req.Header.Set("X-Subject", "alice")
+		})
+		serve("POST", "/api/user/favourite", func(req *http.Request) {
+			req.AddCookie(&http.Cookie{Name: utils.CookieName, Value: "cookie-1"})
+		})
+		serve("POST", "/api/user/favourite", nil)
+
+		events := recorded()
+		Expect(events).To(HaveLen(3))
+		Expect([]string{events[0].ActorType, events[0].Actor}).To(Equal([]string{audit.ActorTypeAPIKey, "7"}))
+		Expect([]string{events[1].ActorType, events[1].Actor}).To(Equal([]string{audit.ActorTypeCookie, "cookie-1"}))
+		Expect([]string{events[2].ActorType, events[2].Actor}).To(Equal([]string{audit.ActorTypeAnonymous, ""}))
+		Expect(events[1].Changes).To(BeEmpty())
+	})
+
+	It("should not record reads, failures, skipped requests or read only routes", func() {
+		Expect(serve("GET", "/api/testrun/", nil)).To(Equal(http.StatusOK))
+		Expect(serve("DELETE", "/api/testrun/1", nil)).To(Equal(http.StatusNotFound))
+		Expect(serve("POST", "/api/testrun/", nil)).To(Equal(http.StatusOK))
+		Expect(serve("POST", "/api/gates/project-uuid/evaluate/1", nil)).To(Equal(http.StatusOK))
+		Expect(serve("POST", "/api/unknown", nil)).To(Equal(http.StatusNotFound))
+
+		Expect(recorded()).To(BeEmpty())
+	})
+
+	It("should not fail the request when the event cannot be stored", func() {
+		router = gin.New()
+		router.Use(audit.Middleware(failingRecorder{}))
+		router.POST("/api/user/favourite", func(c *gin.Context) {
+			c.JSON(http.StatusCreated, gin.H{})
+		})
+
+		Expect(serve("POST", "/api/user/favourite", nil)).To(Equal(http.StatusCreated))
+	})
+})
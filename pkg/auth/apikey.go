@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"gorm.io/gorm"
+	"log"
+	"net/http"
+	"time"
+)
+
+const (
+	// APIKeyHeader is the header CI jobs send their project API key in.
+	APIKeyHeader = "X-Fern-Api-Key"
+	// APIKeyPrefixLength is the number of leading characters of a key that are
+	// kept in clear to tell keys apart.
+	APIKeyPrefixLength = 12
+
+	apiKeyTag = "fern_"
+)
+
+// ErrInvalidAPIKey is returned for keys that are unknown or revoked.
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// GenerateAPIKey returns a new random API key.
+func GenerateAPIKey() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return apiKeyTag + hex.EncodeToString(secret), nil
+}
+
+// HashAPIKey returns the hash an API key is stored and looked up by.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyVerifier interface for looking up the project API key a request was sent with
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (*models.ProjectAPIKey, error)
+}
+
+// DefaultAPIKeyVerifier struct for verifying API keys stored in the database
+type DefaultAPIKeyVerifier struct {
+	db *gorm.DB
+}
+
+// NewDefaultAPIKeyVerifier creates a new APIKeyVerifier
+func NewDefaultAPIKeyVerifier(db *gorm.DB) *DefaultAPIKeyVerifier {
+	return &DefaultAPIKeyVerifier{db: db}
+}
+
+// VerifyAPIKey returns the key, with its project, that hashes to the same value
+// as key. It returns ErrInvalidAPIKey when there is no such key or it was
+// revoked, and records when the key was last used otherwise.
+func (v *DefaultAPIKeyVerifier) VerifyAPIKey(ctx context.Context, key string) (*models.ProjectAPIKey, error) {
+	var apiKey models.ProjectAPIKey
+	err := v.db.WithContext(ctx).Preload("Project").
+		Where("key_hash = ? AND revoked_at IS NULL", HashAPIKey(key)).
+		First(&apiKey).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidAPIKey
+	} else if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := v.db.WithContext(ctx).Model(&apiKey).Update("last_used_at", now).Error; err != nil {
+		log.Printf("Failed to record use of api key %d: %v", apiKey.ID, err)
+	}
+	return &apiKey, nil
+}
+
+// APIKeyMiddleware Middleware for authenticating requests with a project API key.
+// Requests with an X-Fern-Api-Key header are given the same scope as a token
+// allowed to write to the key's project only, in the organization of that
+// project; all others are passed on to next, usually the JWTMiddleware.
+func APIKeyMiddleware(verifier APIKeyVerifier, next gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			next(c)
+			return
+		}
+
+		apiKey, err := verifier.VerifyAPIKey(c.Request.Context(), key)
+		if errors.Is(err, ErrInvalidAPIKey) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid api key"})
+			return
+		} else if err != nil {
+			log.Printf("Failed to verify api key: %v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to verify api key"})
+			return
+		}
+
+		c.Set("scope", []interface{}{"fern.write", FP + "." + apiKey.Project.Name})
+		c.Set("apiKeyID", apiKey.ID)
+		c.Set(organizationIDKey, apiKey.Project.OrganizationID)
+		c.Next()
+	}
+}
@@ -0,0 +1,151 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/auth/mocks"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("APIKeyMiddleware", func() {
+	var (
+		mockVerifier *mocks.APIKeyVerifier
+		mockLookup   *mocks.ProjectLookup
+		router       *gin.Engine
+		recorder     *httptest.ResponseRecorder
+		jwtCalled    bool
+	)
+
+	post := func(key string, body string) {
+		req, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(auth.APIKeyHeader, key)
+		}
+		router.ServeHTTP(recorder, req)
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		mockVerifier = new(mocks.APIKeyVerifier)
+		mockLookup = new(mocks.ProjectLookup)
+		mockLookup.On("ProjectNames", mock.Anything, mock.Anything).Return(func(_ context.Context, refs auth.ProjectRefs) []string {
+			return refs.Names
+		}, nil)
+		router = gin.New()
+		recorder = httptest.NewRecorder()
+		jwtCalled = false
+
+		jwtMiddleware := func(c *gin.Context) {
+			jwtCalled = true
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authorization header missing"})
+		}
+		router.Use(auth.APIKeyMiddleware(mockVerifier, jwtMiddleware))
+		router.Use(auth.ScopeMiddleware(mockLookup))
+		router.POST("/", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"message": "success"})
+		})
+	})
+
+	It("should hand requests without an API key to the next middleware", func() {
+		post("", `{"project": "project-a"}`)
+
+		Expect(jwtCalled).To(BeTrue())
+		Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+		mockVerifier.AssertNotCalled(GinkgoT(), "VerifyAPIKey", mock.Anything, mock.Anything)
+	})
+
+	It("should allow writes to the key's project", func() {
+		apiKey := &models.ProjectAPIKey{ID: 7, Project: models.ProjectDetails{Name: "project-a"}}
+		mockVerifier.On("VerifyAPIKey", mock.Anything, "valid_key").Return(apiKey, nil)
+
+		post("valid_key", `{"project": "project-a"}`)
+
+		Expect(jwtCalled).To(BeFalse())
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(ContainSubstring("success"))
+	})
+
+	It("should abort with 403 for writes to another project", func() {
+		apiKey := &models.ProjectAPIKey{ID: 7, Project: models.ProjectDetails{Name: "project-a"}}
+		mockVerifier.On("VerifyAPIKey", mock.Anything, "valid_key").Return(apiKey, nil)
+
+		post("valid_key", `{"project": "project-b"}`)
+
+		Expect(recorder.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should abort with 401 if the API key is invalid", func() {
+		mockVerifier.On("VerifyAPIKey", mock.Anything, "invalid_key").Return(nil, auth.ErrInvalidAPIKey)
+
+		post("invalid_key", `{"project": "project-a"}`)
+
+		Expect(jwtCalled).To(BeFalse())
+		Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should abort with 500 if the API key cannot be verified", func() {
+		mockVerifier.On("VerifyAPIKey", mock.Anything, "valid_key").Return(nil, fmt.Errorf("error"))
+
+		post("valid_key", `{"project": "project-a"}`)
+
+		Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
+
+var _ = Describe("DefaultAPIKeyVerifier", func() {
+	var (
+		db       *gorm.DB
+		verifier *auth.DefaultAPIKeyVerifier
+		key      string
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&models.ProjectDetails{}, &models.ProjectAPIKey{})).To(Succeed())
+
+		project := models.ProjectDetails{Name: "project-a"}
+		Expect(db.Create(&project).Error).NotTo(HaveOccurred())
+
+		key, err = auth.GenerateAPIKey()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(HavePrefix("fern_"))
+		apiKey := models.ProjectAPIKey{ProjectID: project.ID, Name: "ci", KeyHash: auth.HashAPIKey(key)}
+		Expect(db.Create(&apiKey).Error).NotTo(HaveOccurred())
+
+		verifier = auth.NewDefaultAPIKeyVerifier(db)
+	})
+
+	It("should return the key with its project and record its use", func() {
+		apiKey, err := verifier.VerifyAPIKey(context.Background(), key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apiKey.Name).To(Equal("ci"))
+		Expect(apiKey.Project.Name).To(Equal("project-a"))
+
+		var stored models.ProjectAPIKey
+		Expect(db.First(&stored, apiKey.ID).Error).NotTo(HaveOccurred())
+		Expect(stored.LastUsedAt).NotTo(BeNil())
+	})
+
+	It("should reject unknown and revoked keys", func() {
+		_, err := verifier.VerifyAPIKey(context.Background(), "fern_unknown")
+		Expect(err).To(MatchError(auth.ErrInvalidAPIKey))
+
+		Expect(db.Model(&models.ProjectAPIKey{}).Where("1 = 1").Update("revoked_at", gorm.Expr("CURRENT_TIMESTAMP")).Error).NotTo(HaveOccurred())
+		_, err = verifier.VerifyAPIKey(context.Background(), key)
+		Expect(err).To(MatchError(auth.ErrInvalidAPIKey))
+	})
+})
@@ -103,28 +103,99 @@ func JWTMiddleware(jwksUrl string, fetcher JWKSFetcher, validator JWTValidator)
 			return
 		}
 
+		// Tokens without scopes are accepted from identified users, who may
+		// have roles bound to them
 		authConfig := config.GetAuth()
-		scope, ok := token.PrivateClaims()[authConfig.ScopeClaimName].([]interface{})
-		if !ok || len(scope) == 0 {
+		scope, _ := token.PrivateClaims()[authConfig.ScopeClaimName].([]interface{})
+		if len(scope) == 0 && token.Subject() == "" {
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "scope claim is missing or empty"})
 			return
 		}
+		if scope == nil {
+			scope = []interface{}{}
+		}
 
 		c.Set("scope", scope)
+		c.Set("subject", token.Subject())
+		if groups, ok := token.PrivateClaims()[authConfig.GroupsClaimName].([]interface{}); ok {
+			c.Set("groups", convertToStringSlice(groups))
+		}
+		if organization, ok := token.PrivateClaims()[authConfig.OrganizationClaimName].(string); ok {
+			c.Set(organizationNameKey, organization)
+		}
 		c.Next()
 	}
 }
 
-type RequestBody struct {
-	Project string `json:"project" binding:"required"`
+const (
+	PermissionRead     = "fern.read"
+	PermissionWrite    = "fern.write"
+	PermissionMaintain = "fern.maintain"
+	PermissionAdmin    = "fern.admin"
+
+	adminRoutePrefix = "/api/admin/"
+)
+
+// permissionLevels orders the permissions; each one grants the ones below it.
+var permissionLevels = map[string]int{
+	PermissionRead:     1,
+	PermissionWrite:    2,
+	PermissionMaintain: 3,
+	PermissionAdmin:    4,
 }
 
-// ScopeMiddleware Middleware for checking if the user has the necessary scope for the request.
-func ScopeMiddleware() gin.HandlerFunc {
-	permissions := map[string]string{
-		"POST": "fern.write",
+// methodPermissions is the permission a request needs by method, unless its
+// route is listed in routePermissions.
+var methodPermissions = map[string]string{
+	http.MethodGet:    PermissionRead,
+	http.MethodHead:   PermissionRead,
+	http.MethodPost:   PermissionWrite,
+	http.MethodPut:    PermissionWrite,
+	http.MethodPatch:  PermissionWrite,
+	http.MethodDelete: PermissionWrite,
+}
+
+// routePermissions overrides methodPermissions for "METHOD route" pairs.
+var routePermissions = map[string]string{
+	"POST /api/project":                                PermissionAdmin,
+	"PUT /api/project/:uuid":                           PermissionMaintain,
+	"DELETE /api/project/:uuid":                        PermissionAdmin,
+	"POST /api/project/:uuid/keys":                     PermissionMaintain,
+	"DELETE /api/project/:uuid/keys/:id":               PermissionMaintain,
+	"PUT /api/gates/:projectUUID":                      PermissionMaintain,
+	"DELETE /api/gates/:projectUUID":                   PermissionMaintain,
+	"POST /api/gates/:projectUUID/evaluate/:testRunId": PermissionRead,
+	"POST /api/user/favourite":                         PermissionRead,
+	"DELETE /api/user/favourite/:projectUUID":          PermissionRead,
+	"PUT /api/user/preference":                         PermissionRead,
+	"POST /api/user/preferred":                         PermissionRead,
+	"DELETE /api/user/preferred":                       PermissionRead,
+	"POST /query":                                      PermissionRead,
+}
+
+// RequiredPermission returns the permission needed to call route with method,
+// or false when the method is not allowed.
+func RequiredPermission(method string, route string) (string, bool) {
+	if permission, ok := routePermissions[method+" "+route]; ok {
+		return permission, true
 	}
+	permission, ok := methodPermissions[method]
+	return permission, ok
+}
 
+// ScopeMiddleware Middleware for checking if the user has the necessary scope for the request.
+//
+// The fern.read, fern.write, fern.maintain and fern.admin scopes grant the
+// permission of the same name and the ones below it. Callers with fern.admin
+// or a fernproject.* scope hold their permission on every project; others
+// only on the projects named by their fernproject.<name> scopes. Roles set by RoleMiddleware add to
+// the scopes. The projects a request refers to are resolved from its path
+// parameters, query and JSON body, and the required permission must be held
+// on each of them. Writes that do not refer to a project need the permission
+// on every project. Reads that do not are let through, and handlers listing
+// data filter it with AllowedProjects. Routes under /api/admin need fern.admin
+// or the admin role bound to every project.
+func ScopeMiddleware(lookup ProjectLookup) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		scope, ok := c.Get("scope")
 		if !ok {
@@ -132,44 +203,122 @@ func ScopeMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		requiredPermission, ok := permissions[c.Request.Method]
+		requiredPermission, ok := RequiredPermission(c.Request.Method, c.FullPath())
 		if !ok {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "invalid method"})
 			return
 		}
+		required := permissionLevels[requiredPermission]
 
-		scopes := convertToStringSlice(scope.([]interface{}))
-
-		if !slices.Contains(scopes, requiredPermission) || !containsSubstring(scopes, FP) {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient scope"})
+		grants, err := grantsFromScopes(scopesFromClaim(scope))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
 		}
+		if roleGrants, ok := c.Get(roleGrantsKey); ok {
+			grants.merge(roleGrants.(Grants))
+		}
 
-		bodyBytes, err := readRequestBody(c)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Unable to read request body"})
+		if strings.HasPrefix(c.FullPath(), adminRoutePrefix) {
+			if !grants.Admin {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient scope"})
+				return
+			}
+			c.Next()
 			return
 		}
 
-		var requestBody RequestBody
-		if err := c.BindJSON(&requestBody); err != nil {
-			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		if grants.maxLevel() < required {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient scope"})
+			return
+		}
+		if grants.All >= required {
+			c.Next()
 			return
 		}
 
-		c.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-
-		fernProjectName, err := validateProjectName(scopes, requestBody.Project)
+		refs, err := projectRefsFromRequest(c)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "unable to read request body"})
+			return
+		}
+		var projects []string
+		if !refs.Empty() {
+			projects, err = lookup.ProjectNames(c.Request.Context(), refs)
+			if err != nil {
+				log.Printf("Failed to resolve projects of request: %v", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve project"})
+				return
+			}
+		}
+
+		if len(projects) == 0 && required > permissionLevels[PermissionRead] {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "unable to determine the project of the request"})
 			return
 		}
+		for _, project := range projects {
+			if grants.level(project) < required {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("insufficient scope for project %s", project)})
+				return
+			}
+		}
 
-		c.Set("fernProjectName", fernProjectName)
+		if grants.All < permissionLevels[PermissionRead] {
+			names := grants.projectsAt(permissionLevels[PermissionRead])
+			c.Request = c.Request.WithContext(WithAllowedProjects(c.Request.Context(), names))
+		}
+		if len(projects) == 1 {
+			c.Set("fernProjectName", projects[0])
+		}
 		c.Next()
 	}
 }
 
+// scopesFromClaim converts a scope claim, either a list or a space separated
+// string, to a slice of strings.
+func scopesFromClaim(scope any) []string {
+	switch value := scope.(type) {
+	case []interface{}:
+		return convertToStringSlice(value)
+	case []string:
+		return value
+	case string:
+		return strings.Fields(value)
+	}
+	return nil
+}
+
+// grantsFromScopes returns the permissions granted by scopes: the highest of
+// fern.read, fern.write, fern.maintain and fern.admin, held on every project
+// or on the projects of the fernproject.<name> scopes.
+func grantsFromScopes(scopes []string) (Grants, error) {
+	var grants Grants
+	level := 0
+	for _, scope := range scopes {
+		level = max(level, permissionLevels[scope])
+	}
+	if slices.Contains(scopes, PermissionAdmin) {
+		grants.All = level
+		grants.Admin = true
+		return grants, nil
+	}
+	for _, v := range scopes {
+		if !strings.HasPrefix(v, FP+".") {
+			continue
+		}
+		parts := strings.SplitN(v, ".", 2)
+		if len(parts) != 2 || len(parts[1]) == 0 {
+			return grants, fmt.Errorf("fern project scope claim is not formatted properly")
+		}
+		if parts[1] == "*" {
+			grants.All = level
+			continue
+		}
+		grants.grant(parts[1], level)
+	}
+	return grants, nil
+}
+
 // readRequestBody reads and returns the request body bytes.
 func readRequestBody(c *gin.Context) ([]byte, error) {
 	bodyBytes, err := io.ReadAll(c.Request.Body)
@@ -180,25 +329,6 @@ func readRequestBody(c *gin.Context) ([]byte, error) {
 	return bodyBytes, nil
 }
 
-// validateProjectName checks if the project name from the request body matches the scope claim and returns the fernProjectName.
-func validateProjectName(scopes []string, projectName string) (string, error) {
-	for _, v := range scopes {
-		if strings.HasPrefix(v, FP+".") {
-			parts := strings.SplitN(v, ".", 2)
-			if len(parts) != 2 || len(parts[1]) == 0 {
-				return "", fmt.Errorf("fern project scope claim is not formatted properly")
-			}
-
-			fernProjectName := parts[1]
-			if projectName != fernProjectName {
-				return "", fmt.Errorf("project name does not match fern project scope claim")
-			}
-			return fernProjectName, nil
-		}
-	}
-	return "", fmt.Errorf("fern project scope claim not found")
-}
-
 // convertToStringSlice converts a slice of interface{} to a slice of strings.
 func convertToStringSlice(slice []interface{}) []string {
 	strSlice := make([]string, len(slice))
@@ -207,13 +337,3 @@ func convertToStringSlice(slice []interface{}) []string {
 	}
 	return strSlice
 }
-
-// containsSubstring checks if any string in the slice contains the specified substring.
-func containsSubstring(slice []string, substring string) bool {
-	for _, v := range slice {
-		if strings.Contains(v, substring) {
-			return true
-		}
-	}
-	return false
-}
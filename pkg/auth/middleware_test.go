@@ -1,6 +1,7 @@
 package auth_test
 
 import (
+	"context"
 	"fmt"
 	"github.com/guidewire/fern-reporter/config"
 	"github.com/guidewire/fern-reporter/pkg/auth"
@@ -92,22 +93,96 @@ var _ = Describe("JWTMiddleware", func() {
 		Expect(recorder.Code).To(Equal(http.StatusOK))
 		Expect(recorder.Body.String()).To(ContainSubstring("success"))
 	})
+
+	It("should accept tokens without scope that identify a user and set subject, groups and organization", func() {
+		config.GetAuth().ScopeClaimName = "scope"
+		config.GetAuth().GroupsClaimName = "groups"
+		config.GetAuth().OrganizationClaimName = "org"
+		jwkSet := jwk.NewSet()
+		mockFetcher.On("FetchKeys", mock.Anything, "test_url").Return(jwkSet, nil)
+
+		mockToken := jwt.New()
+		Expect(mockToken.Set(jwt.SubjectKey, "alice")).To(Succeed())
+		Expect(mockToken.Set("groups", []interface{}{"shoppers"})).To(Succeed())
+		Expect(mockToken.Set("org", "retail")).To(Succeed())
+		mockValidator.On("ParseAndValidateToken", mock.Anything, "valid_token", jwkSet).Return(mockToken, nil)
+
+		router.Use(auth.JWTMiddleware("test_url", mockFetcher, mockValidator))
+		router.GET("/", func(c *gin.Context) {
+			scope, _ := c.Get("scope")
+			c.JSON(http.StatusOK, gin.H{"scope": scope, "subject": c.GetString("subject"), "groups": c.GetStringSlice("groups"),
+				"organization": c.GetString("fernOrganizationName")})
+		})
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer valid_token")
+		router.ServeHTTP(recorder, req)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(MatchJSON(`{"scope": [], "subject": "alice", "groups": ["shoppers"], "organization": "retail"}`))
+	})
+
+	It("should abort with 400 if token has neither scope nor subject", func() {
+		config.GetAuth().ScopeClaimName = "scope"
+		jwkSet := jwk.NewSet()
+		mockFetcher.On("FetchKeys", mock.Anything, "test_url").Return(jwkSet, nil)
+		mockValidator.On("ParseAndValidateToken", mock.Anything, "valid_token", jwkSet).Return(jwt.New(), nil)
+		router.Use(auth.JWTMiddleware("test_url", mockFetcher, mockValidator))
+
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer valid_token")
+		router.ServeHTTP(recorder, req)
+
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+	})
 })
 
 var _ = Describe("ScopeMiddleware", func() {
 	var (
-		router   *gin.Engine
-		recorder *httptest.ResponseRecorder
+		router     *gin.Engine
+		recorder   *httptest.ResponseRecorder
+		mockLookup *mocks.ProjectLookup
+		allowed    []string
+		allowAll   bool
 	)
 
+	// withScopes sets up a router whose caller has scopes and that answers
+	// every route with 200.
+	withScopes := func(scopes ...interface{}) {
+		router.Use(func(c *gin.Context) {
+			c.Set("scope", scopes)
+		})
+		router.Use(auth.ScopeMiddleware(mockLookup))
+		handler := func(c *gin.Context) {
+			allowed, allowAll = auth.AllowedProjects(c)
+			c.JSON(http.StatusOK, gin.H{"message": "success"})
+		}
+		router.GET("/api/reports/testruns", handler)
+		router.GET("/api/reports/trends/:projectUUID", handler)
+		router.GET("/api/testrun/:id", handler)
+		router.POST("/api/testrun/", handler)
+		router.DELETE("/api/testrun/:id", handler)
+		router.POST("/api/project", handler)
+	}
+
+	serve := func(method string, path string, body string) {
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		router.ServeHTTP(recorder, req)
+	}
+
 	BeforeEach(func() {
 		gin.SetMode(gin.TestMode)
 		router = gin.New()
 		recorder = httptest.NewRecorder()
+		mockLookup = new(mocks.ProjectLookup)
+		allowed, allowAll = nil, false
 	})
 
 	It("should abort with 401 if scope is not set in context", func() {
-		router.Use(auth.ScopeMiddleware())
+		router.Use(auth.ScopeMiddleware(mockLookup))
 		router.GET("/", func(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{"message": "success"})
 		})
@@ -120,32 +195,108 @@ var _ = Describe("ScopeMiddleware", func() {
 
 	It("should abort with 403 if method is not in permissions map", func() {
 		router.Use(func(c *gin.Context) {
-			c.Set("scope", "fern.write")
+			c.Set("scope", "fern.admin")
 		})
-		router.Use(auth.ScopeMiddleware())
-		router.DELETE("/", func(c *gin.Context) {
+		router.Use(auth.ScopeMiddleware(mockLookup))
+		router.OPTIONS("/", func(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{"message": "success"})
 		})
 
-		req, _ := http.NewRequest("DELETE", "/", nil)
+		req, _ := http.NewRequest("OPTIONS", "/", nil)
 		router.ServeHTTP(recorder, req)
 
 		Expect(recorder.Code).To(Equal(http.StatusForbidden))
 	})
 
 	It("should abort with 403 if scope does not include required permission", func() {
-		scopes := []interface{}{"fern.read"}
-		router.Use(func(c *gin.Context) {
-			c.Set("scope", scopes)
-		})
-		router.Use(auth.ScopeMiddleware())
-		router.POST("/", func(c *gin.Context) {
-			c.JSON(http.StatusOK, gin.H{"message": "success"})
-		})
+		withScopes("fern.read", "fernproject.project-a")
+		mockLookup.On("ProjectNames", mock.Anything, auth.ProjectRefs{Names: []string{"project-a"}}).Return([]string{"project-a"}, nil)
 
-		req, _ := http.NewRequest("POST", "/", nil)
-		router.ServeHTTP(recorder, req)
+		serve("POST", "/api/testrun/", `{"test_project_name": "project-a"}`)
+
+		Expect(recorder.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should allow reads of the caller's projects resolved from the path", func() {
+		withScopes("fern.read", "fernproject.project-a")
+		mockLookup.On("ProjectNames", mock.Anything, auth.ProjectRefs{UUIDs: []string{"uuid-a"}}).Return([]string{"project-a"}, nil)
+		mockLookup.On("ProjectNames", mock.Anything, auth.ProjectRefs{TestRunIDs: []uint64{7}}).Return([]string{"project-b"}, nil)
+
+		serve("GET", "/api/reports/trends/uuid-a", "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		recorder = httptest.NewRecorder()
+		serve("GET", "/api/testrun/7", "")
+		Expect(recorder.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should resolve projects from the query", func() {
+		withScopes("fern.read", "fernproject.project-a")
+		mockLookup.On("ProjectNames", mock.Anything, auth.ProjectRefs{IDs: []uint64{2}}).Return([]string{"project-b"}, nil)
+
+		serve("GET", "/api/reports/testruns?project_id=2", "")
+
+		Expect(recorder.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should let reads without a project through and report the allowed projects", func() {
+		withScopes("fern.write", "fernproject.project-a", "fernproject.project-b")
+
+		serve("GET", "/api/reports/testruns", "")
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(allowAll).To(BeFalse())
+		Expect(allowed).To(Equal([]string{"project-a", "project-b"}))
+		mockLookup.AssertNotCalled(GinkgoT(), "ProjectNames", mock.Anything, mock.Anything)
+	})
+
+	It("should allow writes to the caller's projects only", func() {
+		withScopes("fern.write", "fernproject.project-a")
+		mockLookup.On("ProjectNames", mock.Anything, mock.Anything).Return(func(_ context.Context, refs auth.ProjectRefs) []string {
+			return append(refs.Names, refs.UUIDs...)
+		}, nil)
+
+		serve("POST", "/api/testrun/", `{"test_project_name": "project-a"}`)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
 
+		recorder = httptest.NewRecorder()
+		serve("POST", "/api/testrun/", `{"test_project_name": "project-a", "test_project_id": "project-b"}`)
+		Expect(recorder.Code).To(Equal(http.StatusForbidden))
+
+		recorder = httptest.NewRecorder()
+		serve("POST", "/api/testrun/", `{"suite_runs": []}`)
 		Expect(recorder.Code).To(Equal(http.StatusForbidden))
 	})
+
+	It("should require fern.admin for admin routes", func() {
+		withScopes("fern.write", "fernproject.*")
+
+		serve("POST", "/api/project", `{"name": "project-c"}`)
+		Expect(recorder.Code).To(Equal(http.StatusForbidden))
+
+		router = gin.New()
+		recorder = httptest.NewRecorder()
+		withScopes("fern.admin")
+		serve("POST", "/api/project", `{"name": "project-c"}`)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(allowAll).To(BeTrue())
+	})
+
+	It("should give callers with fernproject.* access to every project", func() {
+		withScopes("fern.write", "fernproject.*")
+
+		serve("DELETE", "/api/testrun/7", "")
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(allowAll).To(BeTrue())
+	})
+
+	It("should abort with 500 if projects cannot be resolved", func() {
+		withScopes("fern.read", "fernproject.project-a")
+		mockLookup.On("ProjectNames", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("error"))
+
+		serve("GET", "/api/testrun/7", "")
+
+		Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+	})
 })
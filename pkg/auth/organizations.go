@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"gorm.io/gorm"
+	"log"
+	"net/http"
+)
+
+const (
+	organizationNameKey = "fernOrganizationName"
+	organizationIDKey   = "fernOrganizationID"
+)
+
+// ErrUnknownOrganization is returned for organizations that do not exist.
+var ErrUnknownOrganization = errors.New("unknown organization")
+
+type organizationContextKey struct{}
+
+// WithOrganization returns a copy of ctx that limits the queries scoped with
+// ScopeProjects and ScopeProjectRows to the organization with the given ID.
+func WithOrganization(ctx context.Context, organizationID uint64) context.Context {
+	return context.WithValue(ctx, organizationContextKey{}, organizationID)
+}
+
+// OrganizationFromContext returns the ID of the organization the caller
+// belongs to, or false when the caller is not limited to one, for instance
+// when auth is disabled. ctx may be the request context or the gin context of
+// the request.
+func OrganizationFromContext(ctx context.Context) (uint64, bool) {
+	if c, ok := ctx.(*gin.Context); ok {
+		if c.Request == nil {
+			return 0, false
+		}
+		ctx = c.Request.Context()
+	}
+	organizationID, ok := ctx.Value(organizationContextKey{}).(uint64)
+	return organizationID, ok
+}
+
+// ScopeProjects limits a query on project_details to the projects of the
+// caller's organization.
+func ScopeProjects(ctx context.Context, query *gorm.DB) *gorm.DB {
+	if organizationID, ok := OrganizationFromContext(ctx); ok {
+		return query.Where("project_details.organization_id = ?", organizationID)
+	}
+	return query
+}
+
+// ScopeProjectRows limits a query to the rows whose column, such as
+// test_runs.project_id, holds the ID of a project of the caller's
+// organization.
+func ScopeProjectRows(ctx context.Context, query *gorm.DB, column string) *gorm.DB {
+	if organizationID, ok := OrganizationFromContext(ctx); ok {
+		projects := query.Session(&gorm.Session{NewDB: true}).
+			Table("project_details").Select("id").Where("organization_id = ?", organizationID)
+		return query.Where(column+" IN (?)", projects)
+	}
+	return query
+}
+
+// OrganizationResolver interface for looking up organizations by name
+type OrganizationResolver interface {
+	OrganizationID(ctx context.Context, name string) (uint64, error)
+}
+
+// DefaultOrganizationResolver struct for looking up organizations in the database
+type DefaultOrganizationResolver struct {
+	db *gorm.DB
+}
+
+// NewDefaultOrganizationResolver creates a new OrganizationResolver
+func NewDefaultOrganizationResolver(db *gorm.DB) *DefaultOrganizationResolver {
+	return &DefaultOrganizationResolver{db: db}
+}
+
+// OrganizationID returns the ID of the organization called name, or
+// ErrUnknownOrganization when there is none.
+func (r *DefaultOrganizationResolver) OrganizationID(ctx context.Context, name string) (uint64, error) {
+	var organization models.Organization
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&organization).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, ErrUnknownOrganization
+	} else if err != nil {
+		return 0, err
+	}
+	return organization.ID, nil
+}
+
+// OrganizationMiddleware Middleware for limiting a request to the organization
+// of the caller. It must run after the JWTMiddleware and APIKeyMiddleware and
+// before the middlewares and handlers that query projects. Requests made with
+// an API key belong to the organization of the key's project, and others to
+// the organization named by the token's organization claim, or the default
+// organization when the token has none. Tokens naming an organization that
+// does not exist are rejected.
+func OrganizationMiddleware(resolver OrganizationResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		organizationID := models.DefaultOrganizationID
+		if id, ok := c.Get(organizationIDKey); ok {
+			organizationID = id.(uint64)
+		} else if name := c.GetString(organizationNameKey); name != "" {
+			var err error
+			organizationID, err = resolver.OrganizationID(c.Request.Context(), name)
+			if errors.Is(err, ErrUnknownOrganization) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "unknown organization"})
+				return
+			} else if err != nil {
+				log.Printf("Failed to resolve organization %s: %v", name, err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve organization"})
+				return
+			}
+		}
+
+		c.Request = c.Request.WithContext(WithOrganization(c.Request.Context(), organizationID))
+		c.Next()
+	}
+}
@@ -0,0 +1,184 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/auth/mocks"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("OrganizationMiddleware", func() {
+	var (
+		resolver     *mocks.OrganizationResolver
+		organization uint64
+		scoped       bool
+	)
+
+	serve := func(setup gin.HandlerFunc) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.Use(setup)
+		router.Use(auth.OrganizationMiddleware(resolver))
+		router.GET("/api/testrun/", func(c *gin.Context) {
+			organization, scoped = auth.OrganizationFromContext(c.Request.Context())
+			c.Status(http.StatusOK)
+		})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/testrun/", nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		resolver = mocks.NewOrganizationResolver(GinkgoT())
+		organization, scoped = 0, false
+	})
+
+	It("should put callers without an organization claim in the default organization", func() {
+		w := serve(func(c *gin.Context) {})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(scoped).To(BeTrue())
+		Expect(organization).To(Equal(models.DefaultOrganizationID))
+	})
+
+	It("should resolve the organization named by the token", func() {
+		resolver.On("OrganizationID", mock.Anything, "retail").Return(uint64(7), nil)
+		w := serve(func(c *gin.Context) {
+			c.Set("fernOrganizationName", "retail")
+		})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(organization).To(Equal(uint64(7)))
+	})
+
+	It("should reject tokens naming an unknown organization", func() {
+		resolver.On("OrganizationID", mock.Anything, "unknown").Return(uint64(0), auth.ErrUnknownOrganization)
+		w := serve(func(c *gin.Context) {
+			c.Set("fernOrganizationName", "unknown")
+		})
+		Expect(w.Code).To(Equal(http.StatusForbidden))
+		Expect(w.Body.String()).To(ContainSubstring("unknown organization"))
+		Expect(scoped).To(BeFalse())
+	})
+
+	It("should return 500 when the organization cannot be resolved", func() {
+		resolver.On("OrganizationID", mock.Anything, "retail").Return(uint64(0), fmt.Errorf("error"))
+		w := serve(func(c *gin.Context) {
+			c.Set("fernOrganizationName", "retail")
+		})
+		Expect(w.Code).To(Equal(http.StatusInternalServerError))
+	})
+
+	It("should use the organization of the project of an API key", func() {
+		w := serve(func(c *gin.Context) {
+			c.Set("fernOrganizationID", uint64(3))
+		})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(organization).To(Equal(uint64(3)))
+	})
+})
+
+var _ = Describe("Organization scoping", func() {
+	var (
+		db           *gorm.DB
+		retail       models.Organization
+		cart         models.ProjectDetails
+		retailCart   models.ProjectDetails
+		retailCtx    context.Context
+		defaultCtx   context.Context
+		retailRunID  uint64
+		defaultRunID uint64
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&models.Organization{}, &models.ProjectDetails{}, &models.TestRun{},
+			&models.RoleBinding{})).To(Succeed())
+
+		Expect(db.Create(&models.Organization{ID: models.DefaultOrganizationID, Name: "default"}).Error).NotTo(HaveOccurred())
+		retail = models.Organization{Name: "retail"}
+		Expect(db.Create(&retail).Error).NotTo(HaveOccurred())
+
+		cart = models.ProjectDetails{Name: "Cart", TeamName: "shop"}
+		Expect(db.Create(&cart).Error).NotTo(HaveOccurred())
+		retailCart = models.ProjectDetails{Name: "Cart", TeamName: "shop", OrganizationID: retail.ID}
+		Expect(db.Create(&retailCart).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "retail-cart-uuid", retailCart.ID).Error).NotTo(HaveOccurred())
+
+		defaultRun := models.TestRun{ProjectID: cart.ID}
+		Expect(db.Create(&defaultRun).Error).NotTo(HaveOccurred())
+		retailRun := models.TestRun{ProjectID: retailCart.ID}
+		Expect(db.Create(&retailRun).Error).NotTo(HaveOccurred())
+		defaultRunID, retailRunID = defaultRun.ID, retailRun.ID
+
+		retailCtx = auth.WithOrganization(context.Background(), retail.ID)
+		defaultCtx = auth.WithOrganization(context.Background(), models.DefaultOrganizationID)
+	})
+
+	It("should look organizations up by name", func() {
+		resolver := auth.NewDefaultOrganizationResolver(db)
+		id, err := resolver.OrganizationID(context.Background(), "retail")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal(retail.ID))
+
+		_, err = resolver.OrganizationID(context.Background(), "unknown")
+		Expect(err).To(MatchError(auth.ErrUnknownOrganization))
+	})
+
+	It("should limit queries to the projects and runs of the organization", func() {
+		var projects []models.ProjectDetails
+		Expect(auth.ScopeProjects(retailCtx, db.Model(&models.ProjectDetails{})).Find(&projects).Error).NotTo(HaveOccurred())
+		Expect(projects).To(HaveLen(1))
+		Expect(projects[0].ID).To(Equal(retailCart.ID))
+
+		var runIDs []uint64
+		Expect(auth.ScopeProjectRows(defaultCtx, db.Model(&models.TestRun{}), "test_runs.project_id").Pluck("id", &runIDs).Error).NotTo(HaveOccurred())
+		Expect(runIDs).To(Equal([]uint64{defaultRunID}))
+		Expect(auth.ScopeProjectRows(retailCtx, db.Model(&models.TestRun{}), "test_runs.project_id").Pluck("id", &runIDs).Error).NotTo(HaveOccurred())
+		Expect(runIDs).To(Equal([]uint64{retailRunID}))
+
+		Expect(auth.ScopeProjects(context.Background(), db.Model(&models.ProjectDetails{})).Find(&projects).Error).NotTo(HaveOccurred())
+		Expect(projects).To(HaveLen(2))
+	})
+
+	It("should resolve project references within the organization", func() {
+		lookup := auth.NewDefaultProjectLookup(db)
+		names, err := lookup.ProjectNames(defaultCtx, auth.ProjectRefs{UUIDs: []string{"retail-cart-uuid"}, TestRunIDs: []uint64{retailRunID}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(BeEmpty())
+
+		names, err = lookup.ProjectNames(retailCtx, auth.ProjectRefs{UUIDs: []string{"retail-cart-uuid"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"Cart"}))
+	})
+
+	It("should only grant the roles bound in the organization", func() {
+		Expect(db.Create(&models.RoleBinding{Role: auth.RoleAdmin, SubjectType: auth.SubjectTypeUser, Subject: "alice"}).Error).NotTo(HaveOccurred())
+		Expect(db.Create(&models.RoleBinding{Role: auth.RoleViewer, SubjectType: auth.SubjectTypeUser, Subject: "bob", TeamName: "shop", OrganizationID: retail.ID}).Error).NotTo(HaveOccurred())
+		resolver := auth.NewDefaultRoleResolver(db)
+
+		grants, err := resolver.Grants(retailCtx, "alice", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(grants.Admin).To(BeFalse())
+		Expect(grants.All).To(Equal(0))
+
+		grants, err = resolver.Grants(defaultCtx, "bob", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(grants.Projects).To(BeEmpty())
+
+		grants, err = resolver.Grants(retailCtx, "bob", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(grants.Projects).To(Equal(map[string]int{"Cart": 1}))
+	})
+})
@@ -0,0 +1,209 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ProjectRefs holds the references to projects found in a request.
+type ProjectRefs struct {
+	Names        []string
+	UUIDs        []string
+	IDs          []uint64
+	TestRunIDs   []uint64
+	IngestJobIDs []uint64
+	Fingerprints []string
+}
+
+// Empty reports whether the request referenced no project.
+func (r ProjectRefs) Empty() bool {
+	return len(r.Names) == 0 && len(r.UUIDs) == 0 && len(r.IDs) == 0 &&
+		len(r.TestRunIDs) == 0 && len(r.IngestJobIDs) == 0 && len(r.Fingerprints) == 0
+}
+
+// addProject adds a project reference that is either a numeric project ID or
+// a project UUID.
+func (r *ProjectRefs) addProject(value string) {
+	if value == "" {
+		return
+	}
+	if id, err := strconv.ParseUint(value, 10, 64); err == nil {
+		r.IDs = append(r.IDs, id)
+	} else {
+		r.UUIDs = append(r.UUIDs, value)
+	}
+}
+
+func (r *ProjectRefs) addTestRun(value string) {
+	if id, err := strconv.ParseUint(value, 10, 64); err == nil {
+		r.TestRunIDs = append(r.TestRunIDs, id)
+	}
+}
+
+// ProjectLookup interface for resolving project references to project names
+type ProjectLookup interface {
+	ProjectNames(ctx context.Context, refs ProjectRefs) ([]string, error)
+}
+
+// DefaultProjectLookup struct for resolving project references from the database
+type DefaultProjectLookup struct {
+	db *gorm.DB
+}
+
+// NewDefaultProjectLookup creates a new ProjectLookup
+func NewDefaultProjectLookup(db *gorm.DB) *DefaultProjectLookup {
+	return &DefaultProjectLookup{db: db}
+}
+
+// ProjectNames returns the sorted names of the projects referenced. References
+// to projects, runs, jobs or tests that do not exist, or that belong to another
+// organization than the caller's, are ignored.
+func (l *DefaultProjectLookup) ProjectNames(ctx context.Context, refs ProjectRefs) ([]string, error) {
+	names := slices.Clone(refs.Names)
+	db := l.db.WithContext(ctx)
+
+	lookups := []struct {
+		table  string
+		column string
+		values any
+		count  int
+	}{
+		{"project_details", "project_details.uuid", refs.UUIDs, len(refs.UUIDs)},
+		{"project_details", "project_details.id", refs.IDs, len(refs.IDs)},
+		{"test_runs", "test_runs.id", refs.TestRunIDs, len(refs.TestRunIDs)},
+		{"ingest_jobs", "ingest_jobs.id", refs.IngestJobIDs, len(refs.IngestJobIDs)},
+		{"test_cases", "test_cases.fingerprint", refs.Fingerprints, len(refs.Fingerprints)},
+	}
+	for _, lookup := range lookups {
+		if lookup.count == 0 {
+			continue
+		}
+		query := db.Table(lookup.table)
+		if lookup.table != "project_details" {
+			query = query.Joins("JOIN project_details ON project_details.id = " + lookup.table + ".project_id")
+		}
+		query = ScopeProjects(ctx, query)
+		var found []string
+		if err := query.Where(lookup.column+" IN ?", lookup.values).Pluck("project_details.name", &found).Error; err != nil {
+			return nil, err
+		}
+		names = append(names, found...)
+	}
+
+	slices.Sort(names)
+	return slices.Compact(names), nil
+}
+
+// projectRefsFromRequest collects the projects a request refers to from its
+// path parameters, its query and the top level fields of a JSON body.
+func projectRefsFromRequest(c *gin.Context) (ProjectRefs, error) {
+	var refs ProjectRefs
+
+	fullPath := c.FullPath()
+	for _, param := range c.Params {
+		switch param.Key {
+		case "uuid", "projectUUID", "projectId":
+			refs.addProject(param.Value)
+		case "testRunId", "baseId":
+			refs.addTestRun(param.Value)
+		case "fingerprint":
+			refs.Fingerprints = append(refs.Fingerprints, param.Value)
+		case "id":
+			switch {
+			case strings.Contains(fullPath, "/testrun/"), strings.Contains(fullPath, "/testruns/"):
+				refs.addTestRun(param.Value)
+			case strings.Contains(fullPath, "/ingest/jobs/"):
+				if id, err := strconv.ParseUint(param.Value, 10, 64); err == nil {
+					refs.IngestJobIDs = append(refs.IngestJobIDs, id)
+				}
+			}
+		}
+	}
+
+	query := c.Request.URL.Query()
+	for _, value := range query["project_id"] {
+		refs.addProject(value)
+	}
+	for _, key := range []string{"project", "test_project_name"} {
+		for _, value := range query[key] {
+			if value != "" {
+				refs.Names = append(refs.Names, value)
+			}
+		}
+	}
+
+	if c.Request.Body == nil || !strings.HasPrefix(c.ContentType(), "application/json") {
+		return refs, nil
+	}
+	bodyBytes, err := readRequestBody(c)
+	if err != nil {
+		return refs, err
+	}
+	var body map[string]any
+	if err := json.Unmarshal(bodyBytes, &body); err != nil {
+		// Not an object; leave it to the handler to reject
+		return refs, nil
+	}
+	for _, key := range []string{"project", "test_project_name"} {
+		if value, ok := body[key].(string); ok && value != "" {
+			refs.Names = append(refs.Names, value)
+		}
+	}
+	if value, ok := body["test_project_id"].(string); ok {
+		refs.addProject(value)
+	}
+	return refs, nil
+}
+
+type allowedProjectsContextKey struct{}
+
+// WithAllowedProjects returns a copy of ctx that limits the caller to the
+// projects with the given names. ScopeMiddleware sets it on the requests of
+// callers whose scopes only allow some projects.
+func WithAllowedProjects(ctx context.Context, names []string) context.Context {
+	return context.WithValue(ctx, allowedProjectsContextKey{}, names)
+}
+
+// AllowedProjects returns the names of the projects the caller may access, or
+// all as true when the caller is not limited to some projects. Requests that
+// did not go through ScopeMiddleware, for instance when auth is disabled, are
+// not limited. ctx may be the request context or the gin context of the
+// request.
+func AllowedProjects(ctx context.Context) (names []string, all bool) {
+	if c, ok := ctx.(*gin.Context); ok {
+		if c.Request == nil {
+			return nil, true
+		}
+		ctx = c.Request.Context()
+	}
+	names, ok := ctx.Value(allowedProjectsContextKey{}).([]string)
+	return names, !ok
+}
+
+// FilterAllowedProjects limits a query on project_details to the projects the
+// caller may access according to AllowedProjects.
+func FilterAllowedProjects(ctx context.Context, query *gorm.DB) *gorm.DB {
+	names, all := AllowedProjects(ctx)
+	if all {
+		return query
+	}
+	return query.Where("project_details.name IN ?", names)
+}
+
+// FilterAllowedProjectRows limits a query to the rows whose column, such as
+// test_runs.project_id, holds the ID of a project the caller may access
+// according to AllowedProjects.
+func FilterAllowedProjectRows(ctx context.Context, query *gorm.DB, column string) *gorm.DB {
+	names, all := AllowedProjects(ctx)
+	if all {
+		return query
+	}
+	projects := query.Session(&gorm.Session{NewDB: true}).
+		Table("project_details").Select("id").Where("name IN ?", names)
+	return query.Where(column+" IN (?)", projects)
+}
@@ -0,0 +1,67 @@
+package auth_test
+
+import (
+	"context"
+	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("DefaultProjectLookup", func() {
+	var (
+		db      *gorm.DB
+		lookup  *auth.DefaultProjectLookup
+		cart    models.ProjectDetails
+		search  models.ProjectDetails
+		testRun models.TestRun
+		job     models.IngestJob
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&models.ProjectDetails{}, &models.TestRun{}, &models.SuiteRun{}, &models.SpecRun{},
+			&models.Tag{}, &models.TestCase{}, &models.IngestJob{})).To(Succeed())
+
+		cart = models.ProjectDetails{Name: "Cart"}
+		Expect(db.Create(&cart).Error).NotTo(HaveOccurred())
+		Expect(db.Exec("UPDATE project_details SET uuid = ? WHERE id = ?", "cart-uuid", cart.ID).Error).NotTo(HaveOccurred())
+		search = models.ProjectDetails{Name: "Search"}
+		Expect(db.Create(&search).Error).NotTo(HaveOccurred())
+
+		testRun = models.TestRun{ProjectID: search.ID}
+		Expect(db.Create(&testRun).Error).NotTo(HaveOccurred())
+		job = models.IngestJob{ProjectID: cart.ID}
+		Expect(db.Create(&job).Error).NotTo(HaveOccurred())
+		Expect(db.Create(&models.TestCase{ProjectID: search.ID, Fingerprint: "abc"}).Error).NotTo(HaveOccurred())
+
+		lookup = auth.NewDefaultProjectLookup(db)
+	})
+
+	It("should resolve every kind of reference to sorted, unique project names", func() {
+		names, err := lookup.ProjectNames(context.Background(), auth.ProjectRefs{
+			Names:        []string{"Cart"},
+			UUIDs:        []string{"cart-uuid"},
+			IDs:          []uint64{search.ID},
+			TestRunIDs:   []uint64{testRun.ID},
+			IngestJobIDs: []uint64{job.ID},
+			Fingerprints: []string{"abc"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"Cart", "Search"}))
+	})
+
+	It("should ignore references to records that do not exist", func() {
+		names, err := lookup.ProjectNames(context.Background(), auth.ProjectRefs{
+			UUIDs:      []string{"unknown"},
+			TestRunIDs: []uint64{999},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(BeEmpty())
+	})
+})
@@ -0,0 +1,197 @@
+package auth
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"log"
+	"net/http"
+	"slices"
+)
+
+const (
+	RoleViewer     = "viewer"
+	RoleUploader   = "uploader"
+	RoleMaintainer = "maintainer"
+	RoleAdmin      = "admin"
+
+	SubjectTypeUser  = "user"
+	SubjectTypeGroup = "group"
+
+	roleGrantsKey = "fernRoleGrants"
+)
+
+// rolePermissions is the permission each role grants where it is bound. An
+// admin bound to every project may also administer Fern itself.
+var rolePermissions = map[string]string{
+	RoleViewer:     PermissionRead,
+	RoleUploader:   PermissionWrite,
+	RoleMaintainer: PermissionMaintain,
+	RoleAdmin:      PermissionAdmin,
+}
+
+// ValidRole reports whether role is one of the roles that can be bound.
+func ValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// ValidSubjectType reports whether subjectType is user or group.
+func ValidSubjectType(subjectType string) bool {
+	return subjectType == SubjectTypeUser || subjectType == SubjectTypeGroup
+}
+
+// Grants is what a caller may do, as permission levels on every project and on
+// single projects.
+type Grants struct {
+	// All is the permission level held on every project.
+	All int
+	// Projects holds the permission levels held on single projects by name.
+	Projects map[string]int
+	// Admin is set for callers that may administer Fern, such as managing
+	// role bindings.
+	Admin bool
+}
+
+func (g *Grants) grant(project string, level int) {
+	if g.Projects == nil {
+		g.Projects = map[string]int{}
+	}
+	g.Projects[project] = max(g.Projects[project], level)
+}
+
+func (g *Grants) merge(other Grants) {
+	g.All = max(g.All, other.All)
+	g.Admin = g.Admin || other.Admin
+	for project, level := range other.Projects {
+		g.grant(project, level)
+	}
+}
+
+// level returns the permission level held on project.
+func (g Grants) level(project string) int {
+	return max(g.All, g.Projects[project])
+}
+
+// maxLevel returns the highest permission level held on any project.
+func (g Grants) maxLevel() int {
+	level := g.All
+	for _, projectLevel := range g.Projects {
+		level = max(level, projectLevel)
+	}
+	return level
+}
+
+// projectsAt returns the sorted names of the single projects held at level or
+// above.
+func (g Grants) projectsAt(level int) []string {
+	projects := []string{}
+	for project, projectLevel := range g.Projects {
+		if projectLevel >= level {
+			projects = append(projects, project)
+		}
+	}
+	slices.Sort(projects)
+	return projects
+}
+
+// RoleResolver interface for looking up the roles bound to a user and its groups
+type RoleResolver interface {
+	Grants(ctx context.Context, subject string, groups []string) (Grants, error)
+}
+
+// DefaultRoleResolver struct for looking up role bindings in the database
+type DefaultRoleResolver struct {
+	db *gorm.DB
+}
+
+// NewDefaultRoleResolver creates a new RoleResolver
+func NewDefaultRoleResolver(db *gorm.DB) *DefaultRoleResolver {
+	return &DefaultRoleResolver{db: db}
+}
+
+type boundRole struct {
+	Role        string
+	TeamName    string
+	ProjectName *string
+}
+
+// Grants combines the roles bound to the subject and to any of its groups in
+// the caller's organization. Roles bound to a team apply to the projects of the
+// team at the time of the request.
+func (r *DefaultRoleResolver) Grants(ctx context.Context, subject string, groups []string) (Grants, error) {
+	var grants Grants
+	db := r.db.WithContext(ctx)
+
+	bindings := db.Table("role_bindings")
+	if organizationID, ok := OrganizationFromContext(ctx); ok {
+		bindings = bindings.Where("role_bindings.organization_id = ?", organizationID)
+	}
+	var bound []boundRole
+	if err := bindings.
+		Select("role_bindings.role, role_bindings.team_name, project_details.name AS project_name").
+		Joins("LEFT JOIN project_details ON project_details.id = role_bindings.project_id").
+		Where("(role_bindings.subject_type = ? AND role_bindings.subject = ?) OR (role_bindings.subject_type = ? AND role_bindings.subject IN ?)",
+			SubjectTypeUser, subject, SubjectTypeGroup, groups).
+		Scan(&bound).Error; err != nil {
+		return grants, err
+	}
+
+	teamLevels := map[string]int{}
+	for _, binding := range bound {
+		level := permissionLevels[rolePermissions[binding.Role]]
+		switch {
+		case binding.ProjectName != nil:
+			grants.grant(*binding.ProjectName, level)
+		case binding.TeamName != "":
+			teamLevels[binding.TeamName] = max(teamLevels[binding.TeamName], level)
+		default:
+			grants.All = max(grants.All, level)
+			grants.Admin = grants.Admin || binding.Role == RoleAdmin
+		}
+	}
+
+	if len(teamLevels) > 0 {
+		teams := make([]string, 0, len(teamLevels))
+		for team := range teamLevels {
+			teams = append(teams, team)
+		}
+		var projects []struct {
+			Name     string
+			TeamName string
+		}
+		query := ScopeProjects(ctx, db.Table("project_details").Select("name, team_name").Where("team_name IN ?", teams))
+		if err := query.Scan(&projects).Error; err != nil {
+			return grants, err
+		}
+		for _, project := range projects {
+			grants.grant(project.Name, teamLevels[project.TeamName])
+		}
+	}
+	return grants, nil
+}
+
+// RoleMiddleware Middleware for granting the roles bound to the JWT subject and
+// groups. It must run after the JWTMiddleware; ScopeMiddleware combines the
+// roles with the scopes of the token. Requests without a subject, such as
+// those made with an API key, are passed on unchanged.
+func RoleMiddleware(resolver RoleResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subject := c.GetString("subject")
+		groups := c.GetStringSlice("groups")
+		if subject == "" && len(groups) == 0 {
+			c.Next()
+			return
+		}
+
+		grants, err := resolver.Grants(c.Request.Context(), subject, groups)
+		if err != nil {
+			log.Printf("Failed to resolve roles of %s: %v", subject, err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve roles"})
+			return
+		}
+
+		c.Set(roleGrantsKey, grants)
+		c.Next()
+	}
+}
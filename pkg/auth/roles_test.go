@@ -0,0 +1,190 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/auth/mocks"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("DefaultRoleResolver", func() {
+	var (
+		db       *gorm.DB
+		resolver *auth.DefaultRoleResolver
+	)
+
+	bind := func(role string, subjectType string, subject string, projectID *uint64, team string) {
+		binding := models.RoleBinding{Role: role, SubjectType: subjectType, Subject: subject, ProjectID: projectID, TeamName: team}
+		Expect(db.Create(&binding).Error).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&models.ProjectDetails{}, &models.RoleBinding{})).To(Succeed())
+
+		cart := models.ProjectDetails{Name: "Cart", TeamName: "shop"}
+		Expect(db.Create(&cart).Error).NotTo(HaveOccurred())
+		search := models.ProjectDetails{Name: "Search", TeamName: "shop"}
+		Expect(db.Create(&search).Error).NotTo(HaveOccurred())
+		billing := models.ProjectDetails{Name: "Billing", TeamName: "finance"}
+		Expect(db.Create(&billing).Error).NotTo(HaveOccurred())
+
+		bind(auth.RoleMaintainer, auth.SubjectTypeUser, "alice", &cart.ID, "")
+		bind(auth.RoleViewer, auth.SubjectTypeGroup, "shoppers", nil, "shop")
+		bind(auth.RoleUploader, auth.SubjectTypeUser, "bob", &billing.ID, "")
+		bind(auth.RoleAdmin, auth.SubjectTypeGroup, "fern-admins", nil, "")
+
+		resolver = auth.NewDefaultRoleResolver(db)
+	})
+
+	It("should combine the roles bound to the user and its groups", func() {
+		grants, err := resolver.Grants(context.Background(), "alice", []string{"shoppers", "others"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(grants.All).To(Equal(0))
+		Expect(grants.Admin).To(BeFalse())
+		Expect(grants.Projects).To(Equal(map[string]int{"Cart": 3, "Search": 1}))
+	})
+
+	It("should grant every project to roles bound without a project or team", func() {
+		grants, err := resolver.Grants(context.Background(), "carol", []string{"fern-admins"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(grants.All).To(Equal(4))
+		Expect(grants.Admin).To(BeTrue())
+	})
+
+	It("should grant nothing to unknown subjects", func() {
+		grants, err := resolver.Grants(context.Background(), "dave", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(grants).To(Equal(auth.Grants{}))
+	})
+})
+
+var _ = Describe("RoleMiddleware", func() {
+	var (
+		mockResolver *mocks.RoleResolver
+		mockLookup   *mocks.ProjectLookup
+		router       *gin.Engine
+		recorder     *httptest.ResponseRecorder
+	)
+
+	// withCaller sets up a router whose caller has the subject, groups and
+	// scopes given and that answers every route with 200.
+	withCaller := func(subject string, groups []string, scopes ...interface{}) {
+		router.Use(func(c *gin.Context) {
+			c.Set("scope", scopes)
+			c.Set("subject", subject)
+			if groups != nil {
+				c.Set("groups", groups)
+			}
+		})
+		router.Use(auth.RoleMiddleware(mockResolver))
+		router.Use(auth.ScopeMiddleware(mockLookup))
+		handler := func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"message": "success"})
+		}
+		router.GET("/api/reports/trends/:projectUUID", handler)
+		router.POST("/api/testrun/", handler)
+		router.PUT("/api/project/:uuid", handler)
+		router.DELETE("/api/project/:uuid", handler)
+		router.POST("/api/project", handler)
+		router.POST("/api/project/:uuid/keys", handler)
+		router.PUT("/api/gates/:projectUUID", handler)
+		router.GET("/api/admin/roles", handler)
+	}
+
+	serve := func(method string, path string, body string) int {
+		recorder = httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		mockResolver = new(mocks.RoleResolver)
+		mockLookup = new(mocks.ProjectLookup)
+		mockLookup.On("ProjectNames", mock.Anything, mock.Anything).Return(func(_ context.Context, refs auth.ProjectRefs) []string {
+			return append(refs.Names, refs.UUIDs...)
+		}, nil)
+		router = gin.New()
+	})
+
+	It("should enforce the roles bound to the caller per project", func() {
+		mockResolver.On("Grants", mock.Anything, "alice", []string{"shoppers"}).Return(auth.Grants{
+			Projects: map[string]int{"Cart": 3, "Search": 1},
+		}, nil)
+		withCaller("alice", []string{"shoppers"})
+
+		Expect(serve("GET", "/api/reports/trends/Search", "")).To(Equal(http.StatusOK))
+		Expect(serve("GET", "/api/reports/trends/Billing", "")).To(Equal(http.StatusForbidden))
+		Expect(serve("POST", "/api/testrun/", `{"test_project_name": "Search"}`)).To(Equal(http.StatusForbidden))
+		Expect(serve("POST", "/api/testrun/", `{"test_project_name": "Cart"}`)).To(Equal(http.StatusOK))
+		Expect(serve("PUT", "/api/project/Cart", `{"name": "Cart"}`)).To(Equal(http.StatusOK))
+		Expect(serve("POST", "/api/project", `{"name": "New"}`)).To(Equal(http.StatusForbidden))
+		Expect(serve("GET", "/api/admin/roles", "")).To(Equal(http.StatusForbidden))
+	})
+
+	It("should let maintainers manage keys and gates but not delete projects", func() {
+		mockResolver.On("Grants", mock.Anything, "alice", []string(nil)).Return(auth.Grants{
+			Projects: map[string]int{"Cart": 3, "Search": 2},
+		}, nil)
+		withCaller("alice", nil)
+
+		Expect(serve("POST", "/api/project/Cart/keys", `{"name": "ci"}`)).To(Equal(http.StatusOK))
+		Expect(serve("PUT", "/api/gates/Cart", `{}`)).To(Equal(http.StatusOK))
+		Expect(serve("DELETE", "/api/project/Cart", "")).To(Equal(http.StatusForbidden))
+
+		Expect(serve("POST", "/api/project/Search/keys", `{"name": "ci"}`)).To(Equal(http.StatusForbidden))
+		Expect(serve("PUT", "/api/gates/Search", `{}`)).To(Equal(http.StatusForbidden))
+		Expect(serve("PUT", "/api/project/Search", `{"name": "Search"}`)).To(Equal(http.StatusForbidden))
+	})
+
+	It("should add the roles to the scopes of the token", func() {
+		mockResolver.On("Grants", mock.Anything, "bob", []string(nil)).Return(auth.Grants{
+			Projects: map[string]int{"Cart": 1},
+		}, nil)
+		withCaller("bob", nil, "fern.write", "fernproject.Billing")
+
+		Expect(serve("GET", "/api/reports/trends/Cart", "")).To(Equal(http.StatusOK))
+		Expect(serve("POST", "/api/testrun/", `{"test_project_name": "Billing"}`)).To(Equal(http.StatusOK))
+		Expect(serve("POST", "/api/testrun/", `{"test_project_name": "Cart"}`)).To(Equal(http.StatusForbidden))
+	})
+
+	It("should let global admins call the admin routes", func() {
+		mockResolver.On("Grants", mock.Anything, "carol", []string{"fern-admins"}).Return(auth.Grants{All: 4, Admin: true}, nil)
+		withCaller("carol", []string{"fern-admins"})
+
+		Expect(serve("GET", "/api/admin/roles", "")).To(Equal(http.StatusOK))
+		Expect(serve("POST", "/api/project", `{"name": "New"}`)).To(Equal(http.StatusOK))
+	})
+
+	It("should skip callers without a subject", func() {
+		withCaller("", nil, "fern.write", "fernproject.Cart")
+
+		Expect(serve("POST", "/api/testrun/", `{"test_project_name": "Cart"}`)).To(Equal(http.StatusOK))
+		mockResolver.AssertNotCalled(GinkgoT(), "Grants", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	It("should abort with 500 if roles cannot be resolved", func() {
+		mockResolver.On("Grants", mock.Anything, "alice", mock.Anything).Return(auth.Grants{}, fmt.Errorf("error"))
+		withCaller("alice", nil)
+
+		Expect(serve("GET", "/api/reports/trends/Cart", "")).To(Equal(http.StatusInternalServerError))
+	})
+})
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_test_runs_status;
+
+ALTER TABLE public.test_runs
+DROP COLUMN IF EXISTS created_at;
+
+ALTER TABLE public.test_runs
+DROP COLUMN IF EXISTS status;
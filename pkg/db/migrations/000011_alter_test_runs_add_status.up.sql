@@ -0,0 +1,18 @@
+ALTER TABLE public.test_runs
+ADD COLUMN status VARCHAR(20);
+
+-- Runs stored before the lifecycle are not lifecycle-managed, which new runs
+-- record as an empty status
+UPDATE public.test_runs SET status = '' WHERE status IS NULL;
+
+ALTER TABLE public.test_runs
+ALTER COLUMN status SET DEFAULT '',
+ALTER COLUMN status SET NOT NULL;
+
+-- The time the server stored the run, so that the reaper does not depend on
+-- the clock of the CI runner that reported its start time
+ALTER TABLE public.test_runs
+ADD COLUMN created_at timestamp with time zone NOT NULL DEFAULT now();
+
+-- Index for status to let the reaper find open runs quickly
+CREATE INDEX idx_test_runs_status ON test_runs (status);
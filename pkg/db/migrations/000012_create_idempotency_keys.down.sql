@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.idempotency_keys;
+
+ALTER TABLE public.test_runs
+DROP CONSTRAINT IF EXISTS test_runs_id_key;
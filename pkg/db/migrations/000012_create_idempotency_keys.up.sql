@@ -0,0 +1,19 @@
+-- test_runs is keyed on (id, test_seed); ids are unique on their own so
+-- tables that only know the id can reference it
+ALTER TABLE public.test_runs
+ADD CONSTRAINT test_runs_id_key UNIQUE (id);
+
+CREATE TABLE public.idempotency_keys (
+    id bigserial PRIMARY KEY,
+    project_id bigint NOT NULL,
+    idempotency_key text NOT NULL,
+    payload_hash text NOT NULL,
+    test_run_id bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT now(),
+    FOREIGN KEY (test_run_id)
+    REFERENCES public.test_runs(id)
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_idempotency_keys_project_key ON idempotency_keys (project_id, idempotency_key);
+CREATE INDEX idx_idempotency_keys_test_run_id ON idempotency_keys (test_run_id);
@@ -0,0 +1,18 @@
+CREATE TABLE public.ingest_jobs (
+    id bigserial PRIMARY KEY,
+    project_id bigint NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    payload text NOT NULL,
+    payload_hash text,
+    idempotency_key text,
+    test_run_id bigint,
+    error text,
+    attempts integer NOT NULL DEFAULT 0,
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone
+);
+
+-- Workers look up the oldest queued job
+CREATE INDEX idx_ingest_jobs_status_id ON ingest_jobs (status, id);
@@ -0,0 +1,23 @@
+-- Point tag links at the oldest tag of each name before removing duplicates
+INSERT INTO spec_run_tags (spec_run_id, tag_id)
+SELECT spec_run_tags.spec_run_id, keep.id
+FROM spec_run_tags
+JOIN tags ON tags.id = spec_run_tags.tag_id
+JOIN (SELECT name, MIN(id) AS id FROM tags GROUP BY name) keep ON keep.name = tags.name
+WHERE tags.id <> keep.id
+ON CONFLICT DO NOTHING;
+
+INSERT INTO suite_run_tags (suite_run_id, tag_id)
+SELECT suite_run_tags.suite_run_id, keep.id
+FROM suite_run_tags
+JOIN tags ON tags.id = suite_run_tags.tag_id
+JOIN (SELECT name, MIN(id) AS id FROM tags GROUP BY name) keep ON keep.name = tags.name
+WHERE tags.id <> keep.id
+ON CONFLICT DO NOTHING;
+
+-- Links to the duplicates are removed by the ON DELETE CASCADE foreign keys
+DELETE FROM tags
+USING tags keep
+WHERE tags.name = keep.name AND tags.id > keep.id;
+
+CREATE UNIQUE INDEX idx_tags_name ON tags (name);
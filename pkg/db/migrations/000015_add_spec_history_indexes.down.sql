@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_spec_runs_suite_id;
+DROP INDEX IF EXISTS idx_suite_runs_test_run_id;
+DROP INDEX IF EXISTS idx_test_runs_project_id_start_time;
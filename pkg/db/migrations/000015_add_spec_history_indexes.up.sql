@@ -0,0 +1,4 @@
+-- Indexes for reports that walk spec history across a project's runs
+CREATE INDEX idx_test_runs_project_id_start_time ON test_runs (project_id, start_time);
+CREATE INDEX idx_suite_runs_test_run_id ON suite_runs (test_run_id);
+CREATE INDEX idx_spec_runs_suite_id ON spec_runs (suite_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_spec_runs_test_case_id;
+
+ALTER TABLE public.spec_runs
+DROP CONSTRAINT IF EXISTS fk_spec_runs_test_case_id,
+DROP COLUMN IF EXISTS test_case_id;
+
+DROP TABLE IF EXISTS public.test_cases;
@@ -0,0 +1,64 @@
+CREATE TABLE public.test_cases (
+    id bigserial PRIMARY KEY,
+    project_id INT NOT NULL,
+    fingerprint text NOT NULL,
+    suite_name text,
+    spec_description text,
+    first_seen timestamp with time zone,
+    last_seen timestamp with time zone,
+    last_status text,
+    FOREIGN KEY (project_id)
+    REFERENCES project_details (id)
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_test_cases_project_fingerprint ON test_cases (project_id, fingerprint);
+
+ALTER TABLE public.spec_runs
+ADD COLUMN test_case_id bigint,
+ADD CONSTRAINT fk_spec_runs_test_case_id
+    FOREIGN KEY (test_case_id)
+        REFERENCES test_cases (id)
+        ON DELETE SET NULL;
+
+CREATE INDEX idx_spec_runs_test_case_id ON spec_runs (test_case_id);
+
+-- Backfill the catalog from existing spec runs. The names are normalized and
+-- hashed the same way as utils.TestCaseFingerprint.
+CREATE TEMPORARY TABLE spec_run_fingerprints AS
+SELECT spec_runs.id AS spec_run_id,
+       test_runs.project_id,
+       btrim(regexp_replace(COALESCE(suite_runs.suite_name, ''), '\s+', ' ', 'g')) AS suite_name,
+       btrim(regexp_replace(COALESCE(spec_runs.spec_description, ''), '\s+', ' ', 'g')) AS spec_description,
+       spec_runs.status,
+       test_runs.start_time
+FROM spec_runs
+JOIN suite_runs ON suite_runs.id = spec_runs.suite_id
+JOIN test_runs ON test_runs.id = suite_runs.test_run_id
+WHERE test_runs.project_id IS NOT NULL;
+
+ALTER TABLE spec_run_fingerprints ADD COLUMN fingerprint text;
+
+UPDATE spec_run_fingerprints
+SET fingerprint = encode(sha256(convert_to(project_id::text || E'\n' || suite_name || E'\n' || spec_description, 'UTF8')), 'hex');
+
+INSERT INTO test_cases (project_id, fingerprint, suite_name, spec_description, first_seen, last_seen, last_status)
+SELECT DISTINCT ON (project_id, fingerprint)
+       project_id,
+       fingerprint,
+       suite_name,
+       spec_description,
+       MIN(start_time) OVER (PARTITION BY project_id, fingerprint),
+       MAX(start_time) OVER (PARTITION BY project_id, fingerprint),
+       status
+FROM spec_run_fingerprints
+ORDER BY project_id, fingerprint, start_time DESC NULLS LAST, spec_run_id DESC;
+
+UPDATE spec_runs
+SET test_case_id = test_cases.id
+FROM spec_run_fingerprints
+JOIN test_cases ON test_cases.project_id = spec_run_fingerprints.project_id
+    AND test_cases.fingerprint = spec_run_fingerprints.fingerprint
+WHERE spec_runs.id = spec_run_fingerprints.spec_run_id;
+
+DROP TABLE spec_run_fingerprints;
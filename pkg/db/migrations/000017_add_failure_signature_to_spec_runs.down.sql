@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_spec_runs_failure_signature;
+
+ALTER TABLE public.spec_runs
+DROP COLUMN IF EXISTS failure_signature;
@@ -0,0 +1,6 @@
+-- Failed specs are signed at ingest with utils.FailureSignature. Runs stored
+-- before this migration have no signature and are signed when reported.
+ALTER TABLE public.spec_runs
+ADD COLUMN failure_signature varchar(64) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_spec_runs_failure_signature ON spec_runs (failure_signature) WHERE failure_signature <> '';
@@ -0,0 +1,16 @@
+CREATE TABLE public.quality_gates (
+    id bigserial PRIMARY KEY,
+    project_id bigint NOT NULL,
+    min_pass_rate double precision,
+    max_new_failures integer,
+    max_duration_increase double precision,
+    required_tags text NOT NULL DEFAULT '[]',
+    no_critical_failures boolean NOT NULL DEFAULT false,
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    FOREIGN KEY (project_id)
+    REFERENCES project_details (id)
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_quality_gates_project_id ON quality_gates (project_id);
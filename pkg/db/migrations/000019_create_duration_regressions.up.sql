@@ -0,0 +1,25 @@
+CREATE TABLE public.duration_regressions (
+    id bigserial PRIMARY KEY,
+    project_id bigint NOT NULL,
+    test_run_id bigint NOT NULL,
+    git_branch text,
+    git_sha text,
+    kind text NOT NULL,
+    suite_name text NOT NULL,
+    spec_description text,
+    duration_ms bigint NOT NULL,
+    baseline_mean_ms double precision NOT NULL,
+    baseline_stddev_ms double precision NOT NULL,
+    baseline_samples integer NOT NULL,
+    z_score double precision NOT NULL,
+    created_at timestamp with time zone DEFAULT now(),
+    FOREIGN KEY (project_id)
+    REFERENCES project_details (id)
+    ON DELETE CASCADE,
+    FOREIGN KEY (test_run_id)
+    REFERENCES public.test_runs(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_duration_regressions_project_id_created_at ON duration_regressions (project_id, created_at);
+CREATE INDEX idx_duration_regressions_test_run_id ON duration_regressions (test_run_id);
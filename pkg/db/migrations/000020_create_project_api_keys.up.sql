@@ -0,0 +1,16 @@
+CREATE TABLE public.project_api_keys (
+    id bigserial PRIMARY KEY,
+    project_id bigint NOT NULL,
+    name text NOT NULL,
+    prefix text NOT NULL,
+    key_hash text NOT NULL,
+    created_at timestamp with time zone DEFAULT now(),
+    last_used_at timestamp with time zone,
+    revoked_at timestamp with time zone,
+    FOREIGN KEY (project_id)
+    REFERENCES project_details (id)
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_project_api_keys_key_hash ON project_api_keys (key_hash);
+CREATE INDEX idx_project_api_keys_project_id ON project_api_keys (project_id);
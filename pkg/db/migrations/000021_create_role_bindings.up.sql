@@ -0,0 +1,15 @@
+CREATE TABLE public.role_bindings (
+    id bigserial PRIMARY KEY,
+    role text NOT NULL,
+    subject_type text NOT NULL,
+    subject text NOT NULL,
+    project_id bigint,
+    team_name text NOT NULL DEFAULT '',
+    created_at timestamp with time zone DEFAULT now(),
+    FOREIGN KEY (project_id)
+    REFERENCES project_details (id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_role_bindings_subject ON role_bindings (subject_type, subject);
+CREATE UNIQUE INDEX idx_role_bindings_unique ON role_bindings (role, subject_type, subject, COALESCE(project_id, 0), team_name);
@@ -0,0 +1,35 @@
+CREATE TABLE public.audit_events (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone DEFAULT now(),
+    actor_type text NOT NULL,
+    actor text NOT NULL DEFAULT '',
+    method text NOT NULL,
+    route text NOT NULL,
+    path text NOT NULL,
+    status integer NOT NULL,
+    target_type text NOT NULL DEFAULT '',
+    target_id text NOT NULL DEFAULT '',
+    project_id text NOT NULL DEFAULT '',
+    before text,
+    after text,
+    changes text
+);
+
+CREATE INDEX idx_audit_events_created_at ON audit_events (created_at);
+CREATE INDEX idx_audit_events_actor ON audit_events (actor_type, actor);
+CREATE INDEX idx_audit_events_target ON audit_events (target_type, target_id);
+CREATE INDEX idx_audit_events_project_id ON audit_events (project_id);
+
+CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+CREATE TRIGGER audit_events_no_truncate
+    BEFORE TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_audit_events_organization;
+ALTER TABLE audit_events DROP COLUMN IF EXISTS organization_id;
+
+DROP INDEX IF EXISTS idx_role_bindings_unique;
+ALTER TABLE role_bindings DROP COLUMN IF EXISTS organization_id;
+CREATE UNIQUE INDEX idx_role_bindings_unique ON role_bindings (role, subject_type, subject, COALESCE(project_id, 0), team_name);
+
+DROP INDEX IF EXISTS idx_project_details_organization_name;
+ALTER TABLE project_details DROP COLUMN IF EXISTS organization_id;
+
+DROP TABLE IF EXISTS public.organizations;
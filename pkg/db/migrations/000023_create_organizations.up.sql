@@ -0,0 +1,22 @@
+CREATE TABLE public.organizations (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL UNIQUE,
+    created_at timestamp with time zone DEFAULT now()
+);
+
+-- Existing data belongs to the default organization, which must keep ID 1
+INSERT INTO organizations (id, name) VALUES (1, 'default');
+SELECT setval('organizations_id_seq', (SELECT MAX(id) FROM organizations));
+
+ALTER TABLE project_details
+    ADD COLUMN organization_id bigint NOT NULL DEFAULT 1 REFERENCES organizations (id);
+CREATE INDEX idx_project_details_organization_name ON project_details (organization_id, name);
+
+ALTER TABLE role_bindings
+    ADD COLUMN organization_id bigint NOT NULL DEFAULT 1 REFERENCES organizations (id) ON DELETE CASCADE;
+DROP INDEX IF EXISTS idx_role_bindings_unique;
+CREATE UNIQUE INDEX idx_role_bindings_unique ON role_bindings (organization_id, role, subject_type, subject, COALESCE(project_id, 0), team_name);
+
+ALTER TABLE audit_events
+    ADD COLUMN organization_id bigint NOT NULL DEFAULT 1;
+CREATE INDEX idx_audit_events_organization ON audit_events (organization_id, id);
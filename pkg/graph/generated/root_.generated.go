@@ -40,6 +40,22 @@ type DirectiveRoot struct {
 }
 
 type ComplexityRoot struct {
+	DurationRegression struct {
+		BaselineMeanMs   func(childComplexity int) int
+		BaselineSamples  func(childComplexity int) int
+		BaselineStddevMs func(childComplexity int) int
+		CreatedAt        func(childComplexity int) int
+		DurationMs       func(childComplexity int) int
+		GitBranch        func(childComplexity int) int
+		GitSha           func(childComplexity int) int
+		ID               func(childComplexity int) int
+		Kind             func(childComplexity int) int
+		SpecDescription  func(childComplexity int) int
+		SuiteName        func(childComplexity int) int
+		TestRunID        func(childComplexity int) int
+		ZScore           func(childComplexity int) int
+	}
+
 	PageInfo struct {
 		EndCursor       func(childComplexity int) int
 		HasNextPage     func(childComplexity int) int
@@ -48,9 +64,11 @@ type ComplexityRoot struct {
 	}
 
 	Query struct {
-		TestRun     func(childComplexity int, testRunFilter modelv2.TestRunFilter) int
-		TestRunByID func(childComplexity int, id int) int
-		TestRuns    func(childComplexity int, first *int, after *string, desc *bool) int
+		DurationRegressions func(childComplexity int, projectID string, branch *string, first *int) int
+		TestHistory         func(childComplexity int, fingerprint string, first *int, after *string, desc *bool) int
+		TestRun             func(childComplexity int, testRunFilter modelv2.TestRunFilter) int
+		TestRunByID         func(childComplexity int, id int) int
+		TestRuns            func(childComplexity int, first *int, after *string, desc *bool) int
 	}
 
 	SpecRun struct {
@@ -78,6 +96,33 @@ type ComplexityRoot struct {
 		Name func(childComplexity int) int
 	}
 
+	TestHistory struct {
+		Failed           func(childComplexity int) int
+		Fingerprint      func(childComplexity int) int
+		MedianDurationMs func(childComplexity int) int
+		PageInfo         func(childComplexity int) int
+		PassRate         func(childComplexity int) int
+		Passed           func(childComplexity int) int
+		Runs             func(childComplexity int) int
+		Skipped          func(childComplexity int) int
+		SpecDescription  func(childComplexity int) int
+		SuiteName        func(childComplexity int) int
+		TotalCount       func(childComplexity int) int
+	}
+
+	TestHistoryRun struct {
+		BuildURL   func(childComplexity int) int
+		DurationMs func(childComplexity int) int
+		EndTime    func(childComplexity int) int
+		GitBranch  func(childComplexity int) int
+		GitSha     func(childComplexity int) int
+		Message    func(childComplexity int) int
+		SpecRunID  func(childComplexity int) int
+		StartTime  func(childComplexity int) int
+		Status     func(childComplexity int) int
+		TestRunID  func(childComplexity int) int
+	}
+
 	TestRun struct {
 		BuildTriggerActor func(childComplexity int) int
 		BuildURL          func(childComplexity int) int
@@ -122,6 +167,97 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 	_ = ec
 	switch typeName + "." + field {
 
+	case "DurationRegression.baselineMeanMs":
+		if e.complexity.DurationRegression.BaselineMeanMs == nil {
+			break
+		}
+
+		return e.complexity.DurationRegression.BaselineMeanMs(childComplexity), true
+
+	case "DurationRegression.baselineSamples":
+		if e.complexity.DurationRegression.BaselineSamples == nil {
+			break
+		}
+
+		return e.complexity.DurationRegression.BaselineSamples(childComplexity), true
+
+	case "DurationRegression.baselineStddevMs":
+		if e.complexity.DurationRegression.BaselineStddevMs == nil {
+			break
+		}
+
+		return e.complexity.DurationRegression.BaselineStddevMs(childComplexity), true
+
+	case "DurationRegression.createdAt":
+		if e.complexity.DurationRegression.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.DurationRegression.CreatedAt(childComplexity), true
+
+	case "DurationRegression.durationMs":
+		if e.complexity.DurationRegression.DurationMs == nil {
+			break
+		}
+
+		return e.complexity.DurationRegression.DurationMs(childComplexity), true
+
+	case "DurationRegression.gitBranch":
+		if e.complexity.DurationRegression.GitBranch == nil {
+			break
+		}
+
+		return e.complexity.DurationRegression.GitBranch(childComplexity), true
+
+	case "DurationRegression.gitSha":
+		if e.complexity.DurationRegression.GitSha == nil {
+			break
+		}
+
+		return e.complexity.DurationRegression.GitSha(childComplexity), true
+
+	case "DurationRegression.id":
+		if e.complexity.DurationRegression.ID == nil {
+			break
+		}
+
+		return e.complexity.DurationRegression.ID(childComplexity), true
+
+	case "DurationRegression.kind":
+		if e.complexity.DurationRegression.Kind == nil {
+			break
+		}
+
+		return e.complexity.DurationRegression.Kind(childComplexity), true
+
+	case "DurationRegression.specDescription":
+		if e.complexity.DurationRegression.SpecDescription == nil {
+			break
+		}
+
+		return e.complexity.DurationRegression.SpecDescription(childComplexity), true
+
+	case "DurationRegression.suiteName":
+		if e.complexity.DurationRegression.SuiteName == nil {
+			break
+		}
+
+		return e.complexity.DurationRegression.SuiteName(childComplexity), true
+
+	case "DurationRegression.testRunId":
+		if e.complexity.DurationRegression.TestRunID == nil {
+			break
+		}
+
+		return e.complexity.DurationRegression.TestRunID(childComplexity), true
+
+	case "DurationRegression.zScore":
+		if e.complexity.DurationRegression.ZScore == nil {
+			break
+		}
+
+		return e.complexity.DurationRegression.ZScore(childComplexity), true
+
 	case "PageInfo.endCursor":
 		if e.complexity.PageInfo.EndCursor == nil {
 			break
@@ -150,6 +286,30 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.PageInfo.StartCursor(childComplexity), true
 
+	case "Query.durationRegressions":
+		if e.complexity.Query.DurationRegressions == nil {
+			break
+		}
+
+		args, err := ec.field_Query_durationRegressions_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.DurationRegressions(childComplexity, args["projectId"].(string), args["branch"].(*string), args["first"].(*int)), true
+
+	case "Query.testHistory":
+		if e.complexity.Query.TestHistory == nil {
+			break
+		}
+
+		args, err := ec.field_Query_testHistory_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.TestHistory(childComplexity, args["fingerprint"].(string), args["first"].(*int), args["after"].(*string), args["desc"].(*bool)), true
+
 	case "Query.testRun":
 		if e.complexity.Query.TestRun == nil {
 			break
@@ -298,6 +458,153 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Tag.Name(childComplexity), true
 
+	case "TestHistory.failed":
+		if e.complexity.TestHistory.Failed == nil {
+			break
+		}
+
+		return e.complexity.TestHistory.Failed(childComplexity), true
+
+	case "TestHistory.fingerprint":
+		if e.complexity.TestHistory.Fingerprint == nil {
+			break
+		}
+
+		return e.complexity.TestHistory.Fingerprint(childComplexity), true
+
+	case "TestHistory.medianDurationMs":
+		if e.complexity.TestHistory.MedianDurationMs == nil {
+			break
+		}
+
+		return e.complexity.TestHistory.MedianDurationMs(childComplexity), true
+
+	case "TestHistory.pageInfo":
+		if e.complexity.TestHistory.PageInfo == nil {
+			break
+		}
+
+		return e.complexity.TestHistory.PageInfo(childComplexity), true
+
+	case "TestHistory.passRate":
+		if e.complexity.TestHistory.PassRate == nil {
+			break
+		}
+
+		return e.complexity.TestHistory.PassRate(childComplexity), true
+
+	case "TestHistory.passed":
+		if e.complexity.TestHistory.Passed == nil {
+			break
+		}
+
+		return e.complexity.TestHistory.Passed(childComplexity), true
+
+	case "TestHistory.runs":
+		if e.complexity.TestHistory.Runs == nil {
+			break
+		}
+
+		return e.complexity.TestHistory.Runs(childComplexity), true
+
+	case "TestHistory.skipped":
+		if e.complexity.TestHistory.Skipped == nil {
+			break
+		}
+
+		return e.complexity.TestHistory.Skipped(childComplexity), true
+
+	case "TestHistory.specDescription":
+		if e.complexity.TestHistory.SpecDescription == nil {
+			break
+		}
+
+		return e.complexity.TestHistory.SpecDescription(childComplexity), true
+
+	case "TestHistory.suiteName":
+		if e.complexity.TestHistory.SuiteName == nil {
+			break
+		}
+
+		return e.complexity.TestHistory.SuiteName(childComplexity), true
+
+	case "TestHistory.totalCount":
+		if e.complexity.TestHistory.TotalCount == nil {
+			break
+		}
+
+		return e.complexity.TestHistory.TotalCount(childComplexity), true
+
+	case "TestHistoryRun.buildUrl":
+		if e.complexity.TestHistoryRun.BuildURL == nil {
+			break
+		}
+
+		return e.complexity.TestHistoryRun.BuildURL(childComplexity), true
+
+	case "TestHistoryRun.durationMs":
+		if e.complexity.TestHistoryRun.DurationMs == nil {
+			break
+		}
+
+		return e.complexity.TestHistoryRun.DurationMs(childComplexity), true
+
+	case "TestHistoryRun.endTime":
+		if e.complexity.TestHistoryRun.EndTime == nil {
+			break
+		}
+
+		return e.complexity.TestHistoryRun.EndTime(childComplexity), true
+
+	case "TestHistoryRun.gitBranch":
+		if e.complexity.TestHistoryRun.GitBranch == nil {
+			break
+		}
+
+		return e.complexity.TestHistoryRun.GitBranch(childComplexity), true
+
+	case "TestHistoryRun.gitSha":
+		if e.complexity.TestHistoryRun.GitSha == nil {
+			break
+		}
+
+		return e.complexity.TestHistoryRun.GitSha(childComplexity), true
+
+	case "TestHistoryRun.message":
+		if e.complexity.TestHistoryRun.Message == nil {
+			break
+		}
+
+		return e.complexity.TestHistoryRun.Message(childComplexity), true
+
+	case "TestHistoryRun.specRunId":
+		if e.complexity.TestHistoryRun.SpecRunID == nil {
+			break
+		}
+
+		return e.complexity.TestHistoryRun.SpecRunID(childComplexity), true
+
+	case "TestHistoryRun.startTime":
+		if e.complexity.TestHistoryRun.StartTime == nil {
+			break
+		}
+
+		return e.complexity.TestHistoryRun.StartTime(childComplexity), true
+
+	case "TestHistoryRun.status":
+		if e.complexity.TestHistoryRun.Status == nil {
+			break
+		}
+
+		return e.complexity.TestHistoryRun.Status(childComplexity), true
+
+	case "TestHistoryRun.testRunId":
+		if e.complexity.TestHistoryRun.TestRunID == nil {
+			break
+		}
+
+		return e.complexity.TestHistoryRun.TestRunID(childComplexity), true
+
 	case "TestRun.buildTriggerActor":
 		if e.complexity.TestRun.BuildTriggerActor == nil {
 			break
@@ -541,6 +848,8 @@ type Query {
   testRuns(first: Int, after: String, desc: Boolean): TestRunConnection!
   testRun(testRunFilter: TestRunFilter!): [TestRun!]!
   testRunById(id: Int!): TestRun
+  testHistory(fingerprint: String!, first: Int, after: String, desc: Boolean): TestHistory
+  durationRegressions(projectId: String!, branch: String, first: Int): [DurationRegression!]!
 }
 
 type PageInfo {
@@ -560,6 +869,49 @@ type TestRunConnection {
   pageInfo: PageInfo!
   totalCount: Int!
 }
+
+type TestHistoryRun {
+  testRunId: Int!
+  specRunId: Int!
+  status: String
+  message: String
+  startTime: String
+  endTime: String
+  durationMs: Int
+  gitBranch: String
+  gitSha: String
+  buildUrl: String
+}
+
+type TestHistory {
+  fingerprint: String!
+  suiteName: String!
+  specDescription: String!
+  totalCount: Int!
+  passed: Int!
+  failed: Int!
+  skipped: Int!
+  passRate: Float!
+  medianDurationMs: Int
+  runs: [TestHistoryRun!]!
+  pageInfo: PageInfo!
+}
+
+type DurationRegression {
+  id: Int!
+  testRunId: Int!
+  gitBranch: String
+  gitSha: String
+  kind: String!
+  suiteName: String!
+  specDescription: String
+  durationMs: Int!
+  baselineMeanMs: Float!
+  baselineStddevMs: Float!
+  baselineSamples: Int!
+  zScore: Float!
+  createdAt: String!
+}
 `, BuiltIn: false},
 }
 var parsedSchema = gqlparser.MustLoadSchema(sources...)
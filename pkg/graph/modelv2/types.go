@@ -2,6 +2,22 @@
 
 package modelv2
 
+type DurationRegression struct {
+	ID               int     `json:"id"`
+	TestRunID        int     `json:"testRunId"`
+	GitBranch        *string `json:"gitBranch,omitempty"`
+	GitSha           *string `json:"gitSha,omitempty"`
+	Kind             string  `json:"kind"`
+	SuiteName        string  `json:"suiteName"`
+	SpecDescription  *string `json:"specDescription,omitempty"`
+	DurationMs       int     `json:"durationMs"`
+	BaselineMeanMs   float64 `json:"baselineMeanMs"`
+	BaselineStddevMs float64 `json:"baselineStddevMs"`
+	BaselineSamples  int     `json:"baselineSamples"`
+	ZScore           float64 `json:"zScore"`
+	CreatedAt        string  `json:"createdAt"`
+}
+
 type PageInfo struct {
 	HasNextPage     bool   `json:"hasNextPage"`
 	HasPreviousPage bool   `json:"hasPreviousPage"`
@@ -37,6 +53,33 @@ type Tag struct {
 	Name *string `json:"name,omitempty"`
 }
 
+type TestHistory struct {
+	Fingerprint      string            `json:"fingerprint"`
+	SuiteName        string            `json:"suiteName"`
+	SpecDescription  string            `json:"specDescription"`
+	TotalCount       int               `json:"totalCount"`
+	Passed           int               `json:"passed"`
+	Failed           int               `json:"failed"`
+	Skipped          int               `json:"skipped"`
+	PassRate         float64           `json:"passRate"`
+	MedianDurationMs *int              `json:"medianDurationMs,omitempty"`
+	Runs             []*TestHistoryRun `json:"runs"`
+	PageInfo         *PageInfo         `json:"pageInfo"`
+}
+
+type TestHistoryRun struct {
+	TestRunID  int     `json:"testRunId"`
+	SpecRunID  int     `json:"specRunId"`
+	Status     *string `json:"status,omitempty"`
+	Message    *string `json:"message,omitempty"`
+	StartTime  *string `json:"startTime,omitempty"`
+	EndTime    *string `json:"endTime,omitempty"`
+	DurationMs *int    `json:"durationMs,omitempty"`
+	GitBranch  *string `json:"gitBranch,omitempty"`
+	GitSha     *string `json:"gitSha,omitempty"`
+	BuildURL   *string `json:"buildUrl,omitempty"`
+}
+
 type TestRun struct {
 	ID                int         `json:"id"`
 	TestProjectName   *string     `json:"testProjectName,omitempty"`
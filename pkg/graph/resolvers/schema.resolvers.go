@@ -6,10 +6,16 @@ package resolvers
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/auth"
 	"github.com/guidewire/fern-reporter/pkg/graph/generated"
 	"github.com/guidewire/fern-reporter/pkg/graph/modelv2"
+	"github.com/guidewire/fern-reporter/pkg/models"
 	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
 )
 
 // TestRuns is the resolver for the testRuns field.
@@ -24,8 +30,9 @@ func (r *queryResolver) TestRuns(ctx context.Context, first *int, after *string,
 
 	var testRuns []*modelv2.TestRun
 	// Perform the join between test_runs and project_details to get project details (UUID, project_name, team_name)
-	if err := r.DB.Preload("SuiteRuns.SpecRuns.Tags").
-		Joins("JOIN project_details ON project_details.id = test_runs.project_id").
+	query := r.DB.Preload("SuiteRuns.SpecRuns.Tags").
+		Joins("JOIN project_details ON project_details.id = test_runs.project_id")
+	if err := auth.FilterAllowedProjects(ctx, auth.ScopeProjects(ctx, query)).
 		Select("test_runs.*, project_details.uuid, project_details.name AS test_project_name, project_details.team_name").
 		Offset(offset).
 		Limit(*first).
@@ -36,7 +43,8 @@ func (r *queryResolver) TestRuns(ctx context.Context, first *int, after *string,
 
 	// Get the total count of TestRun records.
 	var totalCount int64
-	if err := r.DB.Model(&modelv2.TestRun{}).Count(&totalCount).Error; err != nil {
+	countQuery := auth.ScopeProjectRows(ctx, r.DB.Model(&modelv2.TestRun{}), "test_runs.project_id")
+	if err := auth.FilterAllowedProjectRows(ctx, countQuery, "test_runs.project_id").Count(&totalCount).Error; err != nil {
 		return nil, err
 	}
 
@@ -69,18 +77,134 @@ func (r *queryResolver) TestRuns(ctx context.Context, first *int, after *string,
 // TestRun is the resolver for the testRun field.
 func (r *queryResolver) TestRun(ctx context.Context, testRunFilter modelv2.TestRunFilter) ([]*modelv2.TestRun, error) {
 	var testRuns []*modelv2.TestRun
-	r.DB.Preload("SuiteRuns.SpecRuns.Tags").Where("id = ?", testRunFilter.ID).Where("test_project_name = ?", testRunFilter.TestProjectName).Find(&testRuns)
+	query := r.DB.Preload("SuiteRuns.SpecRuns.Tags").Where("id = ?", testRunFilter.ID).Where("test_project_name = ?", testRunFilter.TestProjectName)
+	query = auth.ScopeProjectRows(ctx, query, "test_runs.project_id")
+	auth.FilterAllowedProjectRows(ctx, query, "test_runs.project_id").Find(&testRuns)
 	return testRuns, nil
 }
 
 // TestRunByID is the resolver for the testRunById field.
 func (r *queryResolver) TestRunByID(ctx context.Context, id int) (*modelv2.TestRun, error) {
 	var testRun *modelv2.TestRun
-	r.DB.Preload("SuiteRuns.SpecRuns.Tags").Where("id = ?", id).First(&testRun)
+	query := auth.ScopeProjectRows(ctx, r.DB.Preload("SuiteRuns.SpecRuns.Tags").Where("id = ?", id), "test_runs.project_id")
+	auth.FilterAllowedProjectRows(ctx, query, "test_runs.project_id").First(&testRun)
 
 	return testRun, nil
 }
 
+// TestHistory is the resolver for the testHistory field.
+func (r *queryResolver) TestHistory(ctx context.Context, fingerprint string, first *int, after *string, desc *bool) (*modelv2.TestHistory, error) {
+	offset := utils.DecodeCursor(after)
+
+	options := handlers.TestHistoryOptions{Offset: offset, Limit: 50}
+	if first != nil {
+		options.Limit = *first
+	}
+	if desc != nil {
+		options.Descending = *desc
+	}
+
+	history, err := handlers.FindTestHistory(r.DB.WithContext(ctx), fingerprint, options)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	runs := make([]*modelv2.TestHistoryRun, len(history.Runs))
+	for i, entry := range history.Runs {
+		startTime := entry.StartTime.Format(time.RFC3339)
+		endTime := entry.EndTime.Format(time.RFC3339)
+		durationMs := int(entry.DurationMs)
+		runs[i] = &modelv2.TestHistoryRun{
+			TestRunID:  int(entry.TestRunID),
+			SpecRunID:  int(entry.SpecRunID),
+			Status:     &entry.Status,
+			Message:    &entry.Message,
+			StartTime:  &startTime,
+			EndTime:    &endTime,
+			DurationMs: &durationMs,
+			GitBranch:  &entry.GitBranch,
+			GitSha:     &entry.GitSha,
+			BuildURL:   &entry.BuildUrl,
+		}
+	}
+
+	pageInfo := &modelv2.PageInfo{
+		HasNextPage:     offset+len(runs) < history.Summary.Runs,
+		HasPreviousPage: offset > 0,
+	}
+	if len(runs) > 0 {
+		pageInfo.StartCursor = utils.EncodeCursor(offset + 1)
+		pageInfo.EndCursor = utils.EncodeCursor(offset + len(runs))
+	}
+
+	var medianDurationMs *int
+	if history.Summary.MedianDurationMs != nil {
+		median := int(*history.Summary.MedianDurationMs)
+		medianDurationMs = &median
+	}
+
+	return &modelv2.TestHistory{
+		Fingerprint:      history.TestCase.Fingerprint,
+		SuiteName:        history.TestCase.SuiteName,
+		SpecDescription:  history.TestCase.SpecDescription,
+		TotalCount:       history.Summary.Runs,
+		Passed:           history.Summary.Passed,
+		Failed:           history.Summary.Failed,
+		Skipped:          history.Summary.Skipped,
+		PassRate:         history.Summary.PassRate,
+		MedianDurationMs: medianDurationMs,
+		Runs:             runs,
+		PageInfo:         pageInfo,
+	}, nil
+}
+
+// DurationRegressions is the resolver for the durationRegressions field.
+func (r *queryResolver) DurationRegressions(ctx context.Context, projectID string, branch *string, first *int) ([]*modelv2.DurationRegression, error) {
+	var project models.ProjectDetails
+	query := auth.ScopeProjects(ctx, r.DB.Where("uuid = ?", projectID))
+	err := auth.FilterAllowedProjects(ctx, query).First(&project).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return []*modelv2.DurationRegression{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	filter := handlers.DurationRegressionFilter{ProjectID: project.ID, Limit: 100}
+	if branch != nil {
+		filter.Branch = *branch
+	}
+	if first != nil {
+		filter.Limit = *first
+	}
+
+	regressions, err := handlers.FindDurationRegressions(r.DB, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*modelv2.DurationRegression, len(regressions))
+	for i, regression := range regressions {
+		result[i] = &modelv2.DurationRegression{
+			ID:               int(regression.ID),
+			TestRunID:        int(regression.TestRunID),
+			GitBranch:        &regression.GitBranch,
+			GitSha:           &regression.GitSha,
+			Kind:             regression.Kind,
+			SuiteName:        regression.SuiteName,
+			SpecDescription:  &regression.SpecDescription,
+			DurationMs:       int(regression.DurationMs),
+			BaselineMeanMs:   regression.BaselineMeanMs,
+			BaselineStddevMs: regression.BaselineStddevMs,
+			BaselineSamples:  regression.BaselineSamples,
+			ZScore:           regression.ZScore,
+			CreatedAt:        regression.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return result, nil
+}
+
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
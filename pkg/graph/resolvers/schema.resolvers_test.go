@@ -9,7 +9,9 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/guidewire/fern-reporter/pkg/auth"
 	"github.com/guidewire/fern-reporter/pkg/graph/generated"
+	"github.com/guidewire/fern-reporter/pkg/graph/modelv2"
 	"github.com/guidewire/fern-reporter/pkg/graph/resolvers"
 	"github.com/guidewire/fern-reporter/pkg/utils"
 	. "github.com/onsi/ginkgo/v2"
@@ -892,6 +894,268 @@ var _ = Describe("Handlers", func() {
 		})
 	})
 
+	Context("test TestHistory resolver", func() {
+		It("should return the history page and summary of a test case", func() {
+			start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_cases" WHERE fingerprint = $1 ORDER BY "test_cases"."id" LIMIT $2`)).
+				WithArgs("abc123", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "fingerprint", "suite_name", "spec_description"}).
+					AddRow(7, "abc123", "Cart", "checks out"))
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT spec_runs.status, spec_runs.start_time, spec_runs.end_time FROM "spec_runs" WHERE spec_runs.test_case_id = $1`)).
+				WithArgs(7).
+				WillReturnRows(sqlmock.NewRows([]string{"status", "start_time", "end_time"}).
+					AddRow("passed", start, start.Add(2*time.Second)).
+					AddRow("failed", start, start.Add(4*time.Second)).
+					AddRow("passed", start, start.Add(3*time.Second)))
+
+			mock.ExpectQuery(`SELECT test_runs.id AS test_run_id, spec_runs.id AS spec_run_id, .* FROM "spec_runs" JOIN suite_runs .* WHERE spec_runs.test_case_id = \$1 ORDER BY test_runs.start_time DESC, test_runs.id DESC, spec_runs.id DESC LIMIT \$2`).
+				WithArgs(7, 2).
+				WillReturnRows(sqlmock.NewRows([]string{"test_run_id", "spec_run_id", "status", "message", "start_time", "end_time", "git_branch", "git_sha", "build_url"}).
+					AddRow(3, 30, "passed", "", start, start.Add(3*time.Second), "main", "sha3", "https://ci/3").
+					AddRow(2, 20, "failed", "boom", start, start.Add(4*time.Second), "main", "sha2", "https://ci/2"))
+
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			gqlHandler := handler.New(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver}))
+			gqlHandler.AddTransport(transport.POST{})
+			cli := client.New(gqlHandler)
+
+			query := `
+            query {
+                testHistory(fingerprint: "abc123", first: 2, desc: true) {
+                    fingerprint
+                    suiteName
+                    specDescription
+                    totalCount
+                    passed
+                    failed
+                    passRate
+                    medianDurationMs
+                    runs {
+                        testRunId
+                        status
+                        message
+                        durationMs
+                        gitSha
+                        buildUrl
+                    }
+                    pageInfo {
+                        hasNextPage
+                        endCursor
+                    }
+                }
+            }
+        `
+
+			var response struct {
+				TestHistory struct {
+					Fingerprint      string  `json:"fingerprint"`
+					SuiteName        string  `json:"suiteName"`
+					SpecDescription  string  `json:"specDescription"`
+					TotalCount       int     `json:"totalCount"`
+					Passed           int     `json:"passed"`
+					Failed           int     `json:"failed"`
+					PassRate         float64 `json:"passRate"`
+					MedianDurationMs int     `json:"medianDurationMs"`
+					Runs             []struct {
+						TestRunID  int    `json:"testRunId"`
+						Status     string `json:"status"`
+						Message    string `json:"message"`
+						DurationMs int    `json:"durationMs"`
+						GitSha     string `json:"gitSha"`
+						BuildURL   string `json:"buildUrl"`
+					} `json:"runs"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				}
+			}
+
+			err := cli.Post(query, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			Expect(response.TestHistory.Fingerprint).To(Equal("abc123"))
+			Expect(response.TestHistory.SpecDescription).To(Equal("checks out"))
+			Expect(response.TestHistory.TotalCount).To(Equal(3))
+			Expect(response.TestHistory.Passed).To(Equal(2))
+			Expect(response.TestHistory.Failed).To(Equal(1))
+			Expect(response.TestHistory.PassRate).To(BeNumerically("~", 2.0/3.0))
+			Expect(response.TestHistory.MedianDurationMs).To(Equal(3000))
+			Expect(response.TestHistory.Runs).To(HaveLen(2))
+			Expect(response.TestHistory.Runs[0].GitSha).To(Equal("sha3"))
+			Expect(response.TestHistory.Runs[1].Message).To(Equal("boom"))
+			Expect(response.TestHistory.Runs[1].DurationMs).To(Equal(4000))
+			Expect(response.TestHistory.PageInfo.HasNextPage).To(BeTrue())
+			Expect(response.TestHistory.PageInfo.EndCursor).To(Equal(utils.EncodeCursor(2)))
+		})
+
+		It("should return null for an unknown fingerprint", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_cases" WHERE fingerprint = $1`)).
+				WithArgs("missing", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			gqlHandler := handler.New(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver}))
+			gqlHandler.AddTransport(transport.POST{})
+			cli := client.New(gqlHandler)
+
+			var response struct {
+				TestHistory *struct {
+					Fingerprint string `json:"fingerprint"`
+				}
+			}
+			err := cli.Post(`query { testHistory(fingerprint: "missing") { fingerprint } }`, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.TestHistory).To(BeNil())
+		})
+	})
+
+	Context("test DurationRegressions resolver", func() {
+		It("should return the duration regressions of a project branch", func() {
+			created := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "project_details" WHERE uuid = $1 ORDER BY "project_details"."id" LIMIT $2`)).
+				WithArgs("project-uuid", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "uuid"}).AddRow(7, "project-uuid"))
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "duration_regressions" WHERE project_id = $1 AND git_branch = $2 ORDER BY test_run_id DESC, z_score DESC, id LIMIT $3`)).
+				WithArgs(7, "main", 5).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "test_run_id", "git_branch", "git_sha", "kind", "suite_name", "spec_description",
+					"duration_ms", "baseline_mean_ms", "baseline_stddev_ms", "baseline_samples", "z_score", "created_at"}).
+					AddRow(2, 7, 42, "main", "abc123", "spec", "Cart", "checks out", 8000, 2050.0, 40.5, 12, 146.9, created).
+					AddRow(1, 7, 42, "main", "abc123", "suite", "Cart", "", 90000, 60050.0, 43.3, 12, 691.6, created))
+
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			gqlHandler := handler.New(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver}))
+			gqlHandler.AddTransport(transport.POST{})
+			cli := client.New(gqlHandler)
+
+			var response struct {
+				DurationRegressions []struct {
+					TestRunID       int     `json:"testRunId"`
+					Kind            string  `json:"kind"`
+					SpecDescription string  `json:"specDescription"`
+					DurationMs      int     `json:"durationMs"`
+					BaselineMeanMs  float64 `json:"baselineMeanMs"`
+					BaselineSamples int     `json:"baselineSamples"`
+					ZScore          float64 `json:"zScore"`
+					CreatedAt       string  `json:"createdAt"`
+				}
+			}
+			err := cli.Post(`query {
+                durationRegressions(projectId: "project-uuid", branch: "main", first: 5) {
+                    testRunId kind specDescription durationMs baselineMeanMs baselineSamples zScore createdAt
+                }
+            }`, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			Expect(response.DurationRegressions).To(HaveLen(2))
+			Expect(response.DurationRegressions[0].TestRunID).To(Equal(42))
+			Expect(response.DurationRegressions[0].SpecDescription).To(Equal("checks out"))
+			Expect(response.DurationRegressions[0].DurationMs).To(Equal(8000))
+			Expect(response.DurationRegressions[0].BaselineMeanMs).To(Equal(2050.0))
+			Expect(response.DurationRegressions[0].ZScore).To(Equal(146.9))
+			Expect(response.DurationRegressions[0].CreatedAt).To(Equal("2025-06-01T12:00:00Z"))
+			Expect(response.DurationRegressions[1].Kind).To(Equal("suite"))
+		})
+
+		It("should return an empty list for an unknown project", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "project_details" WHERE uuid = $1`)).
+				WithArgs("missing", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			gqlHandler := handler.New(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver}))
+			gqlHandler.AddTransport(transport.POST{})
+			cli := client.New(gqlHandler)
+
+			var response struct {
+				DurationRegressions []struct {
+					Kind string `json:"kind"`
+				}
+			}
+			err := cli.Post(`query { durationRegressions(projectId: "missing") { kind } }`, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.DurationRegressions).NotTo(BeNil())
+			Expect(response.DurationRegressions).To(BeEmpty())
+		})
+	})
+
+	Context("when the caller is limited to some projects", func() {
+		var (
+			queryResolver *resolvers.Resolver
+			ctx           context.Context
+		)
+
+		allowedRuns := regexp.QuoteMeta(`test_runs.project_id IN (SELECT id FROM "project_details" WHERE name IN ($`)
+
+		BeforeEach(func() {
+			queryResolver = &resolvers.Resolver{DB: gormDb}
+			ctx = auth.WithAllowedProjects(context.Background(), []string{"Cart"})
+		})
+
+		It("should only page through the runs of the allowed projects", func() {
+			first := 10
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT test_runs.*, project_details.uuid, project_details.name AS test_project_name, project_details.team_name FROM "test_runs" JOIN project_details ON project_details.id = test_runs.project_id WHERE project_details.name IN ($1) ORDER BY id ASC LIMIT $2`)).
+				WithArgs("Cart", first).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "test_runs" WHERE `) + allowedRuns).
+				WithArgs("Cart").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+			connection, err := queryResolver.Query().TestRuns(ctx, &first, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			Expect(connection.Edges).To(BeEmpty())
+			Expect(connection.TotalCount).To(Equal(0))
+		})
+
+		It("should not return runs of other projects", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND test_project_name = $2 AND `)+allowedRuns).
+				WithArgs(1, "Search", "Cart").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND `)+allowedRuns).
+				WithArgs(1, "Cart", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			id, projectName := 1, "Search"
+			testRuns, err := queryResolver.Query().TestRun(ctx, modelv2.TestRunFilter{ID: &id, TestProjectName: &projectName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testRuns).To(BeEmpty())
+
+			testRun, err := queryResolver.Query().TestRunByID(ctx, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testRun.ID).To(BeZero())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should not return the history of tests of other projects", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_cases" WHERE fingerprint = $1 AND test_cases.project_id IN (SELECT id FROM "project_details" WHERE name IN ($2))`)).
+				WithArgs("abc123", "Cart", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			history, err := queryResolver.Query().TestHistory(ctx, "abc123", nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(BeNil())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should not return the duration regressions of other projects", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "project_details" WHERE uuid = $1 AND project_details.name IN ($2)`)).
+				WithArgs("search-uuid", "Cart", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			regressions, err := queryResolver.Query().DurationRegressions(ctx, "search-uuid", nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(regressions).To(BeEmpty())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
 })
 
 var gql_response struct {
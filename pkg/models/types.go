@@ -17,10 +17,12 @@ type TestRun struct {
 	TestSeed          uint64     `json:"test_seed"`
 	StartTime         time.Time  `json:"start_time"`
 	EndTime           time.Time  `json:"end_time"`
+	Status            string     `json:"status"`
 	GitBranch         string     `json:"git_branch"`
 	GitSha            string     `json:"git_sha"`
 	BuildTriggerActor string     `json:"build_trigger_actor"`
 	BuildUrl          string     `json:"build_url"`
+	CreatedAt         time.Time  `json:"-" gorm:"->"` // Set by the database
 	SuiteRuns         []SuiteRun `json:"suite_runs" gorm:"foreignKey:TestRunID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 
 	// Relationship with ProjectDetails
@@ -38,14 +40,108 @@ type SuiteRun struct {
 }
 
 type SpecRun struct {
+	ID               uint64    `json:"id" gorm:"primaryKey"`
+	SuiteID          uint64    `json:"suite_id"`
+	TestCaseID       *uint64   `json:"test_case_id"`
+	SpecDescription  string    `json:"spec_description"`
+	Status           string    `json:"status"`
+	Message          string    `json:"message"`
+	FailureSignature string    `json:"failure_signature,omitempty"`
+	Tags             []Tag     `json:"tags" gorm:"many2many:spec_run_tags;"`
+	StartTime        time.Time `json:"start_time"`
+	EndTime          time.Time `json:"end_time"`
+}
+
+type TestCase struct {
 	ID              uint64    `json:"id" gorm:"primaryKey"`
-	SuiteID         uint64    `json:"suite_id"`
+	ProjectID       uint64    `json:"-" gorm:"uniqueIndex:idx_test_cases_project_fingerprint"`
+	Fingerprint     string    `json:"fingerprint" gorm:"uniqueIndex:idx_test_cases_project_fingerprint"`
+	SuiteName       string    `json:"suite_name"`
 	SpecDescription string    `json:"spec_description"`
-	Status          string    `json:"status"`
-	Message         string    `json:"message"`
-	Tags            []Tag     `json:"tags" gorm:"many2many:spec_run_tags;"`
-	StartTime       time.Time `json:"start_time"`
-	EndTime         time.Time `json:"end_time"`
+	FirstSeen       time.Time `json:"first_seen"`
+	LastSeen        time.Time `json:"last_seen"`
+	LastStatus      string    `json:"last_status"`
+}
+
+type IdempotencyKey struct {
+	ID             uint64    `json:"id" gorm:"primaryKey"`
+	ProjectID      uint64    `json:"project_id" gorm:"uniqueIndex:idx_idempotency_keys_project_key"`
+	IdempotencyKey string    `json:"idempotency_key" gorm:"uniqueIndex:idx_idempotency_keys_project_key"`
+	PayloadHash    string    `json:"payload_hash"`
+	TestRunID      uint64    `json:"test_run_id"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// ProjectAPIKey lets CI jobs upload to one project without an OAuth token.
+// Only a SHA-256 hash of the key is stored; Prefix keeps the first characters
+// so that keys can be told apart when listed.
+type ProjectAPIKey struct {
+	ID         uint64         `json:"id" gorm:"primaryKey"`
+	ProjectID  uint64         `json:"-"`
+	Name       string         `json:"name"`
+	Prefix     string         `json:"prefix"`
+	KeyHash    string         `json:"-" gorm:"uniqueIndex:idx_project_api_keys_key_hash"`
+	CreatedAt  time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	LastUsedAt *time.Time     `json:"last_used_at"`
+	RevokedAt  *time.Time     `json:"revoked_at"`
+	Project    ProjectDetails `json:"-" gorm:"foreignKey:ProjectID;references:ID"`
+}
+
+// RoleBinding grants a role to a user or group of the identity provider on one
+// project, on every project of a team, or on every project when neither is set,
+// within the organization it belongs to.
+type RoleBinding struct {
+	ID             uint64          `json:"id" gorm:"primaryKey"`
+	Role           string          `json:"role"`
+	SubjectType    string          `json:"subject_type"`
+	Subject        string          `json:"subject"`
+	ProjectID      *uint64         `json:"-"`
+	TeamName       string          `json:"team_name"`
+	OrganizationID uint64          `json:"-" gorm:"default:1"`
+	CreatedAt      time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	Project        *ProjectDetails `json:"project,omitempty" gorm:"foreignKey:ProjectID;references:ID"`
+}
+
+// AuditEvent records a mutation made through the API. Events are only ever
+// inserted. Before and After hold the fields of the target, without lists of
+// nested records, and Changes the fields that differ between them.
+type AuditEvent struct {
+	ID             uint64                 `json:"id" gorm:"primaryKey"`
+	CreatedAt      time.Time              `json:"created_at" gorm:"autoCreateTime"`
+	OrganizationID uint64                 `json:"-" gorm:"default:1"`
+	ActorType      string                 `json:"actor_type"`
+	Actor          string                 `json:"actor"`
+	Method         string                 `json:"method"`
+	Route          string                 `json:"route"`
+	Path           string                 `json:"path"`
+	Status         int                    `json:"status"`
+	TargetType     string                 `json:"target_type"`
+	TargetID       string                 `json:"target_id"`
+	ProjectID      string                 `json:"project_id"`
+	Before         map[string]any         `json:"before" gorm:"serializer:json"`
+	After          map[string]any         `json:"after" gorm:"serializer:json"`
+	Changes        map[string]AuditChange `json:"changes" gorm:"serializer:json"`
+}
+
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+type IngestJob struct {
+	ID             uint64     `json:"id" gorm:"primaryKey"`
+	ProjectID      uint64     `json:"project_id"`
+	Status         string     `json:"status"`
+	Payload        string     `json:"-"`
+	PayloadHash    string     `json:"-"`
+	IdempotencyKey string     `json:"-"`
+	TestRunID      *uint64    `json:"test_run_id"`
+	Error          string     `json:"error,omitempty"`
+	Attempts       int        `json:"attempts"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	StartedAt      *time.Time `json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at"`
 }
 
 type TestRunInsight struct {
@@ -56,6 +152,228 @@ type TestRunInsight struct {
 	PassRate        float32   `json:"pass_rate"`
 }
 
+type FlakySpec struct {
+	SuiteName          string     `json:"suite_name"`
+	SpecDescription    string     `json:"spec_description"`
+	Runs               int        `json:"runs"`
+	Failures           int        `json:"failures"`
+	Flips              int        `json:"flips"`
+	FlipRate           float64    `json:"flip_rate"`
+	LastFailureMessage string     `json:"last_failure_message"`
+	LastFailureTime    *time.Time `json:"last_failure_time"`
+	FirstSeen          time.Time  `json:"first_seen"`
+	LastSeen           time.Time  `json:"last_seen"`
+}
+
+type OrderDependentSpec struct {
+	SuiteName          string    `json:"suite_name"`
+	SpecDescription    string    `json:"spec_description"`
+	GitSha             string    `json:"git_sha"`
+	FailingSeeds       []uint64  `json:"failing_seeds"`
+	PassingSeeds       []uint64  `json:"passing_seeds"`
+	MixedSeeds         []uint64  `json:"mixed_seeds"`
+	LastFailureMessage string    `json:"last_failure_message"`
+	LastSeen           time.Time `json:"last_seen"`
+}
+
+type TestHistoryEntry struct {
+	TestRunID  uint64    `json:"test_run_id"`
+	SpecRunID  uint64    `json:"spec_run_id"`
+	Status     string    `json:"status"`
+	Message    string    `json:"message"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+	DurationMs int64     `json:"duration_ms"`
+	GitBranch  string    `json:"git_branch"`
+	GitSha     string    `json:"git_sha"`
+	BuildUrl   string    `json:"build_url"`
+}
+
+type TestHistorySummary struct {
+	Runs             int     `json:"runs"`
+	Passed           int     `json:"passed"`
+	Failed           int     `json:"failed"`
+	Skipped          int     `json:"skipped"`
+	PassRate         float64 `json:"pass_rate"`
+	MedianDurationMs *int64  `json:"median_duration_ms"`
+}
+
+type TestHistory struct {
+	TestCase TestCase           `json:"test_case"`
+	Summary  TestHistorySummary `json:"summary"`
+	Runs     []TestHistoryEntry `json:"runs"`
+}
+
+type CulpritRun struct {
+	TestRunID         uint64    `json:"test_run_id"`
+	GitSha            string    `json:"git_sha"`
+	BuildTriggerActor string    `json:"build_trigger_actor"`
+	BuildUrl          string    `json:"build_url"`
+	StartTime         time.Time `json:"start_time"`
+	SpecStatus        string    `json:"spec_status,omitempty"`
+}
+
+type CulpritReport struct {
+	TestCase     TestCase     `json:"test_case"`
+	GitBranch    string       `json:"git_branch"`
+	Failing      bool         `json:"failing"`
+	LastPassing  *CulpritRun  `json:"last_passing"`
+	FirstFailing *CulpritRun  `json:"first_failing"`
+	Runs         []CulpritRun `json:"runs"`
+	Suspects     []string     `json:"suspects"`
+}
+
+type FailureCluster struct {
+	Signature         string    `json:"signature"`
+	NormalizedMessage string    `json:"normalized_message"`
+	Message           string    `json:"message"`
+	Count             int       `json:"count"`
+	Specs             int       `json:"specs"`
+	TestRuns          int       `json:"test_runs"`
+	Suites            []string  `json:"suites"`
+	FirstSeen         time.Time `json:"first_seen"`
+	LastSeen          time.Time `json:"last_seen"`
+}
+
+type SpecDiff struct {
+	SuiteName       string `json:"suite_name"`
+	SpecDescription string `json:"spec_description"`
+	BaseStatus      string `json:"base_status,omitempty"`
+	Status          string `json:"status,omitempty"`
+	BaseDurationMs  int64  `json:"base_duration_ms"`
+	DurationMs      int64  `json:"duration_ms"`
+	Message         string `json:"message,omitempty"`
+}
+
+type TestRunDiff struct {
+	TestRunID     uint64     `json:"test_run_id"`
+	BaseTestRunID uint64     `json:"base_test_run_id"`
+	NewlyFailing  []SpecDiff `json:"newly_failing"`
+	NewlyPassing  []SpecDiff `json:"newly_passing"`
+	StillFailing  []SpecDiff `json:"still_failing"`
+	Added         []SpecDiff `json:"added"`
+	Removed       []SpecDiff `json:"removed"`
+	Slower        []SpecDiff `json:"slower"`
+}
+
+type BaselineRun struct {
+	TestRunID uint64    `json:"test_run_id"`
+	GitBranch string    `json:"git_branch"`
+	GitSha    string    `json:"git_sha"`
+	StartTime time.Time `json:"start_time"`
+	BuildUrl  string    `json:"build_url"`
+}
+
+type TestRunRegressions struct {
+	TestRunID      uint64       `json:"test_run_id"`
+	Baseline       *BaselineRun `json:"baseline"`
+	BaselineSource string       `json:"baseline_source,omitempty"`
+	Regressions    []SpecDiff   `json:"regressions"`
+}
+
+type QualityGate struct {
+	ID                  uint64    `json:"-" gorm:"primaryKey"`
+	ProjectID           uint64    `json:"-" gorm:"uniqueIndex:idx_quality_gates_project_id"`
+	MinPassRate         *float64  `json:"min_pass_rate"`
+	MaxNewFailures      *int      `json:"max_new_failures"`
+	MaxDurationIncrease *float64  `json:"max_duration_increase"`
+	RequiredTags        []string  `json:"required_tags" gorm:"serializer:json"`
+	NoCriticalFailures  bool      `json:"no_critical_failures"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+type GateRuleResult struct {
+	Rule   string `json:"rule"`
+	Passed bool   `json:"passed"`
+	Reason string `json:"reason"`
+}
+
+type GateEvaluation struct {
+	ProjectID string           `json:"project_id"`
+	TestRunID uint64           `json:"test_run_id"`
+	Passed    bool             `json:"passed"`
+	Baseline  *BaselineRun     `json:"baseline"`
+	Rules     []GateRuleResult `json:"rules"`
+}
+
+type TrendBucket struct {
+	Start         time.Time `json:"start"`
+	Runs          int       `json:"runs"`
+	Total         int64     `json:"total"`
+	Passed        int64     `json:"passed"`
+	Failed        int64     `json:"failed"`
+	Skipped       int64     `json:"skipped"`
+	PassRate      *float64  `json:"pass_rate"`
+	P50DurationMs *int64    `json:"p50_duration_ms"`
+	P95DurationMs *int64    `json:"p95_duration_ms"`
+}
+
+type SpecDurationStats struct {
+	Fingerprint     string   `json:"fingerprint"`
+	SuiteName       string   `json:"suite_name"`
+	SpecDescription string   `json:"spec_description"`
+	Runs            int      `json:"runs"`
+	P50Ms           int64    `json:"p50_ms"`
+	P90Ms           int64    `json:"p90_ms"`
+	P95Ms           int64    `json:"p95_ms"`
+	P99Ms           int64    `json:"p99_ms"`
+	MaxMs           int64    `json:"max_ms"`
+	StddevMs        *float64 `json:"stddev_ms"`
+}
+
+type SlowSpec struct {
+	SpecDurationStats
+	LatestTestRunID  uint64  `json:"latest_test_run_id"`
+	LatestDurationMs int64   `json:"latest_duration_ms"`
+	Ratio            float64 `json:"ratio"`
+}
+
+type DurationRegression struct {
+	ID               uint64    `json:"id" gorm:"primaryKey"`
+	ProjectID        uint64    `json:"-" gorm:"index:idx_duration_regressions_project_id_created_at"`
+	TestRunID        uint64    `json:"test_run_id" gorm:"index"`
+	GitBranch        string    `json:"git_branch"`
+	GitSha           string    `json:"git_sha"`
+	Kind             string    `json:"kind"`
+	SuiteName        string    `json:"suite_name"`
+	SpecDescription  string    `json:"spec_description,omitempty"`
+	DurationMs       int64     `json:"duration_ms"`
+	BaselineMeanMs   float64   `json:"baseline_mean_ms"`
+	BaselineStddevMs float64   `json:"baseline_stddev_ms"`
+	BaselineSamples  int       `json:"baseline_samples"`
+	ZScore           float64   `json:"z_score"`
+	CreatedAt        time.Time `json:"created_at" gorm:"index:idx_duration_regressions_project_id_created_at"`
+}
+
+type HealthComponents struct {
+	PassRate      *float64 `json:"pass_rate"`
+	Flakiness     *float64 `json:"flakiness"`
+	DurationTrend *float64 `json:"duration_trend"`
+	Staleness     *float64 `json:"staleness"`
+	LongFailing   *float64 `json:"long_failing"`
+}
+
+type ProjectHealth struct {
+	ProjectID        string           `json:"project_id"`
+	Name             string           `json:"name"`
+	TeamName         string           `json:"team_name"`
+	Score            *float64         `json:"score"`
+	Components       HealthComponents `json:"components"`
+	Runs             int              `json:"runs"`
+	Specs            int              `json:"specs"`
+	FlakySpecs       int              `json:"flaky_specs"`
+	LongFailingSpecs int              `json:"long_failing_specs"`
+	DurationChange   *float64         `json:"duration_change"`
+	LastRunTime      *time.Time       `json:"last_run_time"`
+}
+
+type TeamHealth struct {
+	TeamName string   `json:"team_name"`
+	Score    *float64 `json:"score"`
+	Projects int      `json:"projects"`
+}
+
 type TestSummary struct {
 	SuiteRunID           uint
 	SuiteName            string
@@ -68,19 +386,33 @@ type TestSummary struct {
 
 type Tag struct {
 	ID       uint64 `json:"id" gorm:"primaryKey"`
-	Name     string `json:"name"`
+	Name     string `json:"name" gorm:"uniqueIndex:idx_tags_name"`
 	Category string `json:"category"`
 	Value    string `json:"value"`
 }
 
+// DefaultOrganizationID is the organization that projects created before
+// organizations were introduced belong to, as do callers whose token names
+// no organization.
+const DefaultOrganizationID uint64 = 1
+
+// Organization separates the projects of business units sharing one Fern.
+// Project names are only unique within an organization.
+type Organization struct {
+	ID        uint64    `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"unique"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
 type ProjectDetails struct {
-	ID        uint64    `json:"-" gorm:"primaryKey"`
-	UUID      string    `json:"uuid" gorm:"->;column:uuid"`
-	Name      string    `json:"name"`
-	TeamName  string    `json:"team_name"`
-	Comment   string    `json:"comment"`
-	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID             uint64    `json:"-" gorm:"primaryKey"`
+	UUID           string    `json:"uuid" gorm:"->;column:uuid"`
+	Name           string    `json:"name"`
+	TeamName       string    `json:"team_name"`
+	Comment        string    `json:"comment"`
+	OrganizationID uint64    `json:"-" gorm:"default:1"`
+	CreatedAt      time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 type PreferredProject struct {
@@ -1,10 +1,14 @@
 package utils
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/guidewire/fern-reporter/pkg/models"
@@ -15,8 +19,17 @@ const (
 	StatusSkipped    = "skipped"
 	StatusPassed     = "passed"
 	StatusFailed     = "failed"
+	StatusInProgress = "in_progress"
+	StatusAbandoned  = "abandoned"
+	StatusQueued     = "queued"
+	StatusProcessing = "processing"
+	StatusDone       = "done"
 )
 
+// FailedStatuses lists every spec status that counts as a failure. Ginkgo
+// reports panics, timeouts and interruptions separately from plain failures.
+var FailedStatuses = []string{StatusFailed, "panicked", "timedout", "interrupted", "aborted"}
+
 type ApiResponse[T any] struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
@@ -72,6 +85,51 @@ func CalculateTestMetrics(testRuns []models.TestRun) (totalTests, executedTests,
 	return
 }
 
+// NormalizeTestName trims a suite name or spec description and collapses runs
+// of whitespace so that formatting changes do not split a test's history.
+func NormalizeTestName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// TestCaseFingerprint identifies a test across runs of a project. It must stay
+// in step with the backfill in the test_cases migration, which computes the
+// same hash in SQL.
+func TestCaseFingerprint(projectID uint64, suiteName string, specDescription string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d\n%s\n%s", projectID, NormalizeTestName(suiteName), NormalizeTestName(specDescription))))
+	return hex.EncodeToString(sum[:])
+}
+
+// failureMessageMasks replace the parts of a failure message that change from
+// run to run. They are applied in order, so the specific patterns come before
+// the catch-all for numbers.
+var failureMessageMasks = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<uuid>"},
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`), "<time>"},
+	{regexp.MustCompile(`\b\d{1,2}:\d{2}:\d{2}(\.\d+)?\b`), "<time>"},
+	{regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b`), "<addr>"},
+	{regexp.MustCompile(`(\.[A-Za-z]+):\d+(:\d+)?\b`), "$1:<line>"},
+	{regexp.MustCompile(`\d+`), "<n>"},
+}
+
+// NormalizeFailureMessage masks the UUIDs, timestamps, addresses, file line
+// numbers and other numbers in a failure message so that failures with the
+// same cause read the same.
+func NormalizeFailureMessage(message string) string {
+	for _, mask := range failureMessageMasks {
+		message = mask.pattern.ReplaceAllString(message, mask.replacement)
+	}
+	return strings.Join(strings.Fields(message), " ")
+}
+
+// FailureSignature identifies failures with the same normalized message.
+func FailureSignature(message string) string {
+	sum := sha256.Sum256([]byte(NormalizeFailureMessage(message)))
+	return hex.EncodeToString(sum[:])
+}
+
 func EncodeCursor(offset int) string {
 	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("cursor%d", offset)))
 }
@@ -33,6 +33,45 @@ var _ = Describe("Utils Test", func() {
 		})
 	})
 
+	Describe("TestCaseFingerprint", func() {
+		It("should hash the project and normalized names", func() {
+			// Must match the backfill in the test_cases migration
+			Expect(utils.TestCaseFingerprint(1, "Cart", "adds items")).To(Equal("2fefc4c37441402c40beddec8ec1cc239247850ce21236027eae2fc8f6e7276b"))
+		})
+
+		It("should ignore whitespace differences", func() {
+			Expect(utils.TestCaseFingerprint(1, " Cart\n", "adds \t items ")).To(Equal(utils.TestCaseFingerprint(1, "Cart", "adds items")))
+		})
+
+		It("should differ between projects", func() {
+			Expect(utils.TestCaseFingerprint(1, "Cart", "adds items")).NotTo(Equal(utils.TestCaseFingerprint(2, "Cart", "adds items")))
+		})
+	})
+
+	Describe("NormalizeFailureMessage", func() {
+		It("should mask the parts of a message that change between runs", func() {
+			Expect(utils.NormalizeFailureMessage("Expected <int>: 42 to equal <int>: 7 at /src/cart_test.go:123")).
+				To(Equal("Expected <int>: <n> to equal <int>: <n> at /src/cart_test.go:<line>"))
+			Expect(utils.NormalizeFailureMessage("order 3F2B8C1E-1D2A-4B5C-9E8F-0A1B2C3D4E5F failed at 2025-06-01T12:00:03.123Z")).
+				To(Equal("order <uuid> failed at <time>"))
+			Expect(utils.NormalizeFailureMessage("invalid memory address 0xc000123abc in main.go:7:12")).
+				To(Equal("invalid memory address <addr> in main.go:<line>"))
+			Expect(utils.NormalizeFailureMessage("timed out after 12:03:44\n\twaiting for port 8080")).
+				To(Equal("timed out after <time> waiting for port <n>"))
+		})
+	})
+
+	Describe("FailureSignature", func() {
+		It("should match messages that only differ in masked parts", func() {
+			Expect(utils.FailureSignature("connection refused on port 5432 after 3 retries")).
+				To(Equal(utils.FailureSignature("connection refused on port 5433 after 10 retries")))
+		})
+
+		It("should differ between messages with different text", func() {
+			Expect(utils.FailureSignature("connection refused")).NotTo(Equal(utils.FailureSignature("connection reset")))
+		})
+	})
+
 	Describe("CalculateTestMetrics", func() {
 		var (
 			testRuns []models.TestRun